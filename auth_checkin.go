@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -22,7 +23,7 @@ import (
 //   }
 type CheckinRequest struct {
 	// Mandatory parameters
-	BeerID    int
+	BeerID    int64
 	GMTOffset int
 	TimeZone  string
 
@@ -48,9 +49,18 @@ type CheckinRequest struct {
 // A variety of struct members can be filled in to specify the rating,
 // comment, etc. for a checkin.
 func (a *AuthService) Checkin(r CheckinRequest) (*Checkin, *http.Response, error) {
+	return a.checkin(context.Background(), r)
+}
+
+// checkin is the context-aware implementation of Checkin.
+func (a *AuthService) checkin(ctx context.Context, r CheckinRequest) (*Checkin, *http.Response, error) {
+	if err := r.validate(); err != nil {
+		return nil, nil, err
+	}
+
 	// Add required parameters
 	q := url.Values{
-		"bid":        []string{strconv.Itoa(r.BeerID)},
+		"bid":        []string{strconv.FormatInt(r.BeerID, 10)},
 		"gmt_offset": []string{strconv.Itoa(r.GMTOffset)},
 		"timezone":   []string{r.TimeZone},
 	}
@@ -89,10 +99,15 @@ func (a *AuthService) Checkin(r CheckinRequest) (*Checkin, *http.Response, error
 	}
 
 	// Perform request to check in a beer
-	res, err := a.client.request("POST", "checkin/add", q, nil, &v)
+	res, err := a.client.requestContext(ctx, "POST", "checkin/add", q, nil, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return v.Response.export(), res, nil
+	checkin := v.Response.export()
+	if err := a.client.afterResponse(res, checkin); err != nil {
+		return nil, res, err
+	}
+
+	return checkin, res, nil
 }
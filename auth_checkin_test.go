@@ -12,8 +12,8 @@ import (
 // TestClientAuthCheckinOK verifies that Client.Auth.Checkin always sets the
 // appropriate POST body parameters for a valid checkin.
 func TestClientAuthCheckinOK(t *testing.T) {
-	beerID := 1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
 	timezone, offset := time.Now().Zone()
 	offset = offset / 60 / 60
@@ -76,7 +76,7 @@ func TestClientAuthCheckinOK(t *testing.T) {
 // TestClientAuthCheckinBadBeerID verifies that Client.Auth.Checkin returns an
 // error when an invalid beer ID is checked-in.
 func TestClientAuthCheckinBadBeerID(t *testing.T) {
-	beerID := -1
+	beerID := int64(-1)
 
 	c, done := authCheckinTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -15,9 +15,9 @@ import (
 // checkins.  For more granular control, and to page through the checkins
 // list using ID parameters, use CheckinsMinMaxIDLimit instead.
 func (a *AuthService) Checkins() ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return a.CheckinsMinMaxIDLimit(0, math.MaxInt32, 25)
+	// Use default parameters as specified by API, with no upper bound on
+	// checkin IDs so that no recent checkins are excluded.
+	return a.CheckinsMinMaxIDLimit(0, math.MaxInt64, 25)
 }
 
 // CheckinsMinMaxIDLimit queries for information about checkins from friends
@@ -26,12 +26,23 @@ func (a *AuthService) Checkins() ([]*Checkin, *http.Response, error) {
 // This is akin to the "Recent Friend Activity" feed displayed on the homepage
 // of Untappd for an authenticated user.
 //
+// If maxID is math.MaxInt64, no upper bound is sent.
+//
 // 50 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (a *AuthService) CheckinsMinMaxIDLimit(minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	return a.client.getCheckins("checkin/recent", url.Values{
-		"min_id": []string{strconv.Itoa(minID)},
-		"max_id": []string{strconv.Itoa(maxID)},
+// one call.  If limit is greater than 50, the checkins are fetched using
+// multiple calls, and combined.
+func (a *AuthService) CheckinsMinMaxIDLimit(minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkMinMaxIDLimit(minID, maxID, limit); err != nil {
+		return nil, nil, err
+	}
+
+	q := url.Values{
+		"min_id": []string{strconv.FormatInt(minID, 10)},
 		"limit":  []string{strconv.Itoa(limit)},
-	})
+	}
+	if maxID != math.MaxInt64 {
+		q.Set("max_id", strconv.FormatInt(maxID, 10))
+	}
+
+	return a.client.getCheckinsLimit("checkin/recent", q, limit, maxCheckinsLimit)
 }
@@ -10,16 +10,14 @@ import (
 )
 
 // TestClientAuthCheckinsOK verifies that Client.Auth.Checkins always sets the
-// appropriate default minimum ID, maximum ID, and limit values.
+// appropriate default minimum ID and limit values, and no maximum ID.
 func TestClientAuthCheckinsOK(t *testing.T) {
 	minID := "0"
-	maxID := strconv.Itoa(math.MaxInt32)
 	limit := "25"
 
 	c, done := authCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		assertParameters(t, r, url.Values{
 			"min_id": []string{minID},
-			"max_id": []string{maxID},
 			"limit":  []string{limit},
 		})
 
@@ -36,11 +34,11 @@ func TestClientAuthCheckinsOK(t *testing.T) {
 // TestClientAuthCheckinsMinMaxIDLimitOK verifies that Client.Auth.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientAuthCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	sMinID := strconv.Itoa(minID)
+	var minID int64
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = math.MaxInt32
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
@@ -0,0 +1,47 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// maxCommentLength is the maximum number of characters in a comment
+	// on a checkin.
+	maxCommentLength = 140
+)
+
+// Comment adds a comment to the checkin with the input ID, on behalf of the
+// authenticated user.  Comments must not be empty, and may contain up to
+// 140 characters.
+func (a *AuthService) Comment(checkinID int64, comment string) (*http.Response, error) {
+	return a.comment(context.Background(), checkinID, comment)
+}
+
+// comment is the context-aware implementation of Comment.
+func (a *AuthService) comment(ctx context.Context, checkinID int64, comment string) (*http.Response, error) {
+	if err := checkComment(checkinID, comment); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{"comment": []string{comment}}
+	return a.client.requestContext(ctx, "POST", "checkin/addcomment/"+strconv.FormatInt(checkinID, 10), q, nil, nil)
+}
+
+// checkComment validates the parameters of a comment on a checkin.
+func checkComment(checkinID int64, comment string) error {
+	if err := checkCheckinID(checkinID); err != nil {
+		return err
+	}
+	if comment == "" {
+		return paramErrorf("comment", "must not be empty")
+	}
+	if n := utf8.RuneCountInString(comment); n > maxCommentLength {
+		return paramErrorf("comment", "%d characters exceeds maximum of %d", n, maxCommentLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package untappd
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestClientAuthComment verifies that Client.Auth.Comment sends a POST
+// request with the comment, and rejects invalid comments.
+func TestClientAuthComment(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		method := "POST"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		path := "/v4/checkin/addcomment/1/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected path: %q != %q", p, path)
+		}
+
+		assertBodyParameters(t, r, url.Values{
+			"comment": []string{"great beer"},
+		})
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, err := c.Auth.Comment(1, "great beer"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, comment := range []string{"", strings.Repeat("🍺", maxCommentLength+1)} {
+		if _, err := c.Auth.Comment(1, comment); !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("unexpected error for invalid comment %q: %v", comment, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Toast toasts the checkin with the input ID, on behalf of the authenticated
+// user.  The Untappd APIv4 toggles toasts, so toasting a checkin which the
+// authenticated user has already toasted removes the toast.
+func (a *AuthService) Toast(checkinID int64) (*http.Response, error) {
+	return a.toast(context.Background(), checkinID)
+}
+
+// toast is the context-aware implementation of Toast.
+func (a *AuthService) toast(ctx context.Context, checkinID int64) (*http.Response, error) {
+	if err := checkCheckinID(checkinID); err != nil {
+		return nil, err
+	}
+
+	return a.client.requestContext(ctx, "POST", "checkin/toast/"+strconv.FormatInt(checkinID, 10), nil, nil, nil)
+}
+
+// checkCheckinID validates the ID of a checkin which is toasted or commented
+// on.
+func checkCheckinID(checkinID int64) error {
+	if checkinID < 1 {
+		return paramErrorf("checkin_id", "%d must be positive", checkinID)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package untappd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestClientAuthToast verifies that Client.Auth.Toast sends a POST request to
+// toast the checkin with the input ID.
+func TestClientAuthToast(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		method := "POST"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		path := "/v4/checkin/toast/1/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected path: %q != %q", p, path)
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if _, err := c.Auth.Toast(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Auth.Toast(0); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("unexpected error for invalid checkin ID: %v", err)
+	}
+}
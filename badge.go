@@ -9,8 +9,8 @@ import (
 // description, when it was earned, and various media associated with the badge.
 type Badge struct {
 	// Metadata from Untappd.
-	ID          int
-	CheckinID   int
+	ID          int64
+	CheckinID   int64
 	Name        string
 	Description string
 	Hint        string
@@ -22,30 +22,52 @@ type Badge struct {
 	// If applicable, time when the specified user earned this badge.
 	Earned time.Time
 
+	// Is this a badge with multiple levels?
+	IsLevel bool
+
+	// If applicable, the highest level of this badge which the specified
+	// user has obtained, and the total number of levels for this badge.
+	// TotalLevels is zero if the API did not report the number of levels.
+	Level       int
+	TotalLevels int
+
 	// If applicable, badge levels which the specified user has obtained.
 	// If the slice has zero length, no levels exist for this badge.
 	Levels []*Badge
 }
 
 // BadgeMedia contains links to media regarding a Badge.  Included are links
-// to a small, medium, and large image for a given Badge.
+// to a small, medium, large, and high resolution image for a given Badge.
 type BadgeMedia struct {
 	SmallImage  url.URL
 	MediumImage url.URL
 	LargeImage  url.URL
+	HDImage     url.URL
+}
+
+// Images returns the images of a Badge as an ImageSet.
+func (m BadgeMedia) Images() ImageSet {
+	return newImageSet(
+		Image{Width: imageWidthSmall, URL: m.SmallImage},
+		Image{Width: imageWidthMedium, URL: m.MediumImage},
+		Image{Width: imageWidthLarge, URL: m.LargeImage},
+		Image{Width: imageWidthOriginal, URL: m.HDImage},
+	)
 }
 
 // rawBadge is the raw JSON representation of an Untappd badge.  Its data is
 // unmarshaled from JSON and then exported to a Badge struct.
 type rawBadge struct {
-	ID          int                 `json:"badge_id"`
-	CheckinID   int                 `json:"checkin_id"`
+	ID          int64               `json:"badge_id"`
+	CheckinID   int64               `json:"checkin_id"`
 	Name        string              `json:"badge_name"`
 	Description string              `json:"badge_description"`
 	Hint        string              `json:"badge_hint"`
-	Active      responseBool        `json:"badge_active_status"`
+	Active      Bool                `json:"badge_active_status"`
 	Media       rawBadgeMedia       `json:"media"`
-	Earned      responseTime        `json:"created_at"`
+	Earned      Time                `json:"created_at"`
+	IsLevel     bool                `json:"is_level"`
+	TotalLevels int                 `json:"total_levels"`
 	Levels      responseBadgeLevels `json:"levels"`
 }
 
@@ -61,6 +83,9 @@ func (r *rawBadge) export() *Badge {
 		Active:      bool(r.Active),
 		Media:       r.Media.export(),
 		Earned:      time.Time(r.Earned),
+		IsLevel:     r.IsLevel,
+		Level:       r.Levels.Count,
+		TotalLevels: r.TotalLevels,
 	}
 
 	// Export badge levels as a slice of badges belonging to parent badge
@@ -76,9 +101,10 @@ func (r *rawBadge) export() *Badge {
 // rawBadgeMedia is the raw JSON representation of Untappd badge media.  Its data is
 // unmarshaled from JSON and then exported to a BadgeMedia struct.
 type rawBadgeMedia struct {
-	SmallImage  responseURL `json:"badge_image_sm"`
-	MediumImage responseURL `json:"badge_image_md"`
-	LargeImage  responseURL `json:"badge_image_lg"`
+	SmallImage  URL `json:"badge_image_sm"`
+	MediumImage URL `json:"badge_image_md"`
+	LargeImage  URL `json:"badge_image_lg"`
+	HDImage     URL `json:"badge_image_hd"`
 }
 
 // export creates an exported BadgeMedia from a rawBadgeMedia struct, allowing
@@ -88,5 +114,6 @@ func (r *rawBadgeMedia) export() BadgeMedia {
 		SmallImage:  url.URL(r.SmallImage),
 		MediumImage: url.URL(r.MediumImage),
 		LargeImage:  url.URL(r.LargeImage),
+		HDImage:     url.URL(r.HDImage),
 	}
 }
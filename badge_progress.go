@@ -0,0 +1,199 @@
+package untappd
+
+import (
+	"strings"
+)
+
+// A BadgeRule describes how progress toward the levels of an Untappd badge
+// can be determined from a User's checkin history.  Untappd does not publish
+// the exact requirements of its badges, so a BadgeRule is an estimate based
+// on each badge's description.
+type BadgeRule struct {
+	// Name of the badge, compared case-insensitively with Badge.Name.
+	Name string
+
+	// Count returns the User's progress toward the badge, such as the
+	// number of distinct countries, from the User's checkins.
+	Count func(checkins []*Checkin) int
+
+	// First is the count required for the first level of the badge, and
+	// Step is the additional count required for each further level.
+	First int
+	Step  int
+}
+
+// required returns the count required for the input level of a BadgeRule,
+// starting at one.
+func (r BadgeRule) required(level int) int {
+	return r.First + (level-1)*r.Step
+}
+
+// DefaultBadgeRules returns BadgeRules for common Untappd badges whose
+// progress can be determined from checkin history.
+func DefaultBadgeRules() []BadgeRule {
+	return []BadgeRule{
+		{
+			// Beers from breweries in different countries
+			Name:  "Around the World",
+			Count: CountDistinctCountries,
+			First: 5,
+			Step:  5,
+		},
+		{
+			// Different stouts and porters
+			Name: "Heavy Weight",
+			Count: func(checkins []*Checkin) int {
+				return CountDistinctBeers(checkins, StyleStout, StylePorter)
+			},
+			First: 5,
+			Step:  5,
+		},
+	}
+}
+
+// A BadgeProgress reports a User's estimated progress toward the next level
+// of a badge, as returned by BadgeProgressReport.
+type BadgeProgress struct {
+	// Name of the badge, and the Badge itself, if the User has earned it.
+	Name  string
+	Badge *Badge
+
+	// Highest level of the badge the User has earned, or zero if the User
+	// has not earned the badge.
+	Level int
+
+	// The User's current count toward the badge, the count required for
+	// the next level, and the difference between them.  If the User has
+	// earned every level of the badge, Next and Remaining are zero.
+	Count     int
+	Next      int
+	Remaining int
+}
+
+// BadgeProgressReport estimates a User's progress toward the next level of
+// each badge described by the input BadgeRules, using the User's earned
+// badges and checkin history.  If rules is nil, DefaultBadgeRules is used.
+//
+// A badge's current level is taken from the earned Badge, if present, and
+// otherwise estimated from the count.  The result is ordered as rules.
+func BadgeProgressReport(badges []*Badge, checkins []*Checkin, rules []BadgeRule) []*BadgeProgress {
+	if rules == nil {
+		rules = DefaultBadgeRules()
+	}
+
+	out := make([]*BadgeProgress, 0, len(rules))
+	for _, r := range rules {
+		p := &BadgeProgress{
+			Name:  r.Name,
+			Count: r.Count(checkins),
+		}
+
+		for _, b := range badges {
+			if b != nil && strings.EqualFold(b.Name, r.Name) {
+				p.Badge = b
+				break
+			}
+		}
+
+		switch {
+		case p.Badge != nil:
+			p.Level = p.Badge.Level
+			if p.Level == 0 {
+				// Badges without levels are earned once
+				p.Level = 1
+			}
+		case r.First > 0 && p.Count >= r.First:
+			// Estimate the level which the count should have earned
+			p.Level = 1
+			if r.Step > 0 {
+				p.Level += (p.Count - r.First) / r.Step
+			}
+		}
+
+		p.Next = r.required(p.Level + 1)
+		if max := p.maxLevel(); (max > 0 && p.Level >= max) || (p.Level > 0 && r.Step <= 0) {
+			p.Next = 0
+		}
+		if p.Next > p.Count {
+			p.Remaining = p.Next - p.Count
+		}
+
+		out = append(out, p)
+	}
+
+	return out
+}
+
+// maxLevel returns the number of levels of a badge, or zero if unknown.
+func (p *BadgeProgress) maxLevel() int {
+	if p.Badge == nil {
+		return 0
+	}
+	if !p.Badge.IsLevel {
+		return 1
+	}
+
+	return p.Badge.TotalLevels
+}
+
+// BadgeProgress estimates the User's progress toward each badge described by
+// the input BadgeRules, using BadgeProgressReport with the Library's badges
+// and checkins.
+func (l *Library) BadgeProgress(rules []BadgeRule) []*BadgeProgress {
+	return BadgeProgressReport(l.Badges, l.Checkins, rules)
+}
+
+// CountDistinctCountries returns the number of distinct countries of the
+// breweries of the input checkins.  Checkins whose brewery country is not
+// known are ignored.
+func CountDistinctCountries(checkins []*Checkin) int {
+	countries := make(map[string]struct{})
+	for _, c := range checkins {
+		if c == nil {
+			continue
+		}
+
+		b := c.Brewery
+		if b == nil && c.Beer != nil {
+			b = c.Beer.Brewery
+		}
+		if b == nil || b.Country == "" {
+			continue
+		}
+
+		// Group alternative names of the same country, such as "USA" and
+		// "United States"
+		key := strings.ToLower(b.Country)
+		if code, ok := CountryCode(b.Country); ok {
+			key = code
+		}
+		countries[key] = struct{}{}
+	}
+
+	return len(countries)
+}
+
+// CountDistinctBeers returns the number of distinct beers in the input
+// checkins which belong to any of the input Style families.  If no styles
+// are specified, all beers are counted.
+func CountDistinctBeers(checkins []*Checkin, styles ...Style) int {
+	beers := make(map[int64]struct{})
+	for _, c := range checkins {
+		if c == nil || c.Beer == nil {
+			continue
+		}
+
+		match := len(styles) == 0
+		for _, s := range styles {
+			if ParseStyle(c.Beer.Style) == s {
+				match = true
+				break
+			}
+		}
+		if match {
+			beers[c.Beer.ID] = struct{}{}
+		}
+	}
+
+	return len(beers)
+}
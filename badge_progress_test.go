@@ -0,0 +1,44 @@
+package untappd
+
+import "testing"
+
+// TestBadgeProgressReport verifies that BadgeProgressReport estimates the
+// progress toward the next level of each badge.
+func TestBadgeProgressReport(t *testing.T) {
+	var checkins []*Checkin
+	for i, country := range []string{"United States", "Belgium", "Germany", "Belgium"} {
+		checkins = append(checkins, &Checkin{
+			Beer:    &Beer{ID: int64(i), Style: "Stout - Imperial / Double"},
+			Brewery: &Brewery{Country: country},
+		})
+	}
+	checkins = append(checkins, &Checkin{
+		Beer:    &Beer{ID: 10, Style: "Porter - American"},
+		Brewery: &Brewery{Country: "England"},
+	})
+
+	badges := []*Badge{
+		{Name: "Heavy Weight", IsLevel: true, Level: 1, TotalLevels: 10},
+	}
+
+	report := BadgeProgressReport(badges, checkins, nil)
+	if l := len(report); l != 2 {
+		t.Fatalf("unexpected number of reports: %d != %d", l, 2)
+	}
+
+	world := report[0]
+	if world.Badge != nil || world.Level != 0 || world.Count != 4 || world.Next != 5 || world.Remaining != 1 {
+		t.Fatalf("unexpected Around the World progress: %+v", world)
+	}
+
+	heavy := report[1]
+	if heavy.Badge == nil || heavy.Level != 1 || heavy.Count != 5 || heavy.Next != 10 || heavy.Remaining != 5 {
+		t.Fatalf("unexpected Heavy Weight progress: %+v", heavy)
+	}
+
+	// A badge at its final level has no next level
+	badges[0].Level = 10
+	if p := BadgeProgressReport(badges, checkins, nil)[1]; p.Next != 0 || p.Remaining != 0 {
+		t.Fatalf("unexpected progress for final level: %+v", p)
+	}
+}
@@ -0,0 +1,189 @@
+package untappd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// A BatchError is returned by batch methods, such as BeerService.InfoBatch,
+// when one or more items in the batch could not be retrieved.  It records
+// the error which occurred for each failed ID, so that callers may retry
+// only the failed subset.
+//
+// The errors are of the same types returned by single-item methods, such as
+// *Error for API errors like HTTP 404 or 429, or *DecodeError for responses
+// which cannot be decoded.
+type BatchError struct {
+	Errors map[int64]error
+}
+
+// Error returns the string representation of a BatchError.
+func (e *BatchError) Error() string {
+	ids := e.Failed()
+	if len(ids) == 1 {
+		return fmt.Sprintf("batch: ID %d failed: %v", ids[0], e.Errors[ids[0]])
+	}
+
+	return fmt.Sprintf("batch: %d IDs failed, first ID %d: %v", len(ids), ids[0], e.Errors[ids[0]])
+}
+
+// Unwrap returns the errors for each failed ID, ordered by ID, so that
+// errors.Is and errors.As may be used to inspect them.
+func (e *BatchError) Unwrap() []error {
+	ids := e.Failed()
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs, e.Errors[id])
+	}
+
+	return errs
+}
+
+// Failed returns the IDs which failed, in ascending order.
+func (e *BatchError) Failed() []int64 {
+	ids := make([]int64, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// InfoBatch queries for information about each of the Beers with the
+// specified IDs, as with Info.  Duplicate IDs are queried only once.
+//
+// Several requests are performed in parallel.  Parallelism is reduced as the
+// Client's remaining rate limit shrinks, and once the limit is exhausted,
+// InfoBatch pauses until it resets, rather than failing for the remaining
+// IDs with HTTP 429 responses.
+//
+// The returned map contains each Beer which was retrieved successfully, keyed
+// by ID.  If any ID fails, a *BatchError is also returned, which records the
+// error for each failed ID.  Once the context is canceled, each remaining ID
+// fails with the context's error.
+func (b *BeerService) InfoBatch(ctx context.Context, ids []int64, compact bool) (map[int64]*Beer, error) {
+	var (
+		mu    sync.Mutex
+		beers = make(map[int64]*Beer, len(ids))
+	)
+
+	err := b.client.runBatch(ctx, ids, func(id int64) error {
+		beer, _, err := b.info(ctx, id, compact)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		beers[id] = beer
+		return nil
+	})
+
+	return beers, err
+}
+
+// InfoBatch queries for information about each of the Venues with the
+// specified IDs, as with Info.  Requests are performed and errors are
+// reported as with BeerService.InfoBatch.
+func (v *VenueService) InfoBatch(ctx context.Context, ids []int64, compact bool) (map[int64]*Venue, error) {
+	var (
+		mu     sync.Mutex
+		venues = make(map[int64]*Venue, len(ids))
+	)
+
+	err := v.client.runBatch(ctx, ids, func(id int64) error {
+		venue, _, err := v.info(ctx, id, compact)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		venues[id] = venue
+		return nil
+	})
+
+	return venues, err
+}
+
+// HydrateVenues replaces the partial venue information embedded in each of
+// the input checkins, such as those returned by activity feeds, with the
+// information returned by Info.  Each venue is queried only once, using
+// InfoBatch, and checkins at the same venue share a single Venue.  Requests
+// are subject to the Client's ServicePolicy for ServiceVenue, so venues may
+// be served from the Client's response cache.
+//
+// Checkins without a venue are ignored.  If any venue cannot be retrieved, a
+// *BatchError is returned, and checkins at that venue retain their partial
+// venue information.
+func (v *VenueService) HydrateVenues(ctx context.Context, checkins []*Checkin, compact bool) error {
+	var ids []int64
+	for _, c := range checkins {
+		if c != nil && c.Venue != nil {
+			ids = append(ids, c.Venue.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	venues, err := v.InfoBatch(ctx, ids, compact)
+	for _, c := range checkins {
+		if c == nil || c.Venue == nil {
+			continue
+		}
+
+		if venue, ok := venues[c.Venue.ID]; ok {
+			c.Venue = venue
+		}
+	}
+
+	return err
+}
+
+// runBatch invokes fn for each of the unique input IDs, performing several
+// invocations in parallel according to the Client's remaining rate limit, as
+// described by BeerService.InfoBatch.  If any invocation fails, or the
+// context is canceled before an invocation starts, a *BatchError is returned
+// which records the error for each failed ID.
+func (c *Client) runBatch(ctx context.Context, ids []int64, fn func(id int64) error) error {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	var (
+		mu   sync.Mutex
+		berr = &BatchError{Errors: make(map[int64]error)}
+	)
+
+	c.runAdaptive(ctx, len(unique), maxBatchConcurrency, func(i int) {
+		id := unique[i]
+
+		err := ctx.Err()
+		if err == nil {
+			err = fn(id)
+		}
+		if err == nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		berr.Errors[id] = err
+	})
+
+	if len(berr.Errors) > 0 {
+		return berr
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestClientBeerInfoBatch verifies that Client.Beer.InfoBatch returns each
+// Beer which was retrieved, and a *BatchError recording each failed ID.
+func TestClientBeerInfoBatch(t *testing.T) {
+	var requests int32
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v4/beer/info/1":
+			w.Write(blackNoteBeerJSON)
+		case "/v4/beer/info/2":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"meta":{"code":404,"error_detail":"Beer not found","error_type":"invalid_param"}}`))
+		case "/v4/beer/info/3":
+			w.Write([]byte(`{"response":{"beer":{"created_at":"foo"}}}`))
+		}
+	})
+	defer done()
+
+	beers, err := c.Beer.InfoBatch(context.Background(), []int64{3, 1, 2, 1}, false)
+
+	if requests != 3 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 3)
+	}
+	if l := len(beers); l != 1 || beers[1] == nil {
+		t.Fatalf("unexpected beers: %v", beers)
+	}
+
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int64{2, 3}; !reflect.DeepEqual(berr.Failed(), want) {
+		t.Fatalf("unexpected failed IDs: %v != %v", berr.Failed(), want)
+	}
+
+	var uerr *Error
+	if !errors.As(berr.Errors[2], &uerr) || uerr.Code != http.StatusNotFound {
+		t.Fatalf("unexpected error for ID 2: %v", berr.Errors[2])
+	}
+	var derr *DecodeError
+	if !errors.As(berr.Errors[3], &derr) {
+		t.Fatalf("unexpected error for ID 3: %v", berr.Errors[3])
+	}
+	if !errors.As(err, &derr) {
+		t.Fatal("expected BatchError to unwrap to *DecodeError")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Beer.InfoBatch(ctx, []int64{1}, false); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error for canceled context: %v", err)
+	}
+}
+
+// TestClientVenueHydrateVenues verifies that Client.Venue.HydrateVenues
+// queries each unique venue once, and replaces the partial venues of
+// checkins with those which were retrieved.
+func TestClientVenueHydrateVenues(t *testing.T) {
+	var requests int32
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v4/venue/info/1021":
+			w.Write(venueJSON)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(invalidVenueErrJSON)
+		}
+	})
+	defer done()
+
+	checkins := []*Checkin{
+		{ID: 1, Venue: &Venue{ID: 1021, Name: "Bell's"}},
+		{ID: 2},
+		{ID: 3, Venue: &Venue{ID: 1021, Name: "Bell's"}},
+		{ID: 4, Venue: &Venue{ID: 2, Name: "Unknown"}},
+		nil,
+	}
+
+	err := c.Venue.HydrateVenues(context.Background(), checkins, false)
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int64{2}; !reflect.DeepEqual(berr.Failed(), want) {
+		t.Fatalf("unexpected failed IDs: %v != %v", berr.Failed(), want)
+	}
+	if requests != 2 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 2)
+	}
+
+	if v := checkins[0].Venue; v.Category != "Nightlife Spot" || v != checkins[2].Venue {
+		t.Fatalf("unexpected hydrated venues: %+v, %+v", v, checkins[2].Venue)
+	}
+	if checkins[1].Venue != nil {
+		t.Fatal("expected checkin without a venue to remain without a venue")
+	}
+	if n := checkins[3].Venue.Name; n != "Unknown" {
+		t.Fatalf("unexpected partial venue Name: %q", n)
+	}
+}
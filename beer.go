@@ -1,6 +1,8 @@
 package untappd
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/url"
 	"time"
 )
@@ -17,31 +19,54 @@ type BeerService struct {
 // member.
 type Beer struct {
 	// Metadata from Untappd.
-	ID          int
+	ID          int64
 	Name        string
-	Label       url.URL
-	ABV         float64
+	ABV         float64 // a percentage, such as 5.5, with up to two decimal places
 	IBU         int
 	Slug        string
 	Style       string
 	Description string
 
+	// Untappd ID of Style, and its normalized family, which is stable even
+	// when Untappd changes the name of the style.
+	StyleID     int64
+	StyleFamily Style
+
+	// Images of this beer's label, in standard and high resolution.
+	Labels ImageSet
+
+	// URL of this beer's standard resolution label, derived from Labels
+	// using Labels.BestFor(100).
+	//
+	// Deprecated: use Labels.
+	Label url.URL
+
 	// Time when this beer was added to Untappd.
 	Created time.Time
 
+	// Is this beer a homebrew, and is it still in production?  Beers are
+	// assumed to be in production unless Untappd reports otherwise.
+	Homebrew     bool
+	InProduction bool
+
 	// Is this beer present in the specified user's wish list?
 	WishList bool
 
-	// Global Untappd rating for this beer.
-	OverallRating float64
+	// Global Untappd rating for this beer, including the number of ratings
+	// and, if applicable, the authenticated user's rating.
+	Rating Rating
 
 	// For beer search requests this is the global checkin count, for beer info
 	// requests this is the rating count.
 	OverallCount int
 
-	// If applicable, the specified user's rating for this beer.
+	// If applicable, the specified user's rating for this beer, from 0.25
+	// to 5 in increments of 0.25.
 	UserRating float64
 
+	// If available, checkin statistics for this beer.
+	Stats BeerStats
+
 	// If applicable, time when the specified user first, or most recently
 	// checked in this beer.
 	FirstHad  time.Time
@@ -55,26 +80,87 @@ type Beer struct {
 	// in this beer.
 	Count int
 
+	// If applicable, has the specified user checked in this beer?
+	HaveHad bool
+
 	// If available, information regarding the brewery which created
 	// this beer.
 	Brewery *Brewery
+
+	// If the request was authenticated, the authenticated user's friends
+	// who have had this beer.  Friends are only reported by
+	// BeerService.Info.
+	Friends []FriendRating
+}
+
+// FriendsRating returns the average rating of this beer among the friends in
+// Friends who have rated it, and the number of such friends.  If no friends
+// have rated this beer, it returns zero for both.
+func (b *Beer) FriendsRating() (average float64, count int) {
+	var sum float64
+	for _, f := range b.Friends {
+		if f.Rating == 0 {
+			continue
+		}
+
+		sum += f.Rating
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+
+	return sum / float64(count), count
+}
+
+// FriendRating is a friend of the authenticated user who has had a beer.
+type FriendRating struct {
+	User *User
+
+	// The friend's rating for the beer, from 0.25 to 5 in increments of
+	// 0.25, or zero if the friend has not rated it.
+	Rating float64
+
+	// Time of the friend's checkin of the beer.
+	Created time.Time
+}
+
+// BeerStats contains checkin statistics for an Untappd beer.
+type BeerStats struct {
+	// Total number of checkins and number of checkins this month.
+	TotalCount   int `json:"total_count"`
+	MonthlyCount int `json:"monthly_count"`
+
+	// Number of unique users who checked in this beer.
+	TotalUserCount int `json:"total_user_count"`
+
+	// If applicable, number of times the authenticated user has checked
+	// in this beer.
+	UserCount int `json:"user_count"`
 }
 
 // rawBeer is the raw JSON representation of an Untappd beer.  Its data is
 // unmarshaled from JSON and then exported to a Beer struct.
 type rawBeer struct {
-	ID            int          `json:"bid"`
-	Name          string       `json:"beer_name"`
-	Label         responseURL  `json:"beer_label"`
-	ABV           float64      `json:"beer_abv"`
-	IBU           int          `json:"beer_ibu"`
-	Slug          string       `json:"beer_slug"`
-	Style         string       `json:"beer_style"`
-	Description   string       `json:"beer_description"`
-	Created       responseTime `json:"created_at"`
-	WishList      bool         `json:"wish_list"`
-	OverallRating float64      `json:"rating_score"`
-	OverallCount  int          `json:"rating_count"`
+	ID           int64      `json:"bid"`
+	Name         string     `json:"beer_name"`
+	Label        URL        `json:"beer_label"`
+	LabelHD      URL        `json:"beer_label_hd"`
+	ABV          float64    `json:"beer_abv"`
+	IBU          int        `json:"beer_ibu"`
+	Slug         string     `json:"beer_slug"`
+	Style        string     `json:"beer_style"`
+	StyleID      int64      `json:"beer_style_id"`
+	Description  string     `json:"beer_description"`
+	Created      Time       `json:"created_at"`
+	Homebrew     Bool       `json:"is_homebrew"`
+	InProduction *Bool      `json:"is_in_production"`
+	WishList     bool       `json:"wish_list"`
+	RatingScore  float64    `json:"rating_score"`
+	OverallCount int        `json:"rating_count"`
+	AuthRating   float64    `json:"auth_rating"`
+	Stats        BeerStats  `json:"stats"`
+	Friends      rawFriends `json:"friends"`
 
 	// For /v4/beer/info/ID, brewery is located inside the rawBeer struct.
 	// This is not the case with /v4/user/beers/username, where it is
@@ -86,20 +172,37 @@ type rawBeer struct {
 // useful structures to be created for client consumption.
 func (r *rawBeer) export() *Beer {
 	b := &Beer{
-		ID:            r.ID,
-		Name:          r.Name,
-		Label:         url.URL(r.Label),
-		ABV:           r.ABV,
-		IBU:           r.IBU,
-		Slug:          r.Slug,
-		Style:         r.Style,
-		Description:   r.Description,
-		Created:       time.Time(r.Created),
-		WishList:      r.WishList,
-		OverallRating: r.OverallRating,
-		OverallCount:  r.OverallCount,
+		ID:           r.ID,
+		Name:         r.Name,
+		ABV:          r.ABV,
+		IBU:          r.IBU,
+		Slug:         r.Slug,
+		Style:        r.Style,
+		StyleID:      r.StyleID,
+		StyleFamily:  ParseStyle(r.Style),
+		Description:  r.Description,
+		Created:      time.Time(r.Created),
+		Homebrew:     bool(r.Homebrew),
+		InProduction: r.InProduction == nil || bool(*r.InProduction),
+		WishList:     r.WishList,
+		OverallCount: r.OverallCount,
+		Stats:        r.Stats,
+		Friends:      r.Friends.export(),
+		Rating: Rating{
+			Score:      r.RatingScore,
+			Count:      r.OverallCount,
+			AuthRating: r.AuthRating,
+			WishList:   r.WishList,
+		},
 	}
 
+	// Label is available in standard and high resolution sizes
+	b.Labels = newImageSet(
+		Image{Width: imageWidthSmall, URL: url.URL(r.Label)},
+		Image{Width: imageWidthOriginal, URL: url.URL(r.LabelHD)},
+	)
+	b.Label = b.Labels.BestFor(imageWidthSmall)
+
 	// If brewery was present inside the Beer struct, as is the case
 	// with /v4/beer/info/ID, add it now.
 	if r.Brewery != nil {
@@ -108,3 +211,43 @@ func (r *rawBeer) export() *Beer {
 
 	return b
 }
+
+// rawFriends is the raw JSON representation of the friends of the
+// authenticated user who have had an Untappd beer.
+type rawFriends struct {
+	Items []struct {
+		User        rawUser `json:"user"`
+		RatingScore float64 `json:"rating_score"`
+		Created     Time    `json:"created_at"`
+	} `json:"items"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawFriends) UnmarshalJSON(data []byte) error {
+	// If no friends have had a beer, the API returns an empty array
+	// instead of an object
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	type friends rawFriends
+	return json.Unmarshal(data, (*friends)(r))
+}
+
+// export creates a slice of exported FriendRatings from a rawFriends struct.
+func (r *rawFriends) export() []FriendRating {
+	if len(r.Items) == 0 {
+		return nil
+	}
+
+	friends := make([]FriendRating, 0, len(r.Items))
+	for _, f := range r.Items {
+		friends = append(friends, FriendRating{
+			User:    f.User.export(),
+			Rating:  f.RatingScore,
+			Created: time.Time(f.Created),
+		})
+	}
+
+	return friends
+}
@@ -10,16 +10,14 @@ import (
 )
 
 // TestClientBeerCheckinsOK verifies that Client.Beer.Checkins always sets the
-// appropriate default minimum ID, maximum ID, and limit values.
+// appropriate default minimum ID and limit values, and no maximum ID.
 func TestClientBeerCheckinsOK(t *testing.T) {
 	minID := "0"
-	maxID := strconv.Itoa(math.MaxInt32)
 	limit := "25"
 
 	c, done := beerCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		assertParameters(t, r, url.Values{
 			"min_id": []string{minID},
-			"max_id": []string{maxID},
 			"limit":  []string{limit},
 		})
 
@@ -43,24 +41,24 @@ func TestClientBeerCheckinsMinMaxIDLimitBadBeer(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.Beer.CheckinsMinMaxIDLimit(-1, 0, math.MaxInt32, 25)
+	_, _, err := c.Beer.CheckinsMinMaxIDLimit(-1, 0, math.MaxInt64, 25)
 	assertInvalidBeerErr(t, err)
 }
 
 // TestClientBeerCheckinsMinMaxIDLimitOK verifies that Client.Beer.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientBeerCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	sMinID := strconv.Itoa(minID)
+	var minID int64
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = math.MaxInt32
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
-	id := 1
-	sID := strconv.Itoa(id)
+	id := int64(1)
+	sID := strconv.FormatInt(id, 10)
 	c, done := beerCheckinsTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/checkins/" + sID + "/"
 		if p := r.URL.Path; p != path {
@@ -0,0 +1,103 @@
+package untappd
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// BeerFilter specifies criteria used to filter a list of beers.  All members
+// are optional, and zero values indicate that no filtering should be performed
+// using that criterion.
+//
+// Style, brewery, and container filters are sent to the Untappd APIv4 with
+// requests which support them.  Rating ranges, and the exclusion of homebrews
+// and retired beers, are always applied by the client, using Filter.  Filter
+// may also be applied to any other list of beers, such as the results of a
+// search or the beers of a Library.
+type BeerFilter struct {
+	// Minimum and maximum rating by the specified user, inclusive.
+	MinRating float64
+	MaxRating float64
+
+	// Minimum and maximum global Untappd rating, inclusive.
+	MinOverallRating float64
+	MaxOverallRating float64
+
+	// Style, brewery, and serving container IDs.
+	StyleID     int64
+	BreweryID   int64
+	ContainerID int64
+
+	// Exclude homebrews, and beers which are no longer in production.
+	ExcludeHomebrew bool
+	ExcludeRetired  bool
+}
+
+// Match reports whether the input Beer satisfies the client-side criteria of
+// this BeerFilter.  Container IDs are not present in Beer structs, and are not
+// considered.  Style IDs are only considered for Beers which report one.
+func (f BeerFilter) Match(b *Beer) bool {
+	if b == nil {
+		return false
+	}
+
+	if f.MinRating != 0 && b.UserRating < f.MinRating {
+		return false
+	}
+	if f.MaxRating != 0 && b.UserRating > f.MaxRating {
+		return false
+	}
+
+	if f.MinOverallRating != 0 && b.Rating.Score < f.MinOverallRating {
+		return false
+	}
+	if f.MaxOverallRating != 0 && b.Rating.Score > f.MaxOverallRating {
+		return false
+	}
+
+	if f.StyleID != 0 && b.StyleID != 0 && b.StyleID != f.StyleID {
+		return false
+	}
+
+	if f.BreweryID != 0 && (b.Brewery == nil || b.Brewery.ID != f.BreweryID) {
+		return false
+	}
+
+	if f.ExcludeHomebrew && b.Homebrew {
+		return false
+	}
+	if f.ExcludeRetired && !b.InProduction {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the beers from the input slice which satisfy Match.
+func (f BeerFilter) Filter(beers []*Beer) []*Beer {
+	out := make([]*Beer, 0, len(beers))
+	for _, b := range beers {
+		if f.Match(b) {
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// values returns the query parameters used to apply this BeerFilter on
+// the server side.
+func (f BeerFilter) values() url.Values {
+	q := url.Values{}
+	if f.StyleID != 0 {
+		q.Set("type_id", strconv.FormatInt(f.StyleID, 10))
+	}
+	if f.BreweryID != 0 {
+		q.Set("brewery_id", strconv.FormatInt(f.BreweryID, 10))
+	}
+	if f.ContainerID != 0 {
+		q.Set("container_id", strconv.FormatInt(f.ContainerID, 10))
+	}
+
+	return q
+}
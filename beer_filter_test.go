@@ -0,0 +1,106 @@
+package untappd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestBeerFilterMatch verifies that BeerFilter.Match applies all client-side
+// criteria.
+func TestBeerFilterMatch(t *testing.T) {
+	beer := &Beer{
+		UserRating: 3.5,
+		Rating:     Rating{Score: 4.1},
+		Brewery: &Brewery{
+			ID: 1,
+		},
+	}
+
+	var tests = []struct {
+		description string
+		filter      BeerFilter
+		match       bool
+	}{
+		{"empty filter", BeerFilter{}, true},
+		{"rating in range", BeerFilter{MinRating: 3, MaxRating: 4}, true},
+		{"rating too low", BeerFilter{MinRating: 4}, false},
+		{"rating too high", BeerFilter{MaxRating: 3}, false},
+		{"overall rating too low", BeerFilter{MinOverallRating: 4.5}, false},
+		{"overall rating too high", BeerFilter{MaxOverallRating: 4}, false},
+		{"brewery match", BeerFilter{BreweryID: 1}, true},
+		{"brewery mismatch", BeerFilter{BreweryID: 2}, false},
+		{"server-side only", BeerFilter{StyleID: 1, ContainerID: 1}, true},
+	}
+
+	for _, tt := range tests {
+		if m := tt.filter.Match(beer); m != tt.match {
+			t.Fatalf("unexpected match for test %q: %v != %v", tt.description, m, tt.match)
+		}
+	}
+
+	styled := &Beer{StyleID: 10}
+	if !(BeerFilter{StyleID: 10}).Match(styled) || (BeerFilter{StyleID: 11}).Match(styled) {
+		t.Fatal("style ID should be matched for beers which report one")
+	}
+
+	if (BeerFilter{}).Match(nil) {
+		t.Fatal("nil beer should not match")
+	}
+}
+
+// TestBeerFilterHomebrewRetired verifies that BeerFilter excludes
+// homebrews and retired beers, as decoded from the Untappd APIv4.
+func TestBeerFilterHomebrewRetired(t *testing.T) {
+	var raw []rawBeer
+	if err := json.Unmarshal([]byte(`[
+  {"bid": 1},
+  {"bid": 2, "is_homebrew": 1, "is_in_production": 1},
+  {"bid": 3, "is_homebrew": 0, "is_in_production": 0}
+]`), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	beers := make([]*Beer, 0, len(raw))
+	for i := range raw {
+		beers = append(beers, raw[i].export())
+	}
+
+	if b := beers[0]; b.Homebrew || !b.InProduction {
+		t.Fatalf("unexpected flags for beer without them: %v, %v", b.Homebrew, b.InProduction)
+	}
+	if b := beers[1]; !b.Homebrew || !b.InProduction {
+		t.Fatalf("unexpected flags for homebrew: %v, %v", b.Homebrew, b.InProduction)
+	}
+	if b := beers[2]; b.Homebrew || b.InProduction {
+		t.Fatalf("unexpected flags for retired beer: %v, %v", b.Homebrew, b.InProduction)
+	}
+
+	var tests = []struct {
+		description string
+		filter      BeerFilter
+		ids         []int64
+	}{
+		{"include all", BeerFilter{}, []int64{1, 2, 3}},
+		{"exclude homebrew", BeerFilter{ExcludeHomebrew: true}, []int64{1, 3}},
+		{"exclude retired", BeerFilter{ExcludeRetired: true}, []int64{1, 2}},
+		{"exclude both", BeerFilter{ExcludeHomebrew: true, ExcludeRetired: true}, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		p := &BeerSearchPage{Beers: beers}
+
+		var ids []int64
+		for _, b := range p.Filter(tt.filter) {
+			ids = append(ids, b.ID)
+		}
+
+		if len(ids) != len(tt.ids) {
+			t.Fatalf("unexpected beers for test %q: %v != %v", tt.description, ids, tt.ids)
+		}
+		for i := range ids {
+			if ids[i] != tt.ids[i] {
+				t.Fatalf("unexpected beers for test %q: %v != %v", tt.description, ids, tt.ids)
+			}
+		}
+	}
+}
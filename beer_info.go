@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -9,7 +10,12 @@ import (
 // Info queries for information about a Beer with the specified ID.
 // If the compact parameter is set to 'true', only basic beer information will
 // be populated.
-func (b *BeerService) Info(id int, compact bool) (*Beer, *http.Response, error) {
+func (b *BeerService) Info(id int64, compact bool) (*Beer, *http.Response, error) {
+	return b.info(context.Background(), id, compact)
+}
+
+// info implements Info, binding the HTTP request to the input context.
+func (b *BeerService) info(ctx context.Context, id int64, compact bool) (*Beer, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -24,10 +30,15 @@ func (b *BeerService) Info(id int, compact bool) (*Beer, *http.Response, error)
 	}
 
 	// Perform request for beer information by ID
-	res, err := b.client.request("GET", "beer/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.requestContext(ctx, "GET", "beer/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return v.Response.Beer.export(), res, nil
+	beer := v.Response.Beer.export()
+	if err := b.client.afterResponse(res, beer); err != nil {
+		return nil, res, err
+	}
+
+	return beer, res, nil
 }
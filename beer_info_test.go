@@ -11,8 +11,8 @@ import (
 // TestClientBeerInfoBadBeer verifies that Client.Beer.Info returns an error when
 // an invalid beer is queried.
 func TestClientBeerInfoBadBeer(t *testing.T) {
-	beerID := -1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(-1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
 	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/info/" + sBeerID + "/"
@@ -49,11 +49,55 @@ func TestClientBeerInfoCompactOK(t *testing.T) {
 	}
 }
 
+// TestClientBeerInfoFriends verifies that Client.Beer.Info decodes the friends
+// of the authenticated user who have had a beer.
+func TestClientBeerInfoFriends(t *testing.T) {
+	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/beer/info/1/":
+			w.Write([]byte(`{"response":{"beer":{"bid":1,"friends":{"count":3,"items":[
+				{"user":{"user_name":"foo"},"rating_score":4.5,"created_at":"Sat, 21 May 2016 00:15:40 +0000"},
+				{"user":{"user_name":"bar"},"rating_score":0},
+				{"user":{"user_name":"baz"},"rating_score":3.5}
+			]}}}}`))
+		default:
+			w.Write([]byte(`{"response":{"beer":{"bid":2,"friends":[]}}}`))
+		}
+	})
+	defer done()
+
+	b, _, err := c.Beer.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(b.Friends); l != 3 {
+		t.Fatalf("unexpected number of friends: %d != %d", l, 3)
+	}
+	if f := b.Friends[0]; f.User.UserName != "foo" || f.Rating != 4.5 || f.Created.IsZero() {
+		t.Fatalf("unexpected first friend: %+v", f)
+	}
+	if avg, n := b.FriendsRating(); avg != 4 || n != 2 {
+		t.Fatalf("unexpected friends rating: %v, %d != %v, %d", avg, n, 4.0, 2)
+	}
+
+	b, _, err = c.Beer.Info(2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Friends != nil {
+		t.Fatalf("unexpected friends for beer with none: %+v", b.Friends)
+	}
+	if avg, n := b.FriendsRating(); avg != 0 || n != 0 {
+		t.Fatalf("unexpected friends rating for beer with none: %v, %d", avg, n)
+	}
+}
+
 // TestClientBeerInfoOK verifies that Client.Beer.Info returns a valid beer when
 // provided with correct input parameters.
 func TestClientBeerInfoOK(t *testing.T) {
-	beerID := 1
-	sBeerID := strconv.Itoa(beerID)
+	beerID := int64(1)
+	sBeerID := strconv.FormatInt(beerID, 10)
 
 	c, done := beerInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/beer/info/" + sBeerID + "/"
@@ -83,7 +127,25 @@ func TestClientBeerInfoOK(t *testing.T) {
 	}
 	overallCount := 123
 	if c := b.OverallCount; c != overallCount {
-		t.Fatalf("unexpected OverallCount: %q != %q", c, overallCount)
+		t.Fatalf("unexpected OverallCount: %d != %d", c, overallCount)
+	}
+	rating := Rating{
+		Score:      4.295,
+		Count:      123,
+		AuthRating: 4.5,
+		WishList:   true,
+	}
+	if r := b.Rating; r != rating {
+		t.Fatalf("unexpected Rating: %+v != %+v", r, rating)
+	}
+	stats := BeerStats{
+		TotalCount:     5000,
+		MonthlyCount:   150,
+		TotalUserCount: 4000,
+		UserCount:      2,
+	}
+	if s := b.Stats; s != stats {
+		t.Fatalf("unexpected Stats: %+v != %+v", s, stats)
 	}
 }
 
@@ -126,6 +188,15 @@ var blackNoteBeerJSON = []byte(`
     "bid": 1,
     "beer_name": "Black Note Stout",
     "rating_count": 123,
+    "rating_score": 4.295,
+    "auth_rating": 4.5,
+    "wish_list": true,
+    "stats": {
+      "total_count": 5000,
+      "monthly_count": 150,
+      "total_user_count": 4000,
+      "user_count": 2
+    },
     "brewery": {
       "brewery_name": "Bell's Brewery, Inc."
     }
@@ -8,40 +8,151 @@ import (
 
 // Search searches for information about beers, using the specified search query.
 //
-// This method returns up to 25 search results.  For more granular control,
-// and to page through and sort the results list, use SearchOffsetLimitSort instead.
+// This method returns up to 25 search results, sorted by checkin count.  For
+// more granular control, and to page through and sort the results list, use
+// SearchOffsetLimitSort instead.
 //
 // It is recommended to search using a "Brewery Name + Beer Name" query, such as
 // "Dogfish 60 Minute".
 func (b *BeerService) Search(query string) ([]*Beer, *http.Response, error) {
 	// Use default parameters as specified by API
-	return b.SearchOffsetLimitSort(query, 0, 25, SortDate)
+	return b.SearchOffsetLimitSort(query, 0, 25, SortCheckin)
 }
 
 // SearchOffsetLimitSort searches for information about beers, using the specified
 // search query.  In addition, it accepts offset, limit, and sort parameters to
-// enable paging and sorting through more than 25 beers.  Beers may be sorted using
-// any of the provided Sort constants with this package.
+// enable paging and sorting through more than 25 beers.  Beers may be sorted by
+// checkin count or by name, using SortCheckin or SortName.  Other sorts return
+// an *InvalidSortError.
 //
 // 50 beers is the maximum number of results which may be returned by one call.
 //
 // It is recommended to search using a "Brewery Name + Beer Name" query, such as
 // "Dogfish 60 Minute".
 func (b *BeerService) SearchOffsetLimitSort(query string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
-	q := url.Values{
-		"q":      []string{query},
-		"offset": []string{strconv.Itoa(offset)},
-		"limit":  []string{strconv.Itoa(limit)},
-		"sort":   []string{string(sort)},
+	p, res, err := b.SearchPage(query, offset, limit, sort)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return p.Beers, res, nil
+}
+
+// BeerSearchPage is a single page of beer search results.  In addition to the
+// beers on this page, it contains the total number of beers which matched a
+// search query, so that callers can page through all results.
+type BeerSearchPage struct {
+	// Total number of beers which matched the search query, and how the
+	// query was interpreted by the API.
+	Found int
+	Query SearchQuery
+
+	// Offset and limit used to retrieve this page.
+	Offset int
+	Limit  int
+
+	// Beers on this page of results.
+	Beers []*Beer
+}
+
+// Filter returns the beers on this page which satisfy the input BeerFilter,
+// such as to exclude homebrews and retired beers from matching results.
+func (p *BeerSearchPage) Filter(f BeerFilter) []*Beer {
+	return f.Filter(p.Beers)
+}
+
+// NotHad returns the beers on this page which the authenticated user has not
+// yet checked in.  This is only meaningful for searches performed by an
+// authenticated Client.
+func (p *BeerSearchPage) NotHad() []*Beer {
+	var beers []*Beer
+	for _, b := range p.Beers {
+		if b != nil && !b.HaveHad {
+			beers = append(beers, b)
+		}
+	}
+
+	return beers
+}
+
+// More reports whether more results are available beyond this page.
+func (p *BeerSearchPage) More() bool {
+	return p.Offset+len(p.Beers) < p.Found
+}
+
+// SearchPage searches for information about beers, using the specified search
+// query, offset, limit, and sort parameters.  Unlike SearchOffsetLimitSort, it
+// returns a BeerSearchPage which contains the total number of matching beers.
+//
+// 50 beers is the maximum number of results which may be returned by one call.
+func (b *BeerService) SearchPage(query string, offset int, limit int, sort Sort) (*BeerSearchPage, *http.Response, error) {
+	return b.search(query, offset, limit, sort)
+}
+
+// SearchInBrewery searches for information about beers, using the specified
+// search query, and returns only the beers produced by the brewery with the
+// specified ID, which makes this method useful for matching beer names when
+// the brewery is already known.
+//
+// The Untappd APIv4 cannot restrict a search to a single brewery, so results
+// are filtered by the client.  Pages of search results, sorted by checkin
+// count, are fetched until 25 matching beers are found, or no more results are
+// available.  A query which matches many beers from other breweries may
+// require many requests.
+func (b *BeerService) SearchInBrewery(breweryID int64, query string) ([]*Beer, *http.Response, error) {
+	beers := make([]*Beer, 0, 25)
+	for offset := 0; ; {
+		p, res, err := b.search(query, offset, maxSearchLimit, SortCheckin)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, beer := range p.Beers {
+			if beer == nil || beer.Brewery == nil || beer.Brewery.ID != breweryID {
+				continue
+			}
+
+			beers = append(beers, beer)
+			if len(beers) == cap(beers) {
+				return beers, res, nil
+			}
+		}
+
+		if len(p.Beers) == 0 || !p.More() {
+			return beers, res, nil
+		}
+		offset += len(p.Beers)
+	}
+}
+
+// search is the backing method for beer search requests.  It handles
+// performing the necessary HTTP request with the correct parameters, and
+// returns a page of Beers.
+func (b *BeerService) search(query string, offset int, limit int, sort Sort) (*BeerSearchPage, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxSearchLimit); err != nil {
+		return nil, nil, err
+	}
+	if err := checkSort(sort, SearchSorts()); err != nil {
+		return nil, nil, err
 	}
 
+	q := url.Values{}
+	q.Set("q", query)
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("sort", string(sort))
+
 	// Temporary struct to unmarshal beers JSON
 	var v struct {
 		Response struct {
+			SearchQuery
+			Found int `json:"found"`
 			Beers struct {
 				Count int `json:"count"`
 				Items []struct {
 					CheckinCount int        `json:"checkin_count"`
+					HaveHad      bool       `json:"have_had"`
+					YourCount    int        `json:"your_count"`
 					Beer         rawBeer    `json:"beer"`
 					Brewery      rawBrewery `json:"brewery"`
 				} `json:"items"`
@@ -62,9 +173,24 @@ func (b *BeerService) SearchOffsetLimitSort(query string, offset int, limit int,
 		beers[i] = item.Beer.export()
 		beers[i].OverallCount = item.CheckinCount
 
+		// Information related to the authenticated user and this beer
+		beers[i].HaveHad = item.HaveHad
+		beers[i].Count = item.YourCount
+
 		// Information about the beer's brewery
 		beers[i].Brewery = item.Brewery.export()
 	}
 
-	return beers, res, nil
+	page := &BeerSearchPage{
+		Found:  v.Response.Found,
+		Query:  v.Response.SearchQuery,
+		Offset: offset,
+		Limit:  limit,
+		Beers:  beers,
+	}
+	if err := b.client.afterResponse(res, page); err != nil {
+		return nil, res, err
+	}
+
+	return page, res, nil
 }
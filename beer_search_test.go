@@ -3,6 +3,7 @@ package untappd
 import (
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -14,7 +15,7 @@ func TestClientBeerSearchOK(t *testing.T) {
 	query := "foo"
 	offset := "0"
 	limit := "25"
-	sort := "date"
+	sort := "checkin"
 
 	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		assertParameters(t, r, url.Values{
@@ -44,7 +45,7 @@ func TestClientBeerSearchOffsetLimitBadQuery(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.Beer.SearchOffsetLimitSort("", 0, 25, SortDate)
+	_, _, err := c.Beer.SearchOffsetLimitSort("", 0, 25, SortCheckin)
 	assertInvalidQueryErr(t, err)
 }
 
@@ -57,7 +58,7 @@ func TestClientBeerSearchOffsetLimitOK(t *testing.T) {
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
-	var sort = SortDate
+	var sort = SortName
 
 	query := "russian river pliny"
 	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
@@ -112,11 +113,102 @@ func TestClientBeerSearchOffsetLimitOK(t *testing.T) {
 			t.Fatalf("unexpected beer Brewery.Name: %q != %q", beers[i].Brewery.Name, expected[i].Brewery.Name)
 		}
 		if beers[i].OverallCount != expected[i].OverallCount {
-			t.Fatalf("unexpected beer OverallCount: %q != %q", beers[i].OverallCount, expected[i].OverallCount)
+			t.Fatalf("unexpected beer OverallCount: %d != %d", beers[i].OverallCount, expected[i].OverallCount)
 		}
 	}
 }
 
+// TestClientBeerSearchInBreweryOK verifies that Client.Beer.SearchInBrewery
+// does not send a brewery ID parameter, discards beers from other breweries,
+// and pages through results until enough beers match.
+func TestClientBeerSearchInBreweryOK(t *testing.T) {
+	query := "pliny"
+	var offsets []string
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"q":     []string{query},
+			"limit": []string{strconv.Itoa(maxSearchLimit)},
+			"sort":  []string{"checkin"},
+		})
+		if id := r.URL.Query().Get("brewery_id"); id != "" {
+			t.Fatalf("unexpected brewery_id parameter: %q", id)
+		}
+
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+
+		// The first page contains only a beer from another brewery
+		if offset == "0" {
+			w.Write([]byte(`{"response":{"found":3,"beers":{"count":1,"items":[{"beer":{"bid":3},"brewery":{"brewery_id":1}}]}}}`))
+			return
+		}
+
+		w.Write(beerSearchJSON)
+	})
+	defer done()
+
+	beers, _, err := c.Beer.SearchInBrewery(5143, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if id := beers[0].ID; id != 1 {
+		t.Fatalf("unexpected beer ID: %d != %d", id, 1)
+	}
+	if want := []string{"0", "1"}; !reflect.DeepEqual(offsets, want) {
+		t.Fatalf("unexpected offsets: %v != %v", offsets, want)
+	}
+}
+
+// TestClientBeerSearchPageOK verifies that Client.Beer.SearchPage returns the
+// total number of matching beers with a page of results.
+func TestClientBeerSearchPageOK(t *testing.T) {
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"offset": []string{"0"},
+			"limit":  []string{"2"},
+			"sort":   []string{"name"},
+		})
+
+		w.Write(beerSearchJSON)
+	})
+	defer done()
+
+	p, _, err := c.Beer.SearchPage("pliny", 0, 2, SortName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f := p.Found; f != 2 {
+		t.Fatalf("unexpected Found: %d != %d", f, 2)
+	}
+	want := SearchQuery{Term: "pliny", ParsedTerm: "pliny*", Type: "wildcard", TypeID: 2}
+	if q := p.Query; q != want {
+		t.Fatalf("unexpected Query: %+v != %+v", q, want)
+	}
+	if l := len(p.Beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+	if p.More() {
+		t.Fatal("expected no more results")
+	}
+
+	if h := p.Beers[1].HaveHad; !h {
+		t.Fatalf("unexpected HaveHad: %v != %v", h, true)
+	}
+	if c := p.Beers[1].Count; c != 3 {
+		t.Fatalf("unexpected Count: %d != %d", c, 3)
+	}
+
+	notHad := p.NotHad()
+	if l := len(notHad); l != 1 || notHad[0].ID != 1 {
+		t.Fatalf("unexpected beers not had: %v", notHad)
+	}
+}
+
 // beerSearchTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user beers API.
 func beerSearchTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -152,6 +244,11 @@ var beerSearchJSON = []byte(`{
   },
   "notifications": {},
   "response": {
+  "search_type": "wildcard",
+  "type_id": 2,
+  "term": "pliny",
+  "parsed_term": "pliny*",
+  "found": 2,
   "beers": {
     "count": 2,
     "items": [
@@ -163,11 +260,14 @@ var beerSearchJSON = []byte(`{
         "beer_style": "Imperial / Double IPA"
       },
       "brewery": {
+        "brewery_id": 5143,
         "brewery_name": "Russian River Brewing Company"
       }
     },
     {
       "checkin_count": 456,
+      "have_had": true,
+      "your_count": 3,
       "beer": {
         "bid": 2,
         "beer_name": "Pliny the Younger",
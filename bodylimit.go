@@ -0,0 +1,57 @@
+package untappd
+
+import (
+	"fmt"
+	"io"
+)
+
+// ResponseTooLargeError is returned when an HTTP response body exceeds the
+// maximum size configured using WithMaxResponseSize.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+// Error returns the string representation of a ResponseTooLargeError.
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds maximum size of %d bytes", e.Limit)
+}
+
+// maxBytesReader is an io.ReadCloser which returns a *ResponseTooLargeError
+// if more than a fixed number of bytes are read.
+type maxBytesReader struct {
+	rc    io.ReadCloser
+	n     int64
+	limit int64
+}
+
+// newMaxBytesReader wraps rc so that no more than limit bytes may be read.
+func newMaxBytesReader(rc io.ReadCloser, limit int64) *maxBytesReader {
+	return &maxBytesReader{
+		rc:    rc,
+		n:     limit,
+		limit: limit,
+	}
+}
+
+// Read implements io.Reader.
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	// Read one byte beyond the limit to determine if the limit was exceeded
+	if int64(len(p)) > r.n+1 {
+		p = p[:r.n+1]
+	}
+
+	n, err := r.rc.Read(p)
+	if int64(n) <= r.n {
+		r.n -= int64(n)
+		return n, err
+	}
+
+	n = int(r.n)
+	r.n = 0
+	return n, &ResponseTooLargeError{Limit: r.limit}
+}
+
+// Close implements io.Closer.
+func (r *maxBytesReader) Close() error {
+	return r.rc.Close()
+}
@@ -1,6 +1,10 @@
 package untappd
 
-import "net/url"
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+)
 
 // BreweryService is a "service" which allows access to API methods involving
 // breweries.
@@ -10,8 +14,12 @@ type BreweryService struct {
 
 // Brewery represents an Untappd brewery, and contains information about a
 // brewery's name, location, logo, and various other metadata.
+//
+// Claimed reports whether the brewery's Untappd page is managed by a verified
+// brewery account, and Independent reports whether Untappd lists the brewery
+// as an independent craft brewery.
 type Brewery struct {
-	ID           int
+	ID           int64
 	Name         string
 	Slug         string
 	Logo         url.URL
@@ -19,17 +27,65 @@ type Brewery struct {
 	Active       bool
 	Location     BreweryLocation
 	Contact      BreweryContact
-	Type         string
-	TypeID       int
+	Claimed      BreweryClaimedStatus
+	Type         BreweryType
+	TypeID       int64
 	Independent  bool
 	InProduction int
-	Rating       BreweryRating
+	Rating       Rating
 	Description  string
 	Stats        BreweryStats
+
+	// Top beers of this brewery, and the total number of beers this brewery
+	// has on Untappd.  They are only reported by BreweryService.Info.
+	Beers     []BeerSummary
+	BeerCount int
+
+	// Breweries which own this brewery, such as the parent company of a
+	// subsidiary, and breweries which have collaborated with this brewery.
+	// They are only reported by BreweryService.Info.
+	Owners        []*Brewery
+	Collaborators []*Brewery
 }
 
+// BeerSummary is one of the top beers of an Untappd brewery, along with its
+// checkin counts.
+type BeerSummary struct {
+	Beer *Beer
+
+	// Whether the authenticated user has had this beer.
+	HasHad bool
+
+	// Total number of checkins, and number of checkins this month.
+	TotalCount   int
+	MonthlyCount int
+}
+
+// BreweryType is the type of an Untappd brewery, as reported by the Untappd
+// APIv4.  A set of BreweryType constants are provided for ease of use.
+type BreweryType string
+
+// Constants that define the types of breweries known to Untappd.
+const (
+	BreweryTypeMicro      BreweryType = "Micro Brewery"
+	BreweryTypeNano       BreweryType = "Nano Brewery"
+	BreweryTypeMacro      BreweryType = "Macro Brewery"
+	BreweryTypeRegional   BreweryType = "Regional Brewery"
+	BreweryTypeBrewPub    BreweryType = "Brew Pub"
+	BreweryTypeContract   BreweryType = "Contract Brewery"
+	BreweryTypeHomebrew   BreweryType = "Home Brewery"
+	BreweryTypeCidery     BreweryType = "Cidery"
+	BreweryTypeMeadery    BreweryType = "Meadery"
+	BreweryTypeBarStore   BreweryType = "Bar / Restaurant / Store"
+	BreweryTypeUnassigned BreweryType = "Not Assigned"
+)
+
 // BreweryLocation represent's an Untappd brewery's location, and contains
 // information such as the brewery's city, state, and latitude/longitude.
+//
+// Depending on the API method, coordinates are reported in either the
+// Latitude and Longitude members, or the BLatitude and BLongitude members.
+// Coordinates returns whichever pair is available.
 type BreweryLocation struct {
 	Address    string  `json:"brewery_address"`
 	City       string  `json:"brewery_city"`
@@ -40,52 +96,181 @@ type BreweryLocation struct {
 	BLongitude float64 `json:"brewery_lng"`
 }
 
-type BreweryRating struct {
-	Count int     `json:"count"`
-	Score float64 `json:"rating_score"`
+// Coordinates returns the latitude and longitude of a brewery, preferring
+// whichever pair of coordinates was reported by the API.
+func (l BreweryLocation) Coordinates() (latitude float64, longitude float64) {
+	if l.Latitude != 0 || l.Longitude != 0 {
+		return l.Latitude, l.Longitude
+	}
+
+	return l.BLatitude, l.BLongitude
 }
 
+// BreweryStats contains checkin statistics for an Untappd brewery.
 type BreweryStats struct {
-	TotalCount   int     `json:"total_count"`
-	UniqueCount  int     `json:"unique_count"`
-	MonthlyCount int     `json:"monthly_count"`
-	WeeklyCount  int     `json:"weekly_count"`
+	// Total number of checkins and number of unique users who checked in
+	// this brewery's beers.
+	TotalCount  int `json:"total_count"`
+	UniqueCount int `json:"unique_count"`
+
+	// Number of checkins this month and this week.
+	MonthlyCount int `json:"monthly_count"`
+	WeeklyCount  int `json:"weekly_count"`
+
+	// If applicable, number of times the specified user has checked in
+	// this brewery's beers.
+	UserCount int `json:"user_count"`
+
+	// Age of this brewery's Untappd page, in days.
 	AgeOnService float64 `json:"age_on_service"`
 }
 
 // BreweryContact represents an Untappd brewery's contact social media
 // and website contact information.
 type BreweryContact struct {
+	// Social media account names.
+	Twitter   string
+	Instagram string
+
+	// Links to the brewery's Facebook page and website.
+	Facebook url.URL
+	URL      url.URL
+}
+
+// rawBreweryContact is the raw JSON representation of Untappd brewery contact
+// information.  Its data is unmarshaled from JSON and then exported to a
+// BreweryContact struct.
+type rawBreweryContact struct {
 	Twitter   string `json:"twitter"`
-	Facebook  string `json:"facebook"`
+	Facebook  URL    `json:"facebook"`
 	Instagram string `json:"instagram"`
-	URL       string `json:"url"`
+	URL       URL    `json:"url"`
+}
+
+// export creates an exported BreweryContact from a rawBreweryContact struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawBreweryContact) export() BreweryContact {
+	return BreweryContact{
+		Twitter:   r.Twitter,
+		Instagram: r.Instagram,
+		Facebook:  url.URL(r.Facebook),
+		URL:       url.URL(r.URL),
+	}
+}
+
+// BreweryClaimedStatus represents the claimed status of an Untappd brewery.
+// A brewery is claimed when its Untappd page is managed by a verified
+// brewery account.
+type BreweryClaimedStatus struct {
+	Claimed bool   `json:"is_claimed"`
+	Slug    string `json:"claimed_slug"`
+
+	// Number of Untappd users following this brewery.
+	FollowerCount int `json:"follower_count"`
+}
+
+// rawClaimedStatus is the raw JSON representation of the claimed status of an
+// Untappd brewery.
+type rawClaimedStatus struct {
+	Claimed       Bool   `json:"is_claimed"`
+	Slug          string `json:"claimed_slug"`
+	FollowerCount int    `json:"follower_count"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawClaimedStatus) UnmarshalJSON(data []byte) error {
+	// Breweries which have never been claimed may report an empty array
+	// instead of an object
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	type status rawClaimedStatus
+	return json.Unmarshal(data, (*status)(r))
+}
+
+// export creates an exported BreweryClaimedStatus from a rawClaimedStatus.
+func (r *rawClaimedStatus) export() BreweryClaimedStatus {
+	return BreweryClaimedStatus{
+		Claimed:       bool(r.Claimed),
+		Slug:          r.Slug,
+		FollowerCount: r.FollowerCount,
+	}
 }
 
 // rawBrewery is the raw JSON representation of an Untappd brewery.  Its data is
 // unmarshaled from JSON and then exported to a Brewery struct.
 type rawBrewery struct {
-	ID           int             `json:"brewery_id"`
-	Name         string          `json:"brewery_name"`
-	Slug         string          `json:"brewery_slug"`
-	Logo         responseURL     `json:"brewery_label"`
-	Country      string          `json:"country_name"`
-	Active       responseBool    `json:"brewery_active"`
-	Location     BreweryLocation `json:"location"`
-	Contact      BreweryContact  `json:"contact"`
-	Type         string          `json:"brewery_type"`
-	TypeID       int             `json:"brewery_type_id"`
-	Independent  responseBool    `json:"is_independent"`
-	InProduction int             `json:"brewery_in_production"`
-	Rating       BreweryRating   `json:"rating"`
-	Description  string          `json:"brewery_description"`
-	Stats        BreweryStats    `json:"stats"`
+	ID           int64              `json:"brewery_id"`
+	Name         string             `json:"brewery_name"`
+	Slug         string             `json:"brewery_slug"`
+	Logo         URL                `json:"brewery_label"`
+	Country      string             `json:"country_name"`
+	Active       Bool               `json:"brewery_active"`
+	Location     BreweryLocation    `json:"location"`
+	Contact      rawBreweryContact  `json:"contact"`
+	Claimed      rawClaimedStatus   `json:"claimed_status"`
+	Type         BreweryType        `json:"brewery_type"`
+	TypeID       int64              `json:"brewery_type_id"`
+	Independent  Bool               `json:"is_independent"`
+	InProduction int                `json:"brewery_in_production"`
+	Rating       rawRating          `json:"rating"`
+	Description  string             `json:"brewery_description"`
+	Stats        BreweryStats       `json:"stats"`
+	BeerList     rawBreweryBeerList `json:"beer_list"`
+	Owners       rawBreweryList     `json:"owners"`
+	Collaborated rawBreweryList     `json:"collaborations_with"`
+}
+
+// rawBreweryList is the raw JSON representation of a list of breweries
+// related to an Untappd brewery.
+type rawBreweryList struct {
+	Items []struct {
+		Brewery rawBrewery `json:"brewery"`
+	} `json:"items"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawBreweryList) UnmarshalJSON(data []byte) error {
+	// Empty lists are reported as an empty array instead of an object
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	type list rawBreweryList
+	return json.Unmarshal(data, (*list)(r))
+}
+
+// export creates a slice of exported Breweries from a rawBreweryList.
+func (r *rawBreweryList) export() []*Brewery {
+	if len(r.Items) == 0 {
+		return nil
+	}
+
+	breweries := make([]*Brewery, 0, len(r.Items))
+	for i := range r.Items {
+		breweries = append(breweries, r.Items[i].Brewery.export())
+	}
+
+	return breweries
+}
+
+// rawBreweryBeerList is the raw JSON representation of the top beers of an
+// Untappd brewery.
+type rawBreweryBeerList struct {
+	BeerCount int `json:"beer_count"`
+	Items     []struct {
+		HasHad       bool    `json:"has_had"`
+		TotalCount   int     `json:"total_count"`
+		MonthlyCount int     `json:"monthly_count"`
+		Beer         rawBeer `json:"beer"`
+	} `json:"items"`
 }
 
 // export creates an exported Brewery from a rawBrewery struct, allowing for
 // more useful structures to be created for client consumption.
 func (r *rawBrewery) export() *Brewery {
-	return &Brewery{
+	b := &Brewery{
 		ID:           r.ID,
 		Name:         r.Name,
 		Slug:         r.Slug,
@@ -93,13 +278,29 @@ func (r *rawBrewery) export() *Brewery {
 		Country:      r.Country,
 		Active:       bool(r.Active),
 		Location:     r.Location,
-		Contact:      r.Contact,
+		Contact:      r.Contact.export(),
+		Claimed:      r.Claimed.export(),
 		Type:         r.Type,
 		TypeID:       r.TypeID,
 		Independent:  bool(r.Independent),
 		InProduction: r.InProduction,
-		Rating:       r.Rating,
+		Rating:       r.Rating.export(),
 		Description:  r.Description,
 		Stats:        r.Stats,
+		BeerCount:    r.BeerList.BeerCount,
+
+		Owners:        r.Owners.export(),
+		Collaborators: r.Collaborated.export(),
 	}
+
+	for _, item := range r.BeerList.Items {
+		b.Beers = append(b.Beers, BeerSummary{
+			Beer:         item.Beer.export(),
+			HasHad:       item.HasHad,
+			TotalCount:   item.TotalCount,
+			MonthlyCount: item.MonthlyCount,
+		})
+	}
+
+	return b
 }
@@ -15,10 +15,10 @@ import (
 // This method returns up to 25 of the Brewery's most recent checkins.
 // For more granular control, and to page through the checkins list using ID
 // parameters, use CheckinsMinMaxIDLimit instead.
-func (b *BreweryService) Checkins(id int) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return b.CheckinsMinMaxIDLimit(id, 0, math.MaxInt32, 25)
+func (b *BreweryService) Checkins(id int64) ([]*Checkin, *http.Response, error) {
+	// Use default parameters as specified by API, with no upper bound on
+	// checkin IDs so that no recent checkins are excluded.
+	return b.CheckinsMinMaxIDLimit(id, 0, math.MaxInt64, 25)
 }
 
 // CheckinsMinMaxIDLimit queries for information about recent checkins for beers
@@ -27,12 +27,23 @@ func (b *BreweryService) Checkins(id int) ([]*Checkin, *http.Response, error) {
 // The ID parameter specifies the Brewery ID, which will return a list of
 // recent checkins for beers made by a given Brewery.
 //
+// If maxID is math.MaxInt64, no upper bound is sent.
+//
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (b *BreweryService) CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	return b.client.getCheckins("brewery/checkins/"+strconv.Itoa(id), url.Values{
-		"min_id": []string{strconv.Itoa(minID)},
-		"max_id": []string{strconv.Itoa(maxID)},
+// one call.  If limit is greater than 25, the checkins are fetched using
+// multiple calls, and combined.
+func (b *BreweryService) CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkMinMaxIDLimit(minID, maxID, limit); err != nil {
+		return nil, nil, err
+	}
+
+	q := url.Values{
+		"min_id": []string{strconv.FormatInt(minID, 10)},
 		"limit":  []string{strconv.Itoa(limit)},
-	})
+	}
+	if maxID != math.MaxInt64 {
+		q.Set("max_id", strconv.FormatInt(maxID, 10))
+	}
+
+	return b.client.getCheckinsLimit("brewery/checkins/"+strconv.FormatInt(id, 10), q, limit, maxFeedCheckinsLimit)
 }
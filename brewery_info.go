@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -9,7 +10,12 @@ import (
 // Info queries for information about a Brewery with the specified ID.
 // If the compact parameter is set to 'true', only basic brewery information will
 // be populated.
-func (b *BreweryService) Info(id int, compact bool) (*Brewery, *http.Response, error) {
+func (b *BreweryService) Info(id int64, compact bool) (*Brewery, *http.Response, error) {
+	return b.info(context.Background(), id, compact)
+}
+
+// info implements Info, binding the HTTP request to the input context.
+func (b *BreweryService) info(ctx context.Context, id int64, compact bool) (*Brewery, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -24,10 +30,15 @@ func (b *BreweryService) Info(id int, compact bool) (*Brewery, *http.Response, e
 	}
 
 	// Perform request for brewery information by ID
-	res, err := b.client.request("GET", "brewery/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.requestContext(ctx, "GET", "brewery/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return v.Response.Brewery.export(), res, nil
+	brewery := v.Response.Brewery.export()
+	if err := b.client.afterResponse(res, brewery); err != nil {
+		return nil, res, err
+	}
+
+	return brewery, res, nil
 }
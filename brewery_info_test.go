@@ -11,8 +11,8 @@ import (
 // TestClientBreweryInfoBadBrewery verifies that Client.Brewery.Info returns an error when
 // an invalid brewery is queried.
 func TestClientBreweryInfoBadBrewery(t *testing.T) {
-	breweryID := -1
-	sBreweryID := strconv.Itoa(breweryID)
+	breweryID := int64(-1)
+	sBreweryID := strconv.FormatInt(breweryID, 10)
 
 	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/brewery/info/" + sBreweryID + "/"
@@ -49,11 +49,49 @@ func TestClientBreweryInfoCompactOK(t *testing.T) {
 	}
 }
 
+// TestClientBreweryInfoUnclaimed verifies that Client.Brewery.Info decodes the
+// claimed status and independence of a brewery in their alternate forms.
+func TestClientBreweryInfoUnclaimed(t *testing.T) {
+	var tests = []struct {
+		desc        string
+		body        string
+		claimed     bool
+		independent bool
+	}{
+		{
+			desc: "empty array",
+			body: `{"claimed_status": [], "is_independent": false}`,
+		},
+		{
+			desc:        "integers",
+			body:        `{"claimed_status": {"is_claimed": 1, "claimed_slug": "foo"}, "is_independent": 1}`,
+			claimed:     true,
+			independent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"response":{"brewery":` + tt.body + `}}`))
+		})
+
+		b, _, err := c.Brewery.Info(1, true)
+		done()
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		if b.Claimed.Claimed != tt.claimed || b.Independent != tt.independent {
+			t.Fatalf("[%s] unexpected claimed status and independence: %v, %v", tt.desc, b.Claimed.Claimed, b.Independent)
+		}
+	}
+}
+
 // TestClientBreweryInfoOK verifies that Client.Brewery.Info returns a valid brewery when
 // provided with correct input parameters.
 func TestClientBreweryInfoOK(t *testing.T) {
-	breweryID := 1
-	sBreweryID := strconv.Itoa(breweryID)
+	breweryID := int64(1)
+	sBreweryID := strconv.FormatInt(breweryID, 10)
 
 	c, done := breweryInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/brewery/info/" + sBreweryID + "/"
@@ -81,17 +119,71 @@ func TestClientBreweryInfoOK(t *testing.T) {
 	if n := b.Slug; n != brewerySlug {
 		t.Fatalf("unexpected Brewery.Slug: %q != %q", n, brewerySlug)
 	}
-	breweryType := "Micro Brewery"
+	breweryType := BreweryTypeMicro
 	if n := b.Type; n != breweryType {
 		t.Fatalf("unexpected Brewery.Type: %q != %q", n, breweryType)
 	}
-	breweryTypeID := 2
+	breweryTypeID := int64(2)
 	if n := b.TypeID; n != breweryTypeID {
-		t.Fatalf("unexpected Brewery.TypeID: %q != %q", n, breweryTypeID)
+		t.Fatalf("unexpected Brewery.TypeID: %d != %d", n, breweryTypeID)
 	}
 	breweryContactTwitter := "BellsBrewery"
 	if n := b.Contact.Twitter; n != breweryContactTwitter {
-		t.Fatalf("unexpected Brewery.TypeID: %q != %q", n, breweryContactTwitter)
+		t.Fatalf("unexpected Brewery.Contact.Twitter: %q != %q", n, breweryContactTwitter)
+	}
+	breweryContactURL := "http://www.bellsbeer.com"
+	if u := b.Contact.URL; u.String() != breweryContactURL {
+		t.Fatalf("unexpected Brewery.Contact.URL: %q != %q", u.String(), breweryContactURL)
+	}
+	breweryContactFacebook := "https://www.facebook.com/BellsBrewery"
+	if u := b.Contact.Facebook; u.String() != breweryContactFacebook {
+		t.Fatalf("unexpected Brewery.Contact.Facebook: %q != %q", u.String(), breweryContactFacebook)
+	}
+	if lat, lng := b.Location.Coordinates(); lat != 42.2848 || lng != -85.4535 {
+		t.Fatalf("unexpected Brewery.Location coordinates: %f, %f", lat, lng)
+	}
+	breweryCity := "Comstock"
+	if c := b.Location.City; c != breweryCity {
+		t.Fatalf("unexpected Brewery.Location.City: %q != %q", c, breweryCity)
+	}
+	breweryStats := BreweryStats{
+		TotalCount:   1000,
+		UniqueCount:  500,
+		MonthlyCount: 100,
+		WeeklyCount:  10,
+		UserCount:    2,
+		AgeOnService: 2000.5,
+	}
+	if s := b.Stats; s != breweryStats {
+		t.Fatalf("unexpected Brewery.Stats: %+v != %+v", s, breweryStats)
+	}
+	if !b.Claimed.Claimed {
+		t.Fatal("expected Brewery.Claimed.Claimed to be true")
+	}
+	if !b.Independent {
+		t.Fatal("expected Brewery.Independent to be true")
+	}
+	breweryClaimedSlug := "bellsbrewery"
+	if s := b.Claimed.Slug; s != breweryClaimedSlug {
+		t.Fatalf("unexpected Brewery.Claimed.Slug: %q != %q", s, breweryClaimedSlug)
+	}
+	if n := b.BeerCount; n != 250 {
+		t.Fatalf("unexpected Brewery.BeerCount: %d != %d", n, 250)
+	}
+	if l := len(b.Beers); l != 2 {
+		t.Fatalf("unexpected number of Brewery.Beers: %d != %d", l, 2)
+	}
+	if s := b.Beers[0]; s.Beer.ID != 3784 || s.Beer.Name != "Two Hearted Ale" || !s.HasHad || s.TotalCount != 900 || s.MonthlyCount != 90 {
+		t.Fatalf("unexpected first Brewery.Beers summary: %+v", s)
+	}
+	if s := b.Beers[1]; s.Beer.ID != 4701 || s.HasHad {
+		t.Fatalf("unexpected second Brewery.Beers summary: %+v", s)
+	}
+	if l := len(b.Owners); l != 1 || b.Owners[0].ID != 2 || b.Owners[0].Type != BreweryTypeMacro {
+		t.Fatalf("unexpected Brewery.Owners: %+v", b.Owners)
+	}
+	if l := len(b.Collaborators); l != 0 {
+		t.Fatalf("unexpected number of Brewery.Collaborators: %d != %d", l, 0)
 	}
 }
 
@@ -136,11 +228,80 @@ var bellsBreweryJSON = []byte(`
       "brewery_slug": "bells-brewery-inc",
       "brewery_type": "Micro Brewery",
       "brewery_type_id": 2,
+      "location": {
+        "brewery_address": "8938 Krum Ave.",
+        "brewery_city": "Comstock",
+        "brewery_state": "MI",
+        "lat": 42.2848,
+        "lng": -85.4535
+      },
+      "stats": {
+        "total_count": 1000,
+        "unique_count": 500,
+        "monthly_count": 100,
+        "weekly_count": 10,
+        "user_count": 2,
+        "age_on_service": 2000.5
+      },
       "contact": {
         "twitter": "BellsBrewery",
-        "facebook": "",
-        "url": ""
-      }
+        "facebook": "https://www.facebook.com/BellsBrewery",
+        "instagram": "bellsbrewery",
+        "url": "http://www.bellsbeer.com"
+      },
+      "is_independent": 1,
+      "claimed_status": {
+        "is_claimed": true,
+        "claimed_slug": "bellsbrewery",
+        "follow_status": false,
+        "follower_count": 12345,
+        "uid": 1,
+        "mute_status": ""
+      },
+      "beer_list": {
+        "is_super": false,
+        "sort": "",
+        "filter": "",
+        "count": 2,
+        "items": [
+          {
+            "has_had": true,
+            "total_count": 900,
+            "monthly_count": 90,
+            "beer": {
+              "bid": 3784,
+              "beer_name": "Two Hearted Ale",
+              "beer_style": "IPA - American",
+              "beer_abv": 7
+            }
+          },
+          {
+            "has_had": false,
+            "total_count": 100,
+            "monthly_count": 10,
+            "beer": {
+              "bid": 4701,
+              "beer_name": "Oberon Ale",
+              "beer_style": "Pale Wheat Ale - American",
+              "beer_abv": 5.8
+            }
+          }
+        ],
+        "beer_count": 250
+      },
+      "owners": {
+        "count": 1,
+        "items": [
+          {
+            "brewery": {
+              "brewery_id": 2,
+              "brewery_name": "Holding Company",
+              "brewery_type": "Macro Brewery"
+            }
+          }
+        ]
+      },
+      "collaborations_with": []
     }
   }
 }`)
@@ -21,6 +21,47 @@ func (b *BreweryService) Search(query string) ([]*Brewery, *http.Response, error
 //
 // 50 breweries is the maximum number of results which may be returned by one call.
 func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int) ([]*Brewery, *http.Response, error) {
+	p, res, err := b.SearchPage(query, offset, limit)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return p.Breweries, res, nil
+}
+
+// BrewerySearchPage is a single page of brewery search results.  In addition
+// to the breweries on this page, it contains the total number of breweries
+// which matched a search query, so that callers can page through all results.
+type BrewerySearchPage struct {
+	// Total number of breweries which matched the search query, and how
+	// the query was interpreted by the API.
+	Found int
+	Query SearchQuery
+
+	// Offset and limit used to retrieve this page.
+	Offset int
+	Limit  int
+
+	// Breweries on this page of results.
+	Breweries []*Brewery
+}
+
+// More reports whether more results are available beyond this page.
+func (p *BrewerySearchPage) More() bool {
+	return p.Offset+len(p.Breweries) < p.Found
+}
+
+// SearchPage searches for information about breweries, using the specified
+// search query, offset, and limit parameters.  Unlike SearchOffsetLimit, it
+// returns a BrewerySearchPage which contains the total number of matching
+// breweries.
+//
+// 50 breweries is the maximum number of results which may be returned by one call.
+func (b *BreweryService) SearchPage(query string, offset int, limit int) (*BrewerySearchPage, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxSearchLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"q":      []string{query},
 		"offset": []string{strconv.Itoa(offset)},
@@ -30,6 +71,8 @@ func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int)
 	// Temporary struct to unmarshal breweries JSON
 	var v struct {
 		Response struct {
+			SearchQuery
+			Found   int `json:"found"`
 			Brewery struct {
 				Count int `json:"count"`
 				Items []struct {
@@ -51,5 +94,16 @@ func (b *BreweryService) SearchOffsetLimit(query string, offset int, limit int)
 		breweries[i] = v.Response.Brewery.Items[i].Brewery.export()
 	}
 
-	return breweries, res, nil
+	page := &BrewerySearchPage{
+		Found:     v.Response.Found,
+		Query:     v.Response.SearchQuery,
+		Offset:    offset,
+		Limit:     limit,
+		Breweries: breweries,
+	}
+	if err := b.client.afterResponse(res, page); err != nil {
+		return nil, res, err
+	}
+
+	return page, res, nil
 }
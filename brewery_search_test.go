@@ -93,6 +93,38 @@ func TestClientBrewerySearchOffsetLimitOK(t *testing.T) {
 	}
 }
 
+// TestClientBrewerySearchPageOK verifies that Client.Brewery.SearchPage
+// returns the total number of matching breweries with a page of results.
+func TestClientBrewerySearchPageOK(t *testing.T) {
+	c, done := brewerySearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"offset": []string{"1"},
+			"limit":  []string{"1"},
+		})
+
+		w.Write(brewerySearchJSON)
+	})
+	defer done()
+
+	p, _, err := c.Brewery.SearchPage("russian river", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f := p.Found; f != 3 {
+		t.Fatalf("unexpected Found: %d != %d", f, 3)
+	}
+	if q := p.Query; q.Term != "russian river" || q.Type != "brewery" {
+		t.Fatalf("unexpected Query: %+v", q)
+	}
+	if l := len(p.Breweries); l != 1 {
+		t.Fatalf("unexpected number of breweries: %d != %d", l, 1)
+	}
+	if !p.More() {
+		t.Fatal("expected more results")
+	}
+}
+
 // brewerySearchTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user breweries API.
 func brewerySearchTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -127,6 +159,9 @@ var brewerySearchJSON = []byte(`{
   },
   "notifications": {},
   "response": {
+  "search_type": "brewery",
+  "term": "russian river",
+  "found": 3,
   "brewery": {
     "count": 1,
     "items": [
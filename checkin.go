@@ -1,7 +1,10 @@
 package untappd
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -9,8 +12,9 @@ import (
 // checkin, including the checkin ID, comment, when the checkin occurred, and
 // information about the user, beer, and brewery for a given checkin.
 type Checkin struct {
-	// Metadata from Untappd.
-	ID int
+	// Metadata from Untappd.  Checkin IDs exceed the range of a 32-bit
+	// integer, so all Untappd IDs are represented using int64.
+	ID int64
 
 	// Time when this checkin was added to Untappd.
 	Created time.Time
@@ -18,7 +22,8 @@ type Checkin struct {
 	// User comment for this checkin.  May be blank.
 	Comment string
 
-	// If applicable, the specified user's rating for this beer.
+	// If applicable, the specified user's rating for this beer, from 0.25
+	// to 5 in increments of 0.25, or zero if the user did not rate it.
 	UserRating float64
 
 	// The user checking in.
@@ -39,67 +44,218 @@ type Checkin struct {
 	// Badges earned when this checkin was submitted.
 	Badges []*Badge
 
-	// Toasts by Untappd users for this checkin.
-	Toasts []*Toast
+	// Friends tagged by the user as being present for this checkin.  Only
+	// basic information about each friend is available.
+	TaggedFriends []*User
 
-	// Comments by Untappd users about this checkin.
-	Comments []*Comment
+	// Toasts by Untappd users for this checkin.  The API may only return
+	// a subset of toasts, so TotalToasts contains the total number of toasts.
+	Toasts      []*Toast
+	TotalToasts int
+
+	// If applicable, has the authenticated user toasted this checkin?
+	Toasted bool
+
+	// Comments by Untappd users about this checkin.  The API may only return
+	// a subset of comments, so TotalComments contains the total number of
+	// comments.
+	Comments      []*Comment
+	TotalComments int
+
+	// The application used to submit this checkin.
+	Source CheckinSource
 
 	// Media uploaded by Untappd users about this checkin
 	// If the slice has zero length, no media exists for this checkin.
 	Media []*CheckinMedia
+
+	// Toasts, comments, and media which have not yet been decoded, when
+	// the Client uses WithLazyDecoding.
+	deferred *deferredCheckin
+}
+
+// Photo returns the URL of the first photo attached to this checkin, in the
+// smallest size which is at least the specified width in pixels.  If no
+// photos are attached to this checkin, or deferred media cannot be decoded,
+// an empty URL and false are returned.
+func (c *Checkin) Photo(width int) (url.URL, bool) {
+	media, err := c.LoadMedia()
+	if err != nil {
+		return url.URL{}, false
+	}
+
+	for _, m := range media {
+		if m == nil || len(m.Photo) == 0 {
+			continue
+		}
+
+		return m.Photo.BestFor(width), true
+	}
+
+	return url.URL{}, false
+}
+
+// CheckinSource represents the application used to submit a Checkin, such as
+// an official Untappd mobile application or a third-party integration.
+type CheckinSource struct {
+	Name    string
+	Website url.URL
+}
+
+// rawCheckinSource is the raw JSON representation of an Untappd checkin's
+// source application.  Its data is unmarshaled from JSON and then exported
+// to a CheckinSource struct.
+type rawCheckinSource struct {
+	Name    string `json:"app_name"`
+	Website URL    `json:"app_website"`
+}
+
+// export creates an exported CheckinSource from a rawCheckinSource struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawCheckinSource) export() CheckinSource {
+	return CheckinSource{
+		Name:    r.Name,
+		Website: url.URL(r.Website),
+	}
 }
 
 // CheckinMedia contains links to media regarding a Checkin.  Included are links
-// to a small, medium, large, and original photos for a given Checkin.
+// to the small, medium, large, and original sizes of a photo for a given Checkin.
 type CheckinMedia struct {
-	PhotoID       int
-	SmallPhoto    url.URL
-	MediumPhoto   url.URL
-	LargePhoto    url.URL
-	OriginalPhoto url.URL
+	PhotoID int64
+	Photo   ImageSet
+}
+
+// Media is a photo attached to an Untappd checkin, along with the user, beer,
+// brewery, and venue of the checkin.  Media is reported by UserService.Info
+// and VenueService.Info.
+type Media struct {
+	PhotoID   int64
+	Photo     ImageSet
+	CheckinID int64
+	Created   time.Time
+
+	// Venue is nil if the checkin has no venue.
+	User    *User
+	Beer    *Beer
+	Brewery *Brewery
+	Venue   *Venue
 }
 
 // rawCheckin is the raw JSON representation of an Untappd checkin.  Its data is
 // unmarshaled from JSON and then exported to a Checkin struct.
 type rawCheckin struct {
-	ID         int           `json:"checkin_id"`
+	rawCheckinSummary
+
+	Toasts   rawCheckinToasts   `json:"toasts"`
+	Comments rawCheckinComments `json:"comments"`
+	Media    rawCheckinMediaSet `json:"media"`
+}
+
+// rawLazyCheckin is like rawCheckin, but its toasts, comments, and media are
+// retained as raw JSON, to be decoded on demand.
+type rawLazyCheckin struct {
+	rawCheckinSummary
+
+	Toasts   json.RawMessage `json:"toasts"`
+	Comments json.RawMessage `json:"comments"`
+	Media    json.RawMessage `json:"media"`
+}
+
+// rawCheckinSummary contains the members of an Untappd checkin which are
+// always decoded immediately.
+type rawCheckinSummary struct {
+	ID         int64         `json:"checkin_id"`
 	Beer       rawBeer       `json:"beer"`
 	Brewery    rawBrewery    `json:"brewery"`
 	User       rawUser       `json:"user"`
 	Venue      responseVenue `json:"venue"`
 	UserRating float64       `json:"rating_score"`
 	Comment    string        `json:"checkin_comment"`
-	Created    responseTime  `json:"created_at"`
+	Created    Time          `json:"created_at"`
+
+	Source rawCheckinSource `json:"source"`
 
 	Badges struct {
 		Count int         `json:"count"`
 		Items []*rawBadge `json:"items"`
 	} `json:"badges"`
 
-	Toasts struct {
-		Count int         `json:"count"`
-		Items []*rawToast `json:"items"`
-	} `json:"toasts"`
+	TaggedFriends rawTaggedFriends `json:"tagged_friends"`
+}
 
-	Comments struct {
-		Count int           `json:"count"`
-		Items []*rawComment `json:"items"`
-	} `json:"comments"`
+// rawTaggedFriends is the raw JSON representation of the friends tagged in an
+// Untappd checkin.
+type rawTaggedFriends struct {
+	Items []struct {
+		// Friends may be reported directly, or wrapped in a user object
+		rawUser
+		User *rawUser `json:"user"`
+	} `json:"items"`
+}
 
-	Media struct {
-		Count int                `json:"count"`
-		Items []*rawCheckinMedia `json:"items"`
-	} `json:"media"`
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawTaggedFriends) UnmarshalJSON(data []byte) error {
+	// Checkins without tagged friends may report an empty array instead of
+	// an object
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	type friends rawTaggedFriends
+	return json.Unmarshal(data, (*friends)(r))
+}
+
+// export creates a slice of exported Users from a rawTaggedFriends struct.
+func (r *rawTaggedFriends) export() []*User {
+	if len(r.Items) == 0 {
+		return nil
+	}
+
+	users := make([]*User, 0, len(r.Items))
+	for i := range r.Items {
+		u := &r.Items[i].rawUser
+		if r.Items[i].User != nil {
+			u = r.Items[i].User
+		}
+
+		users = append(users, u.export())
+	}
+
+	return users
+}
+
+// rawCheckinToasts is the raw JSON representation of the toasts for an
+// Untappd checkin.
+type rawCheckinToasts struct {
+	TotalCount int         `json:"total_count"`
+	Count      int         `json:"count"`
+	AuthToast  bool        `json:"auth_toast"`
+	Items      []*rawToast `json:"items"`
+}
+
+// rawCheckinComments is the raw JSON representation of the comments for an
+// Untappd checkin.
+type rawCheckinComments struct {
+	TotalCount int           `json:"total_count"`
+	Count      int           `json:"count"`
+	Items      []*rawComment `json:"items"`
+}
+
+// rawCheckinMediaSet is the raw JSON representation of the media for an
+// Untappd checkin.
+type rawCheckinMediaSet struct {
+	Count int                `json:"count"`
+	Items []*rawCheckinMedia `json:"items"`
 }
 
 type rawCheckinMedia struct {
-	PhotoID int `json:"photo_id"`
+	PhotoID int64 `json:"photo_id"`
 	Photo   struct {
-		SmallPhoto    responseURL `json:"photo_img_sm"`
-		MediumPhoto   responseURL `json:"photo_img_med"`
-		LargePhoto    responseURL `json:"photo_img_lg"`
-		OriginalPhoto responseURL `json:"photo_img_og"`
+		SmallPhoto    URL `json:"photo_img_sm"`
+		MediumPhoto   URL `json:"photo_img_md"`
+		LargePhoto    URL `json:"photo_img_lg"`
+		OriginalPhoto URL `json:"photo_img_og"`
 	} `json:"photo"`
 }
 
@@ -107,17 +263,42 @@ type rawCheckinMedia struct {
 // for more useful structures to be created for client consumption.
 func (r *rawCheckinMedia) export() *CheckinMedia {
 	return &CheckinMedia{
-		PhotoID:       r.PhotoID,
-		SmallPhoto:    url.URL(r.Photo.SmallPhoto),
-		MediumPhoto:   url.URL(r.Photo.MediumPhoto),
-		LargePhoto:    url.URL(r.Photo.LargePhoto),
-		OriginalPhoto: url.URL(r.Photo.OriginalPhoto),
+		PhotoID: r.PhotoID,
+		Photo: newImageSet(
+			Image{Width: imageWidthSmall, URL: url.URL(r.Photo.SmallPhoto)},
+			Image{Width: imageWidthMedium, URL: url.URL(r.Photo.MediumPhoto)},
+			Image{Width: imageWidthLarge, URL: url.URL(r.Photo.LargePhoto)},
+			Image{Width: imageWidthOriginal, URL: url.URL(r.Photo.OriginalPhoto)},
+		),
 	}
 }
 
 // export creates an exported Checkin from a rawCheckin struct, allowing for more
 // useful structures to be created for client consumption.
 func (r *rawCheckin) export() *Checkin {
+	c := r.rawCheckinSummary.export()
+	r.Toasts.apply(c)
+	r.Comments.apply(c)
+	r.Media.apply(c)
+
+	return c
+}
+
+// export creates an exported Checkin from a rawLazyCheckin struct.  Its
+// toasts, comments, and media are decoded when first accessed.
+func (r *rawLazyCheckin) export() *Checkin {
+	c := r.rawCheckinSummary.export()
+	c.deferred = &deferredCheckin{
+		toasts:   r.Toasts,
+		comments: r.Comments,
+		media:    r.Media,
+	}
+
+	return c
+}
+
+// export creates a Checkin containing the members of a rawCheckinSummary.
+func (r *rawCheckinSummary) export() *Checkin {
 	c := &Checkin{
 		ID:         r.ID,
 		Comment:    r.Comment,
@@ -126,6 +307,7 @@ func (r *rawCheckin) export() *Checkin {
 		Beer:       r.Beer.export(),
 		Brewery:    r.Brewery.export(),
 		User:       r.User.export(),
+		Source:     r.Source.export(),
 	}
 
 	// If no venue was set in the response JSON, venue will be nil
@@ -140,24 +322,160 @@ func (r *rawCheckin) export() *Checkin {
 		badges[i] = r.Badges.Items[i].export()
 	}
 	c.Badges = badges
+	c.TaggedFriends = r.TaggedFriends.export()
 
-	toasts := make([]*Toast, r.Toasts.Count)
-	for i := range r.Toasts.Items {
-		toasts[i] = r.Toasts.Items[i].export()
+	return c
+}
+
+// apply stores the toasts from a rawCheckinToasts struct in a Checkin.
+func (r *rawCheckinToasts) apply(c *Checkin) {
+	toasts := make([]*Toast, r.Count)
+	for i := range r.Items {
+		toasts[i] = r.Items[i].export()
 	}
 	c.Toasts = toasts
+	c.TotalToasts = r.TotalCount
+	c.Toasted = r.AuthToast
+}
 
-	comments := make([]*Comment, r.Comments.Count)
-	for i := range r.Comments.Items {
-		comments[i] = r.Comments.Items[i].export()
+// apply stores the comments from a rawCheckinComments struct in a Checkin.
+func (r *rawCheckinComments) apply(c *Checkin) {
+	comments := make([]*Comment, r.Count)
+	for i := range r.Items {
+		comments[i] = r.Items[i].export()
 	}
 	c.Comments = comments
+	c.TotalComments = r.TotalCount
+}
 
-	media := make([]*CheckinMedia, r.Media.Count)
-	for i := range r.Media.Items {
-		media[i] = r.Media.Items[i].export()
+// apply stores the media from a rawCheckinMediaSet struct in a Checkin.
+func (r *rawCheckinMediaSet) apply(c *Checkin) {
+	media := make([]*CheckinMedia, r.Count)
+	for i := range r.Items {
+		media[i] = r.Items[i].export()
 	}
 	c.Media = media
+}
 
-	return c
+// deferredCheckin retains the raw JSON of a Checkin's toasts, comments, and
+// media, when lazy decoding is enabled.  Each block is discarded once it
+// has been decoded.
+type deferredCheckin struct {
+	mu       sync.Mutex
+	toasts   json.RawMessage
+	comments json.RawMessage
+	media    json.RawMessage
+}
+
+// LoadToasts returns the toasts for this checkin, decoding them first if they
+// were deferred by a Client using WithLazyDecoding.  Toasts, TotalToasts,
+// and Toasted are populated as a side effect.
+//
+// LoadToasts is safe for concurrent use.
+func (c *Checkin) LoadToasts() ([]*Toast, error) {
+	if d := c.deferred; d != nil {
+		if err := d.decode(&d.toasts, &rawCheckinToasts{}, c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Toasts, nil
+}
+
+// LoadComments returns the comments for this checkin, decoding them first if
+// they were deferred by a Client using WithLazyDecoding.  Comments and
+// TotalComments are populated as a side effect.
+//
+// LoadComments is safe for concurrent use.
+func (c *Checkin) LoadComments() ([]*Comment, error) {
+	if d := c.deferred; d != nil {
+		if err := d.decode(&d.comments, &rawCheckinComments{}, c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Comments, nil
+}
+
+// LoadMedia returns the media for this checkin, decoding it first if it was
+// deferred by a Client using WithLazyDecoding.  Media is populated as a side
+// effect.
+//
+// LoadMedia is safe for concurrent use.
+func (c *Checkin) LoadMedia() ([]*CheckinMedia, error) {
+	if d := c.deferred; d != nil {
+		if err := d.decode(&d.media, &rawCheckinMediaSet{}, c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Media, nil
+}
+
+// A checkinBlock is a raw JSON block which can be stored in a Checkin.
+type checkinBlock interface {
+	apply(c *Checkin)
+}
+
+// decode unmarshals a deferred raw JSON block into r and stores it in c, if
+// the block has not already been decoded.
+func (d *deferredCheckin) decode(raw *json.RawMessage, r checkinBlock, c *Checkin) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(*raw) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(*raw, r); err != nil {
+		return err
+	}
+	r.apply(c)
+
+	*raw = nil
+	return nil
+}
+
+// rawMediaItems is the raw JSON representation of a list of photos attached
+// to Untappd checkins.
+type rawMediaItems []rawMedia
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawMediaItems) UnmarshalJSON(data []byte) error {
+	type items rawMediaItems
+	return unmarshalItems(data, (*items)(r))
+}
+
+// rawMedia is the raw JSON representation of a photo attached to an Untappd
+// checkin.
+type rawMedia struct {
+	rawCheckinMedia
+
+	CheckinID int64         `json:"checkin_id"`
+	Created   Time          `json:"created_at"`
+	User      rawUser       `json:"user"`
+	Beer      rawBeer       `json:"beer"`
+	Brewery   rawBrewery    `json:"brewery"`
+	Venue     responseVenue `json:"venue"`
+}
+
+// export creates an exported Media from a rawMedia struct, allowing for more
+// useful structures to be created for client consumption.
+func (r *rawMedia) export() *Media {
+	m := &Media{
+		PhotoID:   r.PhotoID,
+		Photo:     r.rawCheckinMedia.export().Photo,
+		CheckinID: r.CheckinID,
+		Created:   time.Time(r.Created),
+		User:      r.User.export(),
+		Beer:      r.Beer.export(),
+		Brewery:   r.Brewery.export(),
+	}
+
+	if r.Venue.ID != 0 && r.Venue.Name != "" {
+		rv := rawVenue(r.Venue)
+		m.Venue = rv.export()
+	}
+
+	return m
 }
@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"net/http"
 	"testing"
 )
 
@@ -30,6 +31,8 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 					UserName: "gregavola",
 				},
 			}},
+			TotalToasts: 3,
+			Toasted:     true,
 			Comments: []*Comment{{
 				ID:      1,
 				Comment: "hello, world",
@@ -37,6 +40,7 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 					UserName: "gregavola",
 				},
 			}},
+			TotalComments: 2,
 		},
 	}
 
@@ -77,7 +81,91 @@ func assertExpectedCheckins(t *testing.T, checkins []*Checkin) {
 		if checkins[i].Comments[0].User.UserName != expected[i].Comments[0].User.UserName {
 			t.Fatalf("unexpected checkin Toast.User.UserName: %q != %q", checkins[i].Comments[0].User.UserName, expected[i].Comments[0].User.UserName)
 		}
+		if !checkins[i].Comments[0].Owner || !checkins[i].Comments[0].Editor {
+			t.Fatalf("unexpected checkin Comment owner and editor: %v, %v", checkins[i].Comments[0].Owner, checkins[i].Comments[0].Editor)
+		}
+		if checkins[i].Comments[0].Created.IsZero() {
+			t.Fatal("unexpected zero checkin Comment.Created")
+		}
+		if checkins[i].TotalToasts != expected[i].TotalToasts {
+			t.Fatalf("unexpected checkin TotalToasts: %d != %d", checkins[i].TotalToasts, expected[i].TotalToasts)
+		}
+		if checkins[i].Toasted != expected[i].Toasted {
+			t.Fatalf("unexpected checkin Toasted: %v != %v", checkins[i].Toasted, expected[i].Toasted)
+		}
+		if l := len(checkins[i].Media); l != 1 {
+			t.Fatalf("unexpected number of checkin Media: %d != %d", l, 1)
+		}
+		if id := checkins[i].Media[0].PhotoID; id != 4321 {
+			t.Fatalf("unexpected checkin Media.PhotoID: %d != %d", id, 4321)
+		}
+		if l := len(checkins[i].Media[0].Photo); l != 4 {
+			t.Fatalf("unexpected number of checkin Media.Photo sizes: %d != %d", l, 4)
+		}
+		photo, ok := checkins[i].Photo(300)
+		if want := "https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_320x320.jpg"; !ok || photo.String() != want {
+			t.Fatalf("unexpected checkin Photo: %q != %q", photo.String(), want)
+		}
+		if n := checkins[i].Source.Name; n != "Untappd for iPhone - (V2)" {
+			t.Fatalf("unexpected checkin Source.Name: %q", n)
+		}
+		if w := checkins[i].Source.Website; w.Host != "untpd.it" {
+			t.Fatalf("unexpected checkin Source.Website host: %q", w.Host)
+		}
+		if l := len(checkins[i].TaggedFriends); l != 2 {
+			t.Fatalf("unexpected number of checkin TaggedFriends: %d != %d", l, 2)
+		}
+		for j, name := range []string{"mdlayher", "gildasch"} {
+			if n := checkins[i].TaggedFriends[j].UserName; n != name {
+				t.Fatalf("unexpected checkin TaggedFriends[%d].UserName: %q != %q", j, n, name)
+			}
+		}
+		if checkins[i].TotalComments != expected[i].TotalComments {
+			t.Fatalf("unexpected checkin TotalComments: %d != %d", checkins[i].TotalComments, expected[i].TotalComments)
+		}
+	}
+}
+
+// TestClientLazyCheckins verifies that a Client using lazy decoding defers
+// decoding of checkin toasts, comments, and media until they are loaded.
+func TestClientLazyCheckins(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(userCheckinsJSON)
+	})
+	defer done()
+
+	var cfg clientConfig
+	if err := WithLazyDecoding()(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.apply(c); err != nil {
+		t.Fatal(err)
+	}
+
+	checkins, _, err := c.User.Checkins("gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := checkins[0]
+	if ch.Beer.Name != "Brooklyn Bowl Pale Ale" {
+		t.Fatalf("unexpected beer Name: %q", ch.Beer.Name)
+	}
+	if ch.Toasts != nil || ch.Comments != nil || ch.Media != nil {
+		t.Fatal("expected toasts, comments, and media to be deferred")
+	}
+
+	if _, err := ch.LoadToasts(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.LoadComments(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.LoadMedia(); err != nil {
+		t.Fatal(err)
 	}
+
+	assertExpectedCheckins(t, checkins)
 }
 
 // Canned checkins JSON response, taken from documentation: https://untappd.com/api/docs#useractivityfeed
@@ -209,11 +297,14 @@ var userCheckinsJSON = []byte(`{
             }
           },
           "comments": {
-            "total_count": 0,
+            "total_count": 2,
             "count": 1,
             "items": [
               {
                 "comment_id": 1,
+                "comment_owner": true,
+                "comment_editor": true,
+                "created_at": "Sat, 13 Dec 2014 19:20:00 +0000",
                 "comment": "hello, world",
                 "user": {
                   "user_name": "gregavola"
@@ -222,9 +313,9 @@ var userCheckinsJSON = []byte(`{
             ]
           },
           "toasts": {
-            "total_count": 0,
+            "total_count": 3,
             "count": 1,
-            "auth_toast": false,
+            "auth_toast": true,
             "items": [
               {
                 "like_id": 1,
@@ -235,8 +326,18 @@ var userCheckinsJSON = []byte(`{
             ]
           },
           "media": {
-            "count": 0,
-            "items": []
+            "count": 1,
+            "items": [
+              {
+                "photo_id": 4321,
+                "photo": {
+                  "photo_img_sm": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_100x100.jpg",
+                  "photo_img_md": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_320x320.jpg",
+                  "photo_img_lg": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_640x640.jpg",
+                  "photo_img_og": "https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_raw.jpg"
+                }
+              }
+            ]
           },
           "source": {
             "app_name": "Untappd for iPhone - (V2)",
@@ -258,6 +359,21 @@ var userCheckinsJSON = []byte(`{
                 }
               }
             ]
+          },
+          "tagged_friends": {
+            "count": 2,
+            "items": [
+              {
+                "user": {
+                  "uid": 2,
+                  "user_name": "mdlayher"
+                }
+              },
+              {
+                "uid": 3,
+                "user_name": "gildasch"
+              }
+            ]
           }
         }
       ]
@@ -11,9 +11,12 @@ package untappd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -32,6 +35,10 @@ const (
 	// untappdUserAgent is the default user agent this package will report to
 	// the Untappd APIv4.
 	untappdUserAgent = "github.com/mdlayher/untappd"
+
+	// DefaultAPIVersion is the version of the Untappd API used by a Client,
+	// if no other version is specified using WithAPIVersion.
+	DefaultAPIVersion = "v4"
 )
 
 var (
@@ -49,6 +56,9 @@ var (
 
 // Client is a HTTP client for the Untappd APIv4.  It enables access to various
 // methods of the Untappd APIv4.
+//
+// A Client is safe for concurrent use by multiple goroutines.  Its exported
+// fields must not be modified once the Client is in use.
 type Client struct {
 	UserAgent string
 
@@ -60,42 +70,79 @@ type Client struct {
 
 	accessToken string
 
+	// Whether heavyweight checkin blocks are decoded on demand.
+	lazyDecoding bool
+
+	// Maximum size of HTTP response bodies, if greater than zero.
+	maxResponseSize int64
+
+	// Hooks invoked around each request.
+	interceptors []Interceptor
+
+	// The http.Client and configuration used to create this Client, so
+	// that it may be cloned.
+	base *http.Client
+	cfg  clientConfig
+
+	// Rate limit information and cached responses, which may be shared
+	// with clones, and outages of the Untappd APIv4, which are always
+	// shared with clones.
+	limits *rateLimitState
+	cache  *responseCache
+	outage *outageState
+
 	// Methods which require authentication
 	Auth interface {
 		// https://untappd.com/api/docs#checkin
 		Checkin(r CheckinRequest) (*Checkin, *http.Response, error)
 
+		// https://untappd.com/api/docs#toast
+		Toast(checkinID int64) (*http.Response, error)
+
+		// https://untappd.com/api/docs#addcomment
+		Comment(checkinID int64, comment string) (*http.Response, error)
+
 		// https://untappd.com/api/docs#activityfeed
 		Checkins() ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
+
+		// https://untappd.com/api/docs#notifications
+		Notifications() ([]*Notification, *http.Response, error)
+		PollNotifications(ctx context.Context, t *Tracker, interval time.Duration, fn func(ctx context.Context, n *Notification) error) error
 	}
 
 	// Methods involving a Beer
 	Beer interface {
 		// https://untappd.com/api/docs#beeractivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#beerinfo
-		Info(id int, compact bool) (*Beer, *http.Response, error)
+		Info(id int64, compact bool) (*Beer, *http.Response, error)
+		InfoBatch(ctx context.Context, ids []int64, compact bool) (map[int64]*Beer, error)
 
 		// https://untappd.com/api/docs#beersearch
 		Search(query string) ([]*Beer, *http.Response, error)
 		SearchOffsetLimitSort(query string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error)
+		SearchInBrewery(breweryID int64, query string) ([]*Beer, *http.Response, error)
+		SearchPage(query string, offset int, limit int, sort Sort) (*BeerSearchPage, *http.Response, error)
+		Resolve(brewery string, beer string) (*Beer, *http.Response, error)
 	}
 
 	// Methods involving a Brewery
 	Brewery interface {
 		// https://untappd.com/api/docs#breweryactivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#breweryinfo
-		Info(id int, compact bool) (*Brewery, *http.Response, error)
+		Info(id int64, compact bool) (*Brewery, *http.Response, error)
 
 		// https://untappd.com/api/docs#brewerysearch
 		Search(query string) ([]*Brewery, *http.Response, error)
 		SearchOffsetLimit(query string, offset int, limit int) ([]*Brewery, *http.Response, error)
+		SearchPage(query string, offset int, limit int) (*BrewerySearchPage, *http.Response, error)
+		Resolve(name string) (*Brewery, *http.Response, error)
 	}
 
 	// Methods involving a Local area
@@ -103,6 +150,8 @@ type Client struct {
 		// https://untappd.com/api/docs#theppublocal
 		Checkins(latitude float64, longitude float64) ([]*Checkin, *http.Response, error)
 		CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*Checkin, *http.Response, error)
+		Venues(ctx context.Context, latitude float64, longitude float64, radius int, units Distance) ([]*NearbyVenue, error)
+		OnTap(ctx context.Context, latitude float64, longitude float64, radius int, units Distance) ([]*TapList, error)
 	}
 
 	// Methods involving a User
@@ -114,14 +163,22 @@ type Client struct {
 		// https://untappd.com/api/docs#userbeers
 		Beers(username string) ([]*Beer, *http.Response, error)
 		BeersOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error)
+		BeersOffsetLimitSortFilter(username string, offset int, limit int, sort Sort, f BeerFilter) ([]*Beer, *http.Response, error)
+		AllBeers(ctx context.Context, username string, sort Sort) ([]*Beer, error)
 
 		// https://untappd.com/api/docs#useractivityfeed
 		Checkins(username string) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(username string, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
+		AllCheckins(ctx context.Context, username string) ([]*Checkin, error)
+		AllCheckinsFrom(ctx context.Context, username string, cur *Cursor) ([]*Checkin, error)
+		CheckinsSince(ctx context.Context, username string, since time.Time) ([]*Checkin, error)
+		CheckinsBetween(ctx context.Context, username string, start time.Time, end time.Time) ([]*Checkin, error)
 
 		// https://untappd.com/api/docs#userfriends
 		Friends(username string) ([]*User, *http.Response, error)
 		FriendsOffsetLimit(username string, offset int, limit int) ([]*User, *http.Response, error)
+		FriendGraph(ctx context.Context, username string, depth int) (*FriendGraph, error)
+		Leaderboard(ctx context.Context, usernames []string, since time.Time, until time.Time) (*Leaderboard, error)
 
 		// https://untappd.com/api/docs#userinfo
 		Info(username string, compact bool) (*User, *http.Response, error)
@@ -129,25 +186,30 @@ type Client struct {
 		// https://untappd.com/api/docs#userwishlist
 		WishList(username string) ([]*Beer, *http.Response, error)
 		WishListOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error)
+
+		DownloadLibrary(ctx context.Context, username string) (*Library, error)
 	}
 
 	// Methods involving a Venue
 	Venue interface {
 		// https://untappd.com/api/docs#venueactivityfeed
-		Checkins(id int) ([]*Checkin, *http.Response, error)
-		CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error)
+		Checkins(id int64) ([]*Checkin, *http.Response, error)
+		CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error)
 
 		// https://untappd.com/api/docs#venueinfo
-		Info(id int, compact bool) (*Venue, *http.Response, error)
+		Info(id int64, compact bool) (*Venue, *http.Response, error)
+		InfoBatch(ctx context.Context, ids []int64, compact bool) (map[int64]*Venue, error)
+		HydrateVenues(ctx context.Context, checkins []*Checkin, compact bool) error
 	}
 }
 
 // NewClient creates a properly initialized instance of Client, using the input
-// client ID, client secret, and http.Client.
+// client ID, client secret, and http.Client.  Optional ClientOptions may be
+// used to further configure the Client.
 //
 // To use a Client with the Untappd APIv4, you must register for an API key
 // here: https://untappd.com/api/register.
-func NewClient(clientID string, clientSecret string, client *http.Client) (*Client, error) {
+func NewClient(clientID string, clientSecret string, client *http.Client, opts ...ClientOption) (*Client, error) {
 	// Disallow empty ID and secret
 	if clientID == "" {
 		return nil, ErrNoClientID
@@ -157,11 +219,12 @@ func NewClient(clientID string, clientSecret string, client *http.Client) (*Clie
 	}
 
 	// Perform common client setup
-	return newClient(clientID, clientSecret, "", client)
+	return newClient(clientID, clientSecret, "", client, opts)
 }
 
 // NewAuthenticatedClient creates a properly initialized and authenticated instance
-// of Client, using the input access token and http.Client.
+// of Client, using the input access token and http.Client.  Optional
+// ClientOptions may be used to further configure the Client.
 //
 // NewAuthenticatedClient must be called in order to create a Client which can
 // access authenticated API actions, such as checking in beers, toasting other
@@ -172,19 +235,19 @@ func NewClient(clientID string, clientSecret string, client *http.Client) (*Clie
 // the OAuth Authentication procedure documented here:
 // https://untappd.com/api/docs#authentication.  Upon successful OAuth Authentication,
 // you will receive an access token which can be used with NewAuthenticatedClient.
-func NewAuthenticatedClient(accessToken string, client *http.Client) (*Client, error) {
+func NewAuthenticatedClient(accessToken string, client *http.Client, opts ...ClientOption) (*Client, error) {
 	// Disallow empty access token
 	if accessToken == "" {
 		return nil, ErrNoAccessToken
 	}
 
 	// Perform common client setup
-	return newClient("", "", accessToken, client)
+	return newClient("", "", accessToken, client, opts)
 }
 
 // newClient handles common setup logic for a Client for NewClient and
 // NewAuthenticatedClient.
-func newClient(clientID string, clientSecret string, accessToken string, client *http.Client) (*Client, error) {
+func newClient(clientID string, clientSecret string, accessToken string, client *http.Client, opts []ClientOption) (*Client, error) {
 	// If input client is nil, use http.DefaultClient
 	if client == nil {
 		client = http.DefaultClient
@@ -198,24 +261,105 @@ func newClient(clientID string, clientSecret string, accessToken string, client
 		url: &url.URL{
 			Scheme: "https",
 			Host:   "api.untappd.com",
-			Path:   "v4",
+			Path:   DefaultAPIVersion,
 		},
 
 		clientID:     clientID,
 		clientSecret: clientSecret,
 
 		accessToken: accessToken,
+
+		base:   client,
+		limits: &rateLimitState{},
+		cache:  newResponseCache(),
+		outage: &outageState{},
+	}
+
+	// Apply any optional configuration
+	var cfg clientConfig
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.apply(c); err != nil {
+		return nil, err
 	}
 
-	// Add "services" which allow access to various API methods
+	c.addServices()
+	return c, nil
+}
+
+// addServices adds "services" which allow access to various API methods.
+func (c *Client) addServices() {
 	c.Auth = &AuthService{client: c}
 	c.User = &UserService{client: c}
 	c.Beer = &BeerService{client: c}
 	c.Brewery = &BreweryService{client: c}
 	c.Venue = &VenueService{client: c}
 	c.Local = &LocalService{client: c}
+}
 
-	return c, nil
+// Clone creates a new Client with the same configuration as this Client,
+// and applies the input ClientOptions to it.  Clone is typically used with
+// WithAccessToken to cheaply create per-user authenticated Clients from one
+// base Client.
+//
+// The clone shares this Client's HTTP transport, unless transport options
+// such as WithProxy are specified.  The clone also shares this Client's rate
+// limit information, unless its credentials are changed, because the Untappd
+// APIv4 tracks rate limits for each set of credentials.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	cfg := c.cfg
+	cfg.interceptors = append([]Interceptor(nil), c.cfg.interceptors...)
+	cfg.transportChanged = false
+	cfg.credentials = nil
+	cfg.rateLimitStore = nil
+
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rate limit information for other credentials must not be stored in
+	// this Client's store
+	if cfg.credentials == nil && cfg.rateLimitStore == nil {
+		cfg.rateLimitStore = c.cfg.rateLimitStore
+	}
+
+	u := *c.url
+	nc := &Client{
+		UserAgent: c.UserAgent,
+
+		client: c.client,
+		url:    &u,
+
+		clientID:     c.clientID,
+		clientSecret: c.clientSecret,
+
+		accessToken: c.accessToken,
+
+		base:   c.base,
+		limits: c.limits,
+		cache:  c.cache,
+		outage: c.outage,
+	}
+	if cfg.credentials != nil {
+		nc.limits = &rateLimitState{}
+	}
+
+	// Rebuild the transport from the original http.Client only if
+	// transport options were specified
+	if cfg.transportChanged {
+		nc.client = c.base
+	}
+	if err := cfg.apply(nc); err != nil {
+		return nil, err
+	}
+
+	nc.addServices()
+	return nc, nil
 }
 
 // Error represents an error returned from the Untappd APIv4.
@@ -227,6 +371,91 @@ type Error struct {
 	Duration          time.Duration
 }
 
+// nonJSONSnippetSize is the maximum number of bytes of a response body which
+// are retained by a NonJSONResponseError.
+const nonJSONSnippetSize = 256
+
+// ErrNonJSONResponse is matched by errors.Is when the Untappd APIv4, or an
+// intermediary such as a proxy or CDN, returns a response which is not JSON.
+// The returned error is a *NonJSONResponseError.
+var ErrNonJSONResponse = errors.New("non-JSON response")
+
+// NonJSONResponseError is returned when a response body is not JSON, such as
+// an HTML maintenance page or challenge page.  It contains the HTTP status
+// code and content type, and a snippet from the beginning of the body.
+type NonJSONResponseError struct {
+	StatusCode  int
+	ContentType string
+	Snippet     string
+}
+
+// newNonJSONResponseError creates a NonJSONResponseError for the input HTTP
+// response, using a snippet from the beginning of its body.
+func newNonJSONResponseError(res *http.Response, snippet []byte) *NonJSONResponseError {
+	if len(snippet) > nonJSONSnippetSize {
+		snippet = snippet[:nonJSONSnippetSize]
+	}
+
+	return &NonJSONResponseError{
+		StatusCode:  res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+		Snippet:     string(snippet),
+	}
+}
+
+// Error returns the string representation of a NonJSONResponseError.
+func (e *NonJSONResponseError) Error() string {
+	return fmt.Sprintf("expected %s response, but received HTTP %d with content type %q: %q",
+		jsonContentType, e.StatusCode, e.ContentType, e.Snippet)
+}
+
+// Is reports whether target is ErrNonJSONResponse.
+func (e *NonJSONResponseError) Is(target error) bool {
+	return target == ErrNonJSONResponse
+}
+
+// decodeJSON decodes the body of an HTTP response into v.  If the body is
+// not valid JSON, a *NonJSONResponseError is returned.
+func decodeJSON(res *http.Response, v interface{}) error {
+	// Retain the beginning of the body in case it must be reported
+	var snippet bytes.Buffer
+	r := io.TeeReader(res.Body, &snippetWriter{buf: &snippet})
+
+	err := json.NewDecoder(r).Decode(v)
+
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) {
+		return newNonJSONResponseError(res, snippet.Bytes())
+	}
+
+	return err
+}
+
+// snippetWriter is an io.Writer which retains only the first
+// nonJSONSnippetSize bytes written to it.
+type snippetWriter struct {
+	buf *bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	if n := nonJSONSnippetSize - w.buf.Len(); n > 0 {
+		if len(p) < n {
+			n = len(p)
+		}
+		w.buf.Write(p[:n])
+	}
+
+	return len(p), nil
+}
+
+const (
+	// privateProfileErrorType and privateProfileDetail identify the Error
+	// returned by the APIv4 when a User's profile is private.
+	privateProfileErrorType = "invalid_param"
+	privateProfileDetail    = "This user's profile is private."
+)
+
 // Error returns the string representation of an Error.
 func (e Error) Error() string {
 	// Per APIv4 documentation, the "developer friendly" string should be used
@@ -239,10 +468,60 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%d [%s]: %s", e.Code, e.Type, details)
 }
 
+// Is reports whether target is ErrPrivateProfile, and the Error reports that
+// a User's profile is private, or whether target is ErrRateLimit, and the
+// Error reports that the rate limit is exhausted.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrPrivateProfile:
+		return e.isPrivateProfile()
+	case ErrRateLimit:
+		return e.Code == http.StatusTooManyRequests || e.Type == rateLimitErrorType
+	}
+
+	return false
+}
+
+// isPrivateProfile reports whether the Error was returned because a User's
+// profile is private.  The APIv4 reports private profiles using a generic
+// error type, so its specific error detail is matched as well.
+func (e Error) isPrivateProfile() bool {
+	return e.Code == http.StatusInternalServerError &&
+		e.Type == privateProfileErrorType &&
+		strings.HasPrefix(e.Detail, privateProfileDetail)
+}
+
 // request creates a new HTTP request, using the specified HTTP method and API endpoint.
 // Additionally, it accepts POST body parameters, GET query parameters, and an
 // optional struct which can be used to unmarshal result JSON.
 func (c *Client) request(method string, endpoint string, body url.Values, query url.Values, v interface{}) (*http.Response, error) {
+	return c.requestContext(context.Background(), method, endpoint, body, query, v)
+}
+
+// requestContext is like request, but the HTTP request is bound to the
+// input context, so that it may be canceled, or time out.
+func (c *Client) requestContext(ctx context.Context, method string, endpoint string, body url.Values, query url.Values, v interface{}) (*http.Response, error) {
+	// Allow interceptors to inspect and modify request parameters
+	info := &RequestInfo{
+		Method:   method,
+		Endpoint: endpoint,
+		Query:    query,
+		Body:     body,
+	}
+	ctx, err := c.beforeRequest(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	query, body = info.Query, info.Body
+
+	// Apply the policy for the service which handles this endpoint
+	policy := c.cfg.policy(endpoint)
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
 	// Generate relative URL using API root and endpoint
 	rel, err := url.Parse(fmt.Sprintf("%s/%s/", c.url.Path, endpoint))
 	if err != nil {
@@ -260,11 +539,15 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 		}
 	}
 
-	// Always prefer authenticated client access, using an access token.
-	// If no token is found, fall back to unauthenticated client ID and
-	// client secret.
-	if c.accessToken != "" {
-		q.Set("access_token", c.accessToken)
+	// Always prefer authenticated client access, using an access token
+	// from the context or the Client.  If no token is found, fall back to
+	// unauthenticated client ID and client secret.
+	token := c.accessToken
+	if t, ok := tokenFromContext(ctx); ok {
+		token = t
+	}
+	if token != "" {
+		q.Set("access_token", token)
 	} else {
 		q.Set("client_id", c.clientID)
 		q.Set("client_secret", c.clientSecret)
@@ -285,7 +568,7 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 	}
 
 	// Generate new HTTP request for appropriate URL
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -302,31 +585,125 @@ func (c *Client) request(method string, endpoint string, body url.Values, query
 	// Identify the client
 	req.Header.Add("User-Agent", c.UserAgent)
 
+	// In dry-run mode, only requests which do not modify data are sent
+	if c.cfg.dryRun && method != "GET" {
+		res, err := c.dryRun(req, info)
+		if err != nil {
+			return nil, err
+		}
+		observeResponse(ctx, res)
+		if v == nil {
+			return res, nil
+		}
+
+		return res, c.decode(res, endpoint, v, nil)
+	}
+
+	// Serve the request from the cache, if permitted by policy
+	var cacheKey string
+	if method == "GET" && (policy.CacheTTL > 0 || policy.CacheHeaders) && !bypassCache(ctx) {
+		cacheKey = req.URL.String()
+		if res, ok := c.cache.get(cacheKey, req, c.clock().Now()); ok {
+			if h := c.cfg.stats; h != nil {
+				h.ObserveCacheHit(ctx, CacheStats{
+					Service:  serviceFor(endpoint),
+					Endpoint: endpoint,
+				})
+			}
+			observeResponse(ctx, res)
+			if v == nil {
+				return res, nil
+			}
+
+			return res, c.decode(res, endpoint, v, nil)
+		}
+	}
+
+	// During an outage, fail without sending requests until the estimated
+	// retry time
+	if err := c.checkOutage(); err != nil {
+		return nil, err
+	}
+
 	// Invoke request using underlying HTTP client
-	res, err := c.client.Do(req)
+	res, err := c.do(req, endpoint, policy)
 	if err != nil {
-		return nil, err
+		return nil, c.detectOutage(nil, err)
 	}
 	defer res.Body.Close()
+	observeResponse(ctx, res)
+
+	// Guard against unexpectedly large response bodies, if configured
+	if max := c.maxResponseSize; max > 0 {
+		if res.ContentLength > max {
+			return res, &ResponseTooLargeError{Limit: max}
+		}
+
+		res.Body = newMaxBytesReader(res.Body, max)
+	}
+
+	// Track rate limit information for every response, even errors
+	c.updateRateLimitFrom(res)
+
+	// Retain the raw response body for a debug bundle, if enabled.  The
+	// body is read into memory at most once, and shared by debug bundles,
+	// the response cache, and decoding.
+	var raw []byte
+	if c.cfg.debugBundles {
+		raw, err = ioutil.ReadAll(res.Body)
+		if err != nil {
+			return res, err
+		}
+
+		res.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	}
 
 	// Check response for errors
 	if err := checkResponse(res); err != nil {
-		return res, err
+		c.writeDebugBundle(res, raw, err)
+		return res, c.detectOutage(res, err)
 	}
+	c.endOutage()
 
 	// If no second parameter was passed, do not attempt to handle response
 	if v == nil {
 		return res, nil
 	}
 
+	// Retain the response body for later requests, if permitted by policy
+	now := c.clock().Now()
+	if ttl := policy.cacheTTL(res.Header, now); cacheKey != "" && ttl > 0 {
+		if raw == nil {
+			raw, err = ioutil.ReadAll(res.Body)
+			if err != nil {
+				return res, err
+			}
+		}
+
+		c.cache.put(cacheKey, res.Header, raw, now, now.Add(ttl))
+	}
+
 	// Decode response body into v, returning response
-	return res, json.NewDecoder(res.Body).Decode(v)
+	err = c.decode(res, endpoint, v, raw)
+	c.writeDebugBundle(res, raw, err)
+
+	return res, c.detectOutage(res, err)
 }
 
 // getCheckins is the backing method for both any request which returns a
 // list of checkins.  It handles performing the necessary HTTP request
 // with the correct parameters, and returns a list of Checkins.
 func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.Response, error) {
+	return c.getCheckinsContext(context.Background(), endpoint, q)
+}
+
+// getCheckinsContext is like getCheckins, but the HTTP request is bound to
+// the input context.
+func (c *Client) getCheckinsContext(ctx context.Context, endpoint string, q url.Values) ([]*Checkin, *http.Response, error) {
+	if c.lazyDecoding {
+		return c.getLazyCheckinsContext(ctx, endpoint, q)
+	}
+
 	// Temporary struct to unmarshal checkin JSON
 	var v struct {
 		Response struct {
@@ -338,7 +715,7 @@ func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.R
 	}
 
 	// Perform request for user checkins by ID
-	res, err := c.request("GET", endpoint, nil, q, &v)
+	res, err := c.requestContext(ctx, "GET", endpoint, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -349,6 +726,40 @@ func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.R
 		checkins[i] = v.Response.Checkins.Items[i].export()
 	}
 
+	if err := c.afterResponse(res, checkins); err != nil {
+		return nil, res, err
+	}
+
+	return checkins, res, nil
+}
+
+// getLazyCheckinsContext is like getCheckinsContext, but the toasts, comments,
+// and media of each checkin are retained as raw JSON, and decoded on demand.
+func (c *Client) getLazyCheckinsContext(ctx context.Context, endpoint string, q url.Values) ([]*Checkin, *http.Response, error) {
+	// Temporary struct to unmarshal checkin JSON
+	var v struct {
+		Response struct {
+			Checkins struct {
+				Count int               `json:"count"`
+				Items []*rawLazyCheckin `json:"items"`
+			} `json:"checkins"`
+		} `json:"response"`
+	}
+
+	res, err := c.requestContext(ctx, "GET", endpoint, nil, q, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	checkins := make([]*Checkin, v.Response.Checkins.Count)
+	for i := range v.Response.Checkins.Items {
+		checkins[i] = v.Response.Checkins.Items[i].export()
+	}
+
+	if err := c.afterResponse(res, checkins); err != nil {
+		return nil, res, err
+	}
+
 	return checkins, res, nil
 }
 
@@ -357,7 +768,8 @@ func (c *Client) getCheckins(endpoint string, q url.Values) ([]*Checkin, *http.R
 func checkResponse(res *http.Response) error {
 	// Ensure correct content type
 	if cType := res.Header.Get("Content-Type"); !strings.HasPrefix(cType, jsonContentType) {
-		return fmt.Errorf("expected %s content type, but received %s", jsonContentType, cType)
+		b, _ := ioutil.ReadAll(io.LimitReader(res.Body, nonJSONSnippetSize))
+		return newNonJSONResponseError(res, b)
 	}
 
 	// Check for 200-range status code
@@ -369,16 +781,16 @@ func checkResponse(res *http.Response) error {
 	// a more consumable form on error output
 	var apiErr struct {
 		Meta struct {
-			Code              int              `json:"code"`
-			ErrorDetail       string           `json:"error_detail"`
-			ErrorType         string           `json:"error_type"`
-			DeveloperFriendly string           `json:"developer_friendly"`
-			ResponseTime      responseDuration `json:"response_time"`
+			Code              int      `json:"code"`
+			ErrorDetail       string   `json:"error_detail"`
+			ErrorType         string   `json:"error_type"`
+			DeveloperFriendly string   `json:"developer_friendly"`
+			ResponseTime      Duration `json:"response_time"`
 		} `json:"meta"`
 	}
 
 	// Unmarshal error response
-	if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
+	if err := decodeJSON(res, &apiErr); err != nil {
 		return err
 	}
 
@@ -2,6 +2,8 @@ package untappd
 
 import (
 	"bytes"
+	"crypto/tls"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -102,6 +104,48 @@ func TestErrorError(t *testing.T) {
 	}
 }
 
+// TestErrorIsPrivateProfile verifies that only the APIv4 error for private
+// profiles matches ErrPrivateProfile.
+func TestErrorIsPrivateProfile(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        []byte
+		private     bool
+	}{
+		{
+			description: "private profile",
+			body:        privateProfileErrJSON,
+			private:     true,
+		},
+		{
+			description: "invalid user",
+			body:        invalidUserErrJSON,
+		},
+		{
+			description: "unrelated mention of private",
+			body:        []byte(`{"meta":{"code":500,"error_detail":"Private venues cannot be used for checkins.","error_type":"invalid_param"}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(tt.body)
+		})
+
+		_, err := c.request("GET", "user/checkins/foo", nil, nil, nil)
+		done()
+
+		var aerr *Error
+		if !errors.As(err, &aerr) {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+		if got := errors.Is(err, ErrPrivateProfile); got != tt.private {
+			t.Fatalf("unexpected private profile match for test %q: %v != %v", tt.description, got, tt.private)
+		}
+	}
+}
+
 // TestClient_requestContainsAPIKeys verifies that both client_id and client_secret
 // are always present in API requests.
 func TestClient_requestContainsAPIKeys(t *testing.T) {
@@ -264,16 +308,136 @@ func TestClient_requestContainsBody(t *testing.T) {
 	}
 }
 
+// TestClientClone verifies that Client.Clone creates a Client with the same
+// configuration, overriding credentials and sharing the HTTP transport.
+func TestClientClone(t *testing.T) {
+	var tokens []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.URL.Query().Get("access_token"))
+		w.Header().Set(headerRateLimitRemaining, "10")
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	clone, err := c.Clone(WithAccessToken("user-token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.client != c.client {
+		t.Fatal("clone does not share the http.Client")
+	}
+	if *clone.url != *c.url {
+		t.Fatalf("unexpected clone URL: %v != %v", clone.url, c.url)
+	}
+
+	if _, err := clone.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokens) != 2 || tokens[0] != "user-token" || tokens[1] != "" {
+		t.Fatalf("unexpected access tokens: %v", tokens)
+	}
+
+	// Credentials were changed, so rate limits are tracked separately,
+	// while a clone with the same credentials shares them
+	if clone.limits == c.limits {
+		t.Fatal("clone with new credentials shares rate limits")
+	}
+	same, err := c.Clone(WithLazyDecoding())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.limits != c.limits || !same.lazyDecoding || c.lazyDecoding {
+		t.Fatal("unexpected clone configuration")
+	}
+
+	if _, err := c.Clone(WithAccessToken("")); err != ErrNoAccessToken {
+		t.Fatalf("unexpected error for empty access token: %v", err)
+	}
+}
+
+// TestClientCloneTransport verifies that transport options passed to
+// Client.Clone produce a new http.Client, retaining earlier options.
+func TestClientCloneTransport(t *testing.T) {
+	c, err := NewClient("foo", "bar", nil, WithTLSConfig(&tls.Config{ServerName: "example.com"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy, _ := url.Parse("http://proxy.example.com:3128")
+	clone, err := c.Clone(WithProxy(proxy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.client == c.client {
+		t.Fatal("clone with transport options shares the http.Client")
+	}
+
+	tr := clone.client.Transport.(*http.Transport)
+	if tr.TLSClientConfig == nil || tr.TLSClientConfig.ServerName != "example.com" || tr.Proxy == nil {
+		t.Fatal("clone transport does not retain all options")
+	}
+}
+
 // Test_checkResponseWrongContentType verifies that checkResponse returns an error
 // when the Content-Type header does not indicate application/json.
 func Test_checkResponseWrongContentType(t *testing.T) {
 	withHTTPResponse(t, http.StatusOK, "foo/bar", nil, func(t *testing.T, res *http.Response) {
-		if err := checkResponse(res); err.Error() != "expected application/json content type, but received foo/bar" {
-			t.Fatal(err)
+		err := checkResponse(res)
+		if !errors.Is(err, ErrNonJSONResponse) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		nerr := err.(*NonJSONResponseError)
+		if nerr.StatusCode != http.StatusOK || nerr.ContentType != "foo/bar" {
+			t.Fatalf("unexpected error status code and content type: %d, %q", nerr.StatusCode, nerr.ContentType)
 		}
 	})
 }
 
+// TestClientRequestNonJSONResponse verifies that HTML responses, including
+// those which claim to be JSON, result in a NonJSONResponseError containing
+// a snippet of the body.
+func TestClientRequestNonJSONResponse(t *testing.T) {
+	html := "<html><body>Down for maintenance</body></html>"
+
+	var tests = []struct {
+		description string
+		code        int
+		contentType string
+	}{
+		{"HTML content type", http.StatusServiceUnavailable, "text/html"},
+		{"JSON content type", http.StatusOK, jsonContentType},
+		{"JSON content type error", http.StatusBadGateway, jsonContentType},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", tt.contentType)
+			w.WriteHeader(tt.code)
+			w.Write([]byte(html))
+		})
+
+		var v interface{}
+		_, err := c.request("GET", "foo", nil, nil, &v)
+		done()
+
+		var nerr *NonJSONResponseError
+		if !errors.As(err, &nerr) || !errors.Is(err, ErrNonJSONResponse) {
+			t.Fatalf("unexpected error for test %q: %v", tt.description, err)
+		}
+		if nerr.StatusCode != tt.code {
+			t.Fatalf("unexpected status code for test %q: %d != %d", tt.description, nerr.StatusCode, tt.code)
+		}
+		if nerr.Snippet != html {
+			t.Fatalf("unexpected snippet for test %q: %q != %q", tt.description, nerr.Snippet, html)
+		}
+	}
+}
+
 // Test_checkResponseEOF verifies that checkResponse returns an io.EOF when no
 // JSON body is found in the HTTP response body.
 func Test_checkResponseJSONEOF(t *testing.T) {
@@ -570,6 +734,9 @@ var apiErrJSON = []byte(`{
 // invalidUserErrJSON is canned JSON used to test for invalid user handling
 var invalidUserErrJSON = []byte(`{"meta":{"code":500,"error_detail":"There is no user with that username.","error_type":"invalid_auth","response_time":{"time":0,"measure":"seconds"}}}`)
 
+// privateProfileErrJSON is canned JSON used to test for private user handling
+var privateProfileErrJSON = []byte(`{"meta":{"code":500,"error_detail":"This user's profile is private. You must be friends with them to view their activity.","error_type":"invalid_param","developer_friendly":"","response_time":{"time":0,"measure":"seconds"}}}`)
+
 // invalidBeerErrJSON is canned JSON used to test for invalid beer handling
 var invalidBeerErrJSON = []byte(`{"meta":{"code":500,"error_detail":"This Beer ID is invalid.","error_type":"invalid_param","response_time":{"time":0,"measure":"seconds"}}}`)
 
@@ -0,0 +1,64 @@
+package untappd
+
+import (
+	"context"
+	"time"
+)
+
+// A Clock provides the current time and timers to a Client.  All
+// time-dependent behavior of a Client, such as rate limit reset times, cache
+// expiry, retry backoff, and pacing of Scheduler tasks, uses its Clock, so
+// that it may be tested deterministically or simulated.
+//
+// Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// WithClock sets the Clock used by a Client.  If WithClock is not used, the
+// system clock is used.
+func WithClock(clk Clock) ClientOption {
+	return func(c *clientConfig) error {
+		c.clock = clk
+		return nil
+	}
+}
+
+// systemClock is a Clock which uses the system's time.
+type systemClock struct{}
+
+// Now implements Clock.
+func (systemClock) Now() time.Time { return time.Now() }
+
+// After implements Clock.
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clock returns the Clock used by the Client.
+func (c *Client) clock() Clock {
+	if c.cfg.clock != nil {
+		return c.cfg.clock
+	}
+
+	return systemClock{}
+}
+
+// sleepUntil waits until the input time according to the input Clock, or
+// until the context is canceled.
+func sleepUntil(ctx context.Context, clk Clock, t time.Time) error {
+	d := t.Sub(clk.Now())
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clk.After(d):
+		return nil
+	}
+}
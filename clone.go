@@ -0,0 +1,252 @@
+package untappd
+
+// Clone methods create deep copies of response structs, so that a copy may be
+// modified or handed to another goroutine without affecting the original.
+// Values are expected to form a tree, as values returned by a Client do, and
+// url.URL values are copied by value, as their Userinfo is immutable.
+
+// Clone returns a deep copy of a Beer.  Clone returns nil if b is nil.
+func (b *Beer) Clone() *Beer {
+	if b == nil {
+		return nil
+	}
+
+	bb := *b
+	bb.Labels = cloneImageSet(b.Labels)
+	bb.Brewery = b.Brewery.Clone()
+
+	if b.Friends != nil {
+		bb.Friends = make([]FriendRating, len(b.Friends))
+		for i, f := range b.Friends {
+			f.User = f.User.Clone()
+			bb.Friends[i] = f
+		}
+	}
+
+	return &bb
+}
+
+// Clone returns a deep copy of a Brewery.  Clone returns nil if b is nil.
+func (b *Brewery) Clone() *Brewery {
+	if b == nil {
+		return nil
+	}
+
+	bb := *b
+	if b.Beers != nil {
+		bb.Beers = make([]BeerSummary, len(b.Beers))
+		for i, s := range b.Beers {
+			s.Beer = s.Beer.Clone()
+			bb.Beers[i] = s
+		}
+	}
+	bb.Owners = cloneBreweries(b.Owners)
+	bb.Collaborators = cloneBreweries(b.Collaborators)
+
+	return &bb
+}
+
+// Clone returns a deep copy of a Checkin.  Toasts, comments, and media which
+// were deferred by a Client using WithLazyDecoding remain deferred in the
+// copy, and are decoded independently of the original.  Clone returns nil if
+// c is nil.
+func (c *Checkin) Clone() *Checkin {
+	if c == nil {
+		return nil
+	}
+
+	cc := *c
+	cc.User = c.User.Clone()
+	cc.Beer = c.Beer.Clone()
+	cc.Brewery = c.Brewery.Clone()
+	cc.Venue = c.Venue.Clone()
+	cc.Badges = cloneBadges(c.Badges)
+
+	if c.TaggedFriends != nil {
+		cc.TaggedFriends = make([]*User, len(c.TaggedFriends))
+		for i, u := range c.TaggedFriends {
+			cc.TaggedFriends[i] = u.Clone()
+		}
+	}
+
+	if c.Toasts != nil {
+		cc.Toasts = make([]*Toast, len(c.Toasts))
+		for i, t := range c.Toasts {
+			if t == nil {
+				continue
+			}
+
+			tt := *t
+			tt.User = t.User.Clone()
+			cc.Toasts[i] = &tt
+		}
+	}
+
+	if c.Comments != nil {
+		cc.Comments = make([]*Comment, len(c.Comments))
+		for i, m := range c.Comments {
+			if m == nil {
+				continue
+			}
+
+			mm := *m
+			mm.User = m.User.Clone()
+			cc.Comments[i] = &mm
+		}
+	}
+
+	if c.Media != nil {
+		cc.Media = make([]*CheckinMedia, len(c.Media))
+		for i, m := range c.Media {
+			if m == nil {
+				continue
+			}
+
+			cc.Media[i] = &CheckinMedia{
+				PhotoID: m.PhotoID,
+				Photo:   cloneImageSet(m.Photo),
+			}
+		}
+	}
+
+	// Each copy decodes its deferred blocks into itself, so the blocks which
+	// remain must not be shared.  Raw JSON is never modified in place.
+	if d := c.deferred; d != nil {
+		d.mu.Lock()
+		cc.deferred = &deferredCheckin{
+			toasts:   d.toasts,
+			comments: d.comments,
+			media:    d.media,
+		}
+		d.mu.Unlock()
+	}
+
+	return &cc
+}
+
+// Clone returns a deep copy of a User.  Clone returns nil if u is nil.
+func (u *User) Clone() *User {
+	if u == nil {
+		return nil
+	}
+
+	uu := *u
+	if u.RecentBeers != nil {
+		uu.RecentBeers = make([]*Beer, len(u.RecentBeers))
+		for i, b := range u.RecentBeers {
+			uu.RecentBeers[i] = b.Clone()
+		}
+	}
+	uu.RecentMedia = cloneMedia(u.RecentMedia)
+
+	return &uu
+}
+
+// Clone returns a deep copy of a Venue.  Clone returns nil if v is nil.
+func (v *Venue) Clone() *Venue {
+	if v == nil {
+		return nil
+	}
+
+	vv := *v
+	if v.Categories != nil {
+		vv.Categories = make([]VenueCategory, len(v.Categories))
+		copy(vv.Categories, v.Categories)
+	}
+
+	if v.TopBeers != nil {
+		vv.TopBeers = make([]*Beer, len(v.TopBeers))
+		for i, b := range v.TopBeers {
+			vv.TopBeers[i] = b.Clone()
+		}
+	}
+
+	if v.PopularBeers != nil {
+		vv.PopularBeers = make([]VenueBeer, len(v.PopularBeers))
+		for i, b := range v.PopularBeers {
+			b.Beer = b.Beer.Clone()
+			vv.PopularBeers[i] = b
+		}
+	}
+
+	vv.Media = cloneMedia(v.Media)
+
+	if v.Checkins != nil {
+		vv.Checkins = make([]*Checkin, len(v.Checkins))
+		for i, c := range v.Checkins {
+			vv.Checkins[i] = c.Clone()
+		}
+	}
+
+	return &vv
+}
+
+// cloneImageSet returns a copy of an ImageSet.
+func cloneImageSet(s ImageSet) ImageSet {
+	if s == nil {
+		return nil
+	}
+
+	ss := make(ImageSet, len(s))
+	copy(ss, s)
+	return ss
+}
+
+// cloneBreweries returns a deep copy of a slice of Brewery structs.
+func cloneBreweries(bs []*Brewery) []*Brewery {
+	if bs == nil {
+		return nil
+	}
+
+	out := make([]*Brewery, len(bs))
+	for i, b := range bs {
+		out[i] = b.Clone()
+	}
+
+	return out
+}
+
+// cloneBadges returns a deep copy of a slice of Badge structs, including the
+// levels of each Badge.
+func cloneBadges(bs []*Badge) []*Badge {
+	if bs == nil {
+		return nil
+	}
+
+	out := make([]*Badge, len(bs))
+	for i, b := range bs {
+		if b == nil {
+			continue
+		}
+
+		bb := *b
+		bb.Levels = cloneBadges(b.Levels)
+		out[i] = &bb
+	}
+
+	return out
+}
+
+// cloneMedia returns a deep copy of a slice of Media structs.
+func cloneMedia(ms []*Media) []*Media {
+	if ms == nil {
+		return nil
+	}
+
+	out := make([]*Media, len(ms))
+	for i, m := range ms {
+		if m == nil {
+			continue
+		}
+
+		mm := *m
+		mm.Photo = cloneImageSet(m.Photo)
+		mm.User = m.User.Clone()
+		mm.Beer = m.Beer.Clone()
+		mm.Brewery = m.Brewery.Clone()
+		mm.Venue = m.Venue.Clone()
+		out[i] = &mm
+	}
+
+	return out
+}
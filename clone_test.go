@@ -0,0 +1,104 @@
+package untappd
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestCheckinClone verifies that Checkin.Clone creates a deep copy which can
+// be modified without affecting the original.
+func TestCheckinClone(t *testing.T) {
+	u, err := url.Parse("https://untappd.com/user/mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &User{UID: 1, UserName: "mdlayher", URL: *u, RecentBeers: []*Beer{{ID: 2}}}
+	brewery := &Brewery{ID: 3, Name: "Bell's", Owners: []*Brewery{{ID: 4}}, Beers: []BeerSummary{{Beer: &Beer{ID: 5}}}}
+	in := &Checkin{
+		ID:      6,
+		Created: time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC),
+		User:    user,
+		Beer: &Beer{
+			ID:      7,
+			Labels:  ImageSet{{Width: 100, URL: *u}},
+			Brewery: brewery,
+			Friends: []FriendRating{{User: user, Rating: 4}},
+		},
+		Brewery: brewery,
+		Venue: &Venue{
+			ID:           8,
+			Categories:   []VenueCategory{{Name: "Bar"}},
+			PopularBeers: []VenueBeer{{Beer: &Beer{ID: 9}}},
+			Media:        []*Media{{PhotoID: 10, User: user}},
+		},
+		Badges:   []*Badge{{ID: 11, Levels: []*Badge{{ID: 12}}}},
+		Toasts:   []*Toast{{ID: 13, User: user}},
+		Comments: []*Comment{{ID: 14, User: user}},
+		Media:    []*CheckinMedia{{PhotoID: 15, Photo: ImageSet{{URL: *u}}}},
+	}
+
+	out := in.Clone()
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("unexpected clone:\n- want: %+v\n-  got: %+v", in, out)
+	}
+
+	out.User.UserName = "foo"
+	out.Beer.Labels[0].Width = 1
+	out.Beer.Brewery.Owners[0].ID = 1
+	out.Beer.Brewery.Beers[0].Beer.ID = 1
+	out.Beer.Friends[0].User.RecentBeers[0].ID = 1
+	out.Venue.Categories[0].Name = "foo"
+	out.Venue.PopularBeers[0].Beer.ID = 1
+	out.Venue.Media[0].User.UID = 2
+	out.Badges[0].Levels[0].ID = 1
+	out.Toasts[0].User.UID = 2
+	out.Comments[0].User.UID = 2
+	out.Media[0].Photo[0].Width = 1
+
+	switch {
+	case user.UserName != "mdlayher", user.UID != 1, user.RecentBeers[0].ID != 2:
+		t.Fatalf("original user was modified: %+v", user)
+	case in.Beer.Labels[0].Width != 100, in.Media[0].Photo[0].Width != 0:
+		t.Fatal("original images were modified")
+	case brewery.Owners[0].ID != 4, brewery.Beers[0].Beer.ID != 5:
+		t.Fatalf("original brewery was modified: %+v", brewery)
+	case in.Venue.Categories[0].Name != "Bar", in.Venue.PopularBeers[0].Beer.ID != 9:
+		t.Fatalf("original venue was modified: %+v", in.Venue)
+	case in.Badges[0].Levels[0].ID != 12:
+		t.Fatal("original badges were modified")
+	}
+
+	if (*Checkin)(nil).Clone() != nil {
+		t.Fatal("expected nil clone of nil Checkin")
+	}
+}
+
+// TestCheckinCloneDeferred verifies that the deferred blocks of a lazily
+// decoded Checkin are decoded independently by its clone.
+func TestCheckinCloneDeferred(t *testing.T) {
+	var raw rawLazyCheckin
+	if err := json.Unmarshal([]byte(`{"checkin_id":1,"toasts":{"total_count":1,"count":1,"items":[{"uid":1}]}}`), &raw); err != nil {
+		t.Fatal(err)
+	}
+	in := raw.export()
+	out := in.Clone()
+
+	if _, err := out.LoadToasts(); err != nil {
+		t.Fatal(err)
+	}
+	if in.Toasts != nil {
+		t.Fatal("original toasts were decoded by clone")
+	}
+
+	toasts, err := in.LoadToasts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toasts) != 1 || len(out.Toasts) != 1 {
+		t.Fatalf("unexpected toasts: %d, %d", len(toasts), len(out.Toasts))
+	}
+}
@@ -0,0 +1,175 @@
+// Command untappd-exporter is a Prometheus exporter which periodically
+// retrieves the statistics of an Untappd user, and exposes them as metrics.
+//
+// The exporter authenticates as one user, using an access token from the
+// -access_token flag or the UNTAPPD_TOKEN environment variable, or using a
+// configuration file from the -config flag or the UNTAPPD_CONFIG environment
+// variable.  Metrics are served over HTTP at /metrics.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+const (
+	// appName is the name of this binary.
+	appName = "untappd-exporter"
+)
+
+func main() {
+	var (
+		addr     = flag.String("addr", ":9737", "address on which to serve Prometheus metrics")
+		interval = flag.Duration("interval", 15*time.Minute, "interval at which user statistics are refreshed")
+		token    = flag.String("access_token", os.Getenv("UNTAPPD_TOKEN"), "authenticated access token for Untappd APIv4")
+		config   = flag.String("config", os.Getenv("UNTAPPD_CONFIG"), "path to an Untappd client configuration file")
+	)
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix(appName + "> ")
+
+	c, err := untappdClient(*config, *token)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	e := &exporter{client: c}
+	go e.poll(ctx, *interval)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	log.Printf("serving metrics at http://%s/metrics", *addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+// untappdClient creates an authenticated *untappd.Client using either a
+// configuration file or an access token.
+func untappdClient(config string, token string) (*untappd.Client, error) {
+	// A configuration file is shared with other programs, and takes
+	// precedence over the access token
+	if config != "" {
+		cfg, err := untappd.LoadConfig(config)
+		if err != nil {
+			return nil, err
+		}
+
+		return cfg.NewClient(nil)
+	}
+
+	return untappd.NewAuthenticatedClient(token, nil)
+}
+
+// An exporter retrieves the statistics of the authenticated user, and
+// serves them as Prometheus metrics.
+type exporter struct {
+	client *untappd.Client
+
+	mu      sync.Mutex
+	user    *untappd.User
+	updated time.Time
+	errors  int
+}
+
+// poll refreshes the user's statistics immediately, and then once per
+// interval, until the context is canceled.
+func (e *exporter) poll(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		e.refresh()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// refresh retrieves the statistics of the authenticated user.
+func (e *exporter) refresh() {
+	// An empty username requests information about the authenticated user
+	user, _, err := e.client.User.Info("", true)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.errors++
+		log.Printf("failed to refresh user statistics: %v", err)
+		return
+	}
+
+	e.user = user
+	e.updated = time.Now()
+}
+
+// ServeHTTP implements http.Handler, writing metrics in the Prometheus text
+// exposition format.
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	if u := e.user; u != nil {
+		labels := fmt.Sprintf(`{user="%s"}`, labelEscaper.Replace(u.UserName))
+
+		gauge(w, "untappd_user_checkins", "Total number of checkins by the user.", labels, float64(u.Stats.TotalCheckins))
+		gauge(w, "untappd_user_unique_beers", "Number of distinct beers checked in by the user.", labels, float64(u.Stats.TotalBeers))
+		gauge(w, "untappd_user_badges", "Number of badges earned by the user.", labels, float64(u.Stats.TotalBadges))
+		gauge(w, "untappd_user_friends", "Number of friends of the user.", labels, float64(u.Stats.TotalFriends))
+		gauge(w, "untappd_user_photos", "Number of photos uploaded by the user.", labels, float64(u.Stats.TotalPhotos))
+		gauge(w, "untappd_exporter_last_refresh_timestamp_seconds", "Time of the last successful refresh of user statistics.", "", float64(e.updated.Unix()))
+	}
+
+	if rl := e.client.RateLimit(); !rl.Updated.IsZero() {
+		gauge(w, "untappd_ratelimit_limit", "Number of Untappd APIv4 requests permitted per hour.", "", float64(rl.Limit))
+		gauge(w, "untappd_ratelimit_remaining", "Number of Untappd APIv4 requests remaining in the current hour.", "", float64(rl.Remaining))
+	}
+
+	fmt.Fprintln(w, "# HELP untappd_exporter_refresh_errors_total Number of failed refreshes of user statistics.")
+	fmt.Fprintln(w, "# TYPE untappd_exporter_refresh_errors_total counter")
+	fmt.Fprintf(w, "untappd_exporter_refresh_errors_total %d\n", e.errors)
+}
+
+// labelEscaper escapes a Prometheus label value: only backslashes, double
+// quotes, and line feeds are escaped in the text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// gauge writes a single gauge metric in the Prometheus text exposition
+// format.
+func gauge(w io.Writer, name string, help string, labels string, v float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(w, "%s%s %g\n", name, labels, v)
+}
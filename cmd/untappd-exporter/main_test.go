@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+// TestExporterServeHTTP verifies that an exporter writes user statistics in
+// the Prometheus text exposition format, escaping label values.
+func TestExporterServeHTTP(t *testing.T) {
+	c, err := untappd.NewAuthenticatedClient("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &exporter{
+		client: c,
+		user: &untappd.User{
+			UserName: "a\"b\\c\nd\u00e9",
+			Stats: untappd.UserStats{
+				TotalCheckins: 120,
+				TotalBeers:    80,
+				TotalBadges:   10,
+			},
+		},
+		updated: time.Unix(1463788800, 0),
+		errors:  2,
+	}
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected HTTP status: %d != %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("unexpected Content-Type: %q", ct)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"# HELP untappd_user_checkins Total number of checkins by the user.\n",
+		"# TYPE untappd_user_checkins gauge\n",
+		`untappd_user_checkins{user="a\"b\\c\ndé"} 120` + "\n",
+		`untappd_user_unique_beers{user="a\"b\\c\ndé"} 80` + "\n",
+		`untappd_user_badges{user="a\"b\\c\ndé"} 10` + "\n",
+		"untappd_exporter_last_refresh_timestamp_seconds 1.4637888e+09\n",
+		"# TYPE untappd_exporter_refresh_errors_total counter\n",
+		"untappd_exporter_refresh_errors_total 2\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+
+	// No request has reported rate limit information yet
+	if strings.Contains(body, "untappd_ratelimit") {
+		t.Fatalf("unexpected rate limit metrics:\n%s", body)
+	}
+}
+
+// TestExporterServeHTTPNoUser verifies that an exporter only reports refresh
+// errors before user statistics are retrieved.
+func TestExporterServeHTTPNoUser(t *testing.T) {
+	c, err := untappd.NewAuthenticatedClient("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	(&exporter{client: c, errors: 1}).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	if strings.Contains(body, "untappd_user_") {
+		t.Fatalf("unexpected user metrics:\n%s", body)
+	}
+	if !strings.Contains(body, "untappd_exporter_refresh_errors_total 1\n") {
+		t.Fatalf("metrics do not contain refresh errors:\n%s", body)
+	}
+}
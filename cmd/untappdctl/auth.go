@@ -15,7 +15,7 @@ import (
 
 // authCommand allows a user to easily authenticate to the Untappd APIv4, and
 // perform actions which require authentication, such as checking in beers.
-func authCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func authCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "auth",
 		Aliases: []string{"a"},
@@ -47,7 +47,7 @@ func authCheckinCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Use system's timezone and offset for request,
@@ -79,7 +79,7 @@ func authCheckinCommand() *cli.Command {
 
 // authCheckinsCommand allows access to the untappd.Client.Beer.Checkins method, which
 // can query for information about recent checkins for a beer, by ID.
-func authCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func authCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:  "checkins",
 		Usage: "[auth] query for recent checkins from friends",
@@ -94,8 +94,8 @@ func authCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 			// "untappdctl beer checkins mdlayher"
 			c := untappdClient(ctx)
 			checkins, res, err := c.Auth.CheckinsMinMaxIDLimit(
-				ctx.Int("min_id"),
-				ctx.Int("max_id"),
+				ctx.Int64("min_id"),
+				ctx.Int64("max_id"),
 				ctx.Int("limit"),
 			)
 			printRateLimit(res)
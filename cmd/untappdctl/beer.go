@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"strconv"
 
@@ -10,7 +11,7 @@ import (
 
 // beerCommand allows access to untappd.Client.Beer methods, such as beer
 // information by ID, and query by search term.
-func beerCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func beerCommand(offsetFlag, limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "beer",
 		Aliases: []string{"be"},
@@ -18,14 +19,14 @@ func beerCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFlag, m
 		Subcommands: []*cli.Command{
 			beerCheckinsCommand(limitFlag, minIDFlag, maxIDFlag),
 			beerInfoCommand(),
-			beerSearchCommand(offsetFlag, limitFlag, sortFlag),
+			beerSearchCommand(offsetFlag, limitFlag),
 		},
 	}
 }
 
 // beerCheckinsCommand allows access to the untappd.Client.Beer.Checkins method, which
 // can query for information about recent checkins for a beer, by ID.
-func beerCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func beerCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
@@ -38,10 +39,10 @@ func beerCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := ctx.Int64("min_id"), ctx.Int64("max_id"), ctx.Int("limit")
 
 			// Query for beer's checkins by beername, e.g.
 			// "untappdctl beer checkins mdlayher"
@@ -74,7 +75,7 @@ func beerInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "beer ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "beer ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for beer by ID, e.g. "untappdctl beer info 1"
@@ -94,7 +95,7 @@ func beerInfoCommand() *cli.Command {
 
 // beerSearchCommand allows access to the untappd.Client.Beer.Search method, which
 // can search for information about beers, by search term.
-func beerSearchCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFlag) *cli.Command {
+func beerSearchCommand(offsetFlag, limitFlag *cli.IntFlag) *cli.Command {
 	return &cli.Command{
 		Name:    "search",
 		Aliases: []string{"s"},
@@ -102,6 +103,11 @@ func beerSearchCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringF
 		Flags: []cli.Flag{
 			offsetFlag,
 			limitFlag,
+			&cli.StringFlag{
+				Name:  "sort",
+				Value: string(untappd.SortCheckin),
+				Usage: fmt.Sprintf("sort type for beer search results (options: %s)", untappd.SearchSorts()),
+			},
 		},
 
 		Action: func(ctx *cli.Context) error {
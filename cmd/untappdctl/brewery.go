@@ -10,7 +10,7 @@ import (
 
 // breweryCommand allows access to untappd.Client.Brewery methods, such as brewery
 // information by ID, and query by search term.
-func breweryCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func breweryCommand(offsetFlag, limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "brewery",
 		Aliases: []string{"br"},
@@ -25,7 +25,7 @@ func breweryCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *c
 
 // breweryCheckinsCommand allows access to the untappd.Client.Brewery.Checkins method, which
 // can query for information about recent checkins for beers made by a brewery, by ID.
-func breweryCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func breweryCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
@@ -38,10 +38,10 @@ func breweryCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.C
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "brewery ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "brewery ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := ctx.Int64("min_id"), ctx.Int64("max_id"), ctx.Int("limit")
 
 			// Query for brewery's checkins by brewery ID, e.g.
 			// "untappdctl brewery checkins 1"
@@ -74,7 +74,7 @@ func breweryInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "brewery ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "brewery ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for brewery by ID, e.g. "untappdctl brewery info 1"
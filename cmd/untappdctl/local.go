@@ -12,7 +12,7 @@ import (
 
 // localCommand allows access to untappd.Client.Local methods, such as local
 // checkins by latitude and longitude.
-func localCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func localCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "local",
 		Aliases: []string{"l"},
@@ -26,7 +26,7 @@ func localCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
 // localCheckinsCommand allows access to the untappd.Client.Local.Checkins method, which
 // can query for information about recent checkins for a local area, by latitude, longitude,
 // and several other parameters.
-func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func localCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
@@ -74,8 +74,8 @@ func localCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 			checkins, res, err := c.Local.CheckinsMinMaxIDLimitRadius(untappd.LocalCheckinsRequest{
 				Latitude:  lat,
 				Longitude: lng,
-				MinID:     ctx.Int("min_id"),
-				MaxID:     ctx.Int("max_id"),
+				MinID:     ctx.Int64("min_id"),
+				MaxID:     ctx.Int64("max_id"),
 				Limit:     ctx.Int("limit"),
 				Radius:    ctx.Int("radius"),
 				Units:     unit,
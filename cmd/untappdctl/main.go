@@ -48,6 +48,11 @@ func main() {
 			Usage:   "authenticated access token for Untappd APIv4",
 			EnvVars: []string{"UNTAPPD_TOKEN"},
 		},
+		&cli.StringFlag{
+			Name:    "config",
+			Usage:   "path to an Untappd client configuration file",
+			EnvVars: []string{"UNTAPPD_CONFIG"},
+		},
 	}
 
 	// Frequently used flags for paging and sorting results, with their
@@ -69,21 +74,21 @@ func main() {
 	}
 
 	// Flags used to specify minimum and maximum checkin IDs
-	minIDFlag := &cli.IntFlag{
+	minIDFlag := &cli.Int64Flag{
 		Name:  "min_id",
 		Value: 0,
 		Usage: "minimum checkin ID for API query results",
 	}
-	maxIDFlag := &cli.IntFlag{
+	maxIDFlag := &cli.Int64Flag{
 		Name:  "max_id",
-		Value: math.MaxInt32,
+		Value: math.MaxInt64,
 		Usage: "maximum checkin ID for API query results",
 	}
 
 	// Add commands mirroring available untappd.Client services
 	app.Commands = []*cli.Command{
 		authCommand(limitFlag, minIDFlag, maxIDFlag),
-		beerCommand(offsetFlag, limitFlag, sortFlag, minIDFlag, maxIDFlag),
+		beerCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag),
 		breweryCommand(offsetFlag, limitFlag, minIDFlag, maxIDFlag),
 		localCommand(limitFlag, minIDFlag, maxIDFlag),
 		userCommand(offsetFlag, limitFlag, sortFlag, minIDFlag, maxIDFlag),
@@ -98,12 +103,29 @@ func main() {
 	app.Run(os.Args)
 }
 
-// untappdClient creates an initialized *untappd.Client using either the
-// access token, or client ID and secret from global CLI context.
+// untappdClient creates an initialized *untappd.Client using either a
+// configuration file, or the access token, or client ID and secret from
+// global CLI context.
 func untappdClient(ctx *cli.Context) *untappd.Client {
 	var c *untappd.Client
 	var err error
 
+	// A configuration file is shared with other programs, and takes
+	// precedence over individual flags
+	if path := ctx.String("config"); path != "" {
+		cfg, err := untappd.LoadConfig(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c, err = cfg.NewClient(nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return c
+	}
+
 	// Always prefer authenticated access token, if available
 	token := ctx.String("access_token")
 	if token != "" {
@@ -9,7 +9,7 @@ import (
 
 // userCommand allows access to untappd.Client.User methods, such as user
 // information, checked in beers, friends, badges, and wish list.
-func userCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func userCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "user",
 		Aliases: []string{"u"},
@@ -99,7 +99,7 @@ func userBeersCommand(offsetFlag, limitFlag *cli.IntFlag, sortFlag *cli.StringFl
 
 // userCheckinsCommand allows access to the untappd.Client.User.Checkins method, which
 // can query for information about a user's checked in beers, by username.
-func userCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func userCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
@@ -111,7 +111,7 @@ func userCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Comm
 		},
 
 		Action: func(ctx *cli.Context) error {
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := ctx.Int64("min_id"), ctx.Int64("max_id"), ctx.Int("limit")
 
 			// Query for user's checkins by username, e.g.
 			// "untappdctl user checkins mdlayher"
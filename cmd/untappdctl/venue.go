@@ -10,7 +10,7 @@ import (
 
 // venueCommand allows access to untappd.Client.Venue methods, such as venue
 // information by ID.
-func venueCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func venueCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "venue",
 		Aliases: []string{"v"},
@@ -24,7 +24,7 @@ func venueCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
 
 // venueCheckinsCommand allows access to the untappd.Client.Venue.Checkins method, which
 // can query for information about recent checkins at a specified venue, by ID.
-func venueCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Command {
+func venueCheckinsCommand(limitFlag *cli.IntFlag, minIDFlag, maxIDFlag *cli.Int64Flag) *cli.Command {
 	return &cli.Command{
 		Name:    "checkins",
 		Aliases: []string{"c"},
@@ -37,10 +37,10 @@ func venueCheckinsCommand(limitFlag, minIDFlag, maxIDFlag *cli.IntFlag) *cli.Com
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "venue ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "venue ID"), 10, 64)
 			checkAtoiError(err)
 
-			minID, maxID, limit := ctx.Int("min_id"), ctx.Int("max_id"), ctx.Int("limit")
+			minID, maxID, limit := ctx.Int64("min_id"), ctx.Int64("max_id"), ctx.Int("limit")
 
 			// Query for venue's checkins by venue ID, e.g.
 			// "untappdctl venue checkins 1"
@@ -73,7 +73,7 @@ func venueInfoCommand() *cli.Command {
 
 		Action: func(ctx *cli.Context) error {
 			// Check for valid integer ID
-			id, err := strconv.Atoi(mustStringArg(ctx, "venue ID"))
+			id, err := strconv.ParseInt(mustStringArg(ctx, "venue ID"), 10, 64)
 			checkAtoiError(err)
 
 			// Query for venue by ID, e.g. "untappdctl venue info 1"
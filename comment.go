@@ -9,8 +9,8 @@ import (
 // the comment.
 type Comment struct {
 	// Metadata from Untappd.
-	ID        int
-	CheckinID int
+	ID        int64
+	CheckinID int64
 
 	// The actual comment about a Checkin.
 	Comment string
@@ -18,28 +18,43 @@ type Comment struct {
 	// Time when this comment was submitted to Untappd.
 	Created time.Time
 
-	// The user who submitted the Comment.
+	// If applicable, was this comment submitted by the authenticated user,
+	// and can the authenticated user edit it?
+	Owner  bool
+	Editor bool
+
+	// The user who submitted the Comment.  May be nil if no user information
+	// was returned.
 	User *User
 }
 
-// rawComment is the raw JSON representation of an Untappd toast.  Its data is
+// rawComment is the raw JSON representation of an Untappd comment.  Its data is
 // unmarshaled from JSON and then exported to a Comment struct.
 type rawComment struct {
-	ID        int          `json:"comment_id"`
-	CheckinID int          `json:"checkin_id"`
-	Comment   string       `json:"comment"`
-	Created   responseTime `json:"created_at"`
-	User      *rawUser     `json:"user"`
+	ID        int64    `json:"comment_id"`
+	CheckinID int64    `json:"checkin_id"`
+	Comment   string   `json:"comment"`
+	Created   Time     `json:"created_at"`
+	Owner     bool     `json:"comment_owner"`
+	Editor    bool     `json:"comment_editor"`
+	User      *rawUser `json:"user"`
 }
 
 // export creates an exported Comment from a rawComment struct, allowing for more
 // useful structures to be created for client consumption.
 func (r *rawComment) export() *Comment {
-	return &Comment{
+	c := &Comment{
 		ID:        r.ID,
 		CheckinID: r.CheckinID,
 		Comment:   r.Comment,
 		Created:   time.Time(r.Created),
-		User:      r.User.export(),
+		Owner:     r.Owner,
+		Editor:    r.Editor,
+	}
+
+	if r.User != nil {
+		c.User = r.User.export()
 	}
+
+	return c
 }
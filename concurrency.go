@@ -0,0 +1,85 @@
+package untappd
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// maxBatchConcurrency is the maximum number of requests a batch method,
+	// such as BeerService.InfoBatch, performs in parallel.
+	maxBatchConcurrency = 4
+)
+
+// concurrency returns the number of requests which may be in flight at once,
+// up to max, given the Client's remaining rate limit.  Parallelism is scaled
+// down in proportion to the fraction of the hourly limit which remains, and
+// never exceeds the number of remaining requests, so that zero is returned
+// once the limit is exhausted.  If no rate limit information is available, or
+// the reported limit has since been reset, max is returned.
+func (c *Client) concurrency(max int) int {
+	rl := c.RateLimit()
+	if rl.Updated.IsZero() || !c.clock().Now().Before(rl.Reset()) {
+		return max
+	}
+
+	limit := rl.Limit
+	if limit <= 0 {
+		limit = DefaultHourlyBudget
+	}
+	if rl.Remaining <= 0 {
+		return 0
+	}
+
+	// Round up, so a single request may proceed while any remain
+	n := (max*rl.Remaining + limit - 1) / limit
+	if n > max {
+		n = max
+	}
+	if n > rl.Remaining {
+		n = rl.Remaining
+	}
+
+	return n
+}
+
+// runAdaptive invokes fn for each index in [0, n), with up to max invocations
+// running concurrently.  Before each invocation starts, parallelism is
+// adjusted according to the Client's remaining rate limit, and if the limit is
+// exhausted, runAdaptive pauses until it resets.
+//
+// Once the context is canceled, runAdaptive stops pausing, and each remaining
+// invocation starts immediately, so fn must check the context itself.
+func (c *Client) runAdaptive(ctx context.Context, n int, max int, fn func(i int)) {
+	var (
+		wg       sync.WaitGroup
+		inflight int
+
+		// Buffered so that finished invocations never block
+		done = make(chan struct{}, n)
+	)
+
+	for i := 0; i < n; i++ {
+		for ctx.Err() == nil && inflight >= c.concurrency(max) {
+			if inflight > 0 {
+				<-done
+				inflight--
+				continue
+			}
+
+			// Nothing is in flight, so the limit is exhausted
+			_ = c.wait(ctx, WaitEvent{Reason: WaitRateLimit}, c.RateLimit().Reset())
+		}
+
+		inflight++
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			fn(i)
+			done <- struct{}{}
+		}(i)
+	}
+
+	wg.Wait()
+}
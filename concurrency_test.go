@@ -0,0 +1,107 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestClient_concurrency verifies that parallelism is scaled down as the
+// remaining rate limit shrinks, and stops once it is exhausted.
+func TestClient_concurrency(t *testing.T) {
+	c, done := testClient(t, nil)
+	defer done()
+
+	var tests = []struct {
+		desc string
+		rl   RateLimit
+		n    int
+	}{
+		{
+			desc: "no rate limit information",
+			n:    4,
+		},
+		{
+			desc: "full limit",
+			rl:   RateLimit{Limit: 100, Remaining: 100, Updated: time.Now()},
+			n:    4,
+		},
+		{
+			desc: "half limit",
+			rl:   RateLimit{Limit: 100, Remaining: 50, Updated: time.Now()},
+			n:    2,
+		},
+		{
+			desc: "nearly exhausted",
+			rl:   RateLimit{Limit: 100, Remaining: 1, Updated: time.Now()},
+			n:    1,
+		},
+		{
+			desc: "exhausted",
+			rl:   RateLimit{Limit: 100, Remaining: 0, Updated: time.Now()},
+			n:    0,
+		},
+		{
+			desc: "exhausted before reset",
+			rl:   RateLimit{Limit: 100, Remaining: 0, Updated: time.Now().Add(-2 * time.Hour)},
+			n:    4,
+		},
+	}
+
+	for _, tt := range tests {
+		c.limits.rl = tt.rl
+		if n := c.concurrency(4); n != tt.n {
+			t.Fatalf("[%s] unexpected concurrency: %d != %d", tt.desc, n, tt.n)
+		}
+	}
+}
+
+// TestClientBeerInfoBatchAdaptive verifies that Client.Beer.InfoBatch never
+// has more requests in flight than the remaining rate limit permits.
+func TestClientBeerInfoBatchAdaptive(t *testing.T) {
+	var (
+		mu                 sync.Mutex
+		inflight, peak     int
+		remaining, allowed = 8, 0
+	)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inflight++
+		if inflight > peak {
+			peak = inflight
+		}
+		remaining--
+		rem := remaining
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inflight--
+		mu.Unlock()
+
+		w.Header().Set(headerRateLimitLimit, "100")
+		w.Header().Set(headerRateLimitRemaining, strconv.Itoa(rem))
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	// A nearly exhausted limit permits one request at a time
+	c.limits.rl = RateLimit{Limit: 100, Remaining: 8, Updated: time.Now()}
+	allowed = c.concurrency(maxBatchConcurrency)
+
+	beers, err := c.Beer.InfoBatch(context.Background(), []int64{1, 2, 3, 4, 5}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(beers); l != 5 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 5)
+	}
+	if peak > allowed {
+		t.Fatalf("unexpected peak concurrency: %d > %d", peak, allowed)
+	}
+}
@@ -0,0 +1,335 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInlineSecret is returned when a Config contains a client secret or
+	// access token inline, rather than a reference to one.
+	ErrInlineSecret = errors.New("secrets must be referenced using env: or file:, not stored inline")
+)
+
+// Prefixes of Config credential references.
+const (
+	configRefEnv  = "env:"
+	configRefFile = "file:"
+)
+
+// A Config is a serializable Client configuration, which may be shared by
+// several programs using LoadConfig and Config.Save.  The zero value creates
+// a Client with default settings.
+//
+// Credentials are never stored in a Config.  Instead, each credential is a
+// reference of the form "env:NAME", which reads the environment variable
+// NAME, or "file:PATH", which reads the contents of the file at PATH, with
+// surrounding whitespace removed.  The client ID is not secret, and may
+// also be stored inline.
+type Config struct {
+	// References to the credentials for the Client.  If AccessToken is set,
+	// an authenticated Client is created.
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+
+	// Base URL of the Untappd APIv4, and the API version, as with
+	// WithBaseURL and WithAPIVersion.
+	BaseURL    string `json:"base_url,omitempty"`
+	APIVersion string `json:"api_version,omitempty"`
+
+	// User agent reported by the Client.
+	UserAgent string `json:"user_agent,omitempty"`
+
+	// Response decoding and size settings, as with WithLazyDecoding,
+	// WithLenientDecoding, and WithMaxResponseSize.
+	LazyDecoding    bool  `json:"lazy_decoding,omitempty"`
+	LenientDecoding bool  `json:"lenient_decoding,omitempty"`
+	MaxResponseSize int64 `json:"max_response_size,omitempty"`
+
+	// Path to a FileRateLimitStore, as with WithRateLimitStore.
+	RateLimitFile string `json:"rate_limit_file,omitempty"`
+
+	// Default ServicePolicy, and policies for individual services, as with
+	// WithPolicy and WithServicePolicy.
+	Policy   *PolicyConfig            `json:"policy,omitempty"`
+	Services map[Service]PolicyConfig `json:"services,omitempty"`
+}
+
+// A PolicyConfig is the serializable form of a ServicePolicy.  Durations are
+// stored as strings accepted by time.ParseDuration, such as "30s".
+type PolicyConfig struct {
+	Timeout   string `json:"timeout,omitempty"`
+	Retries   int    `json:"retries,omitempty"`
+	RetryWait string `json:"retry_wait,omitempty"`
+	CacheTTL  string `json:"cache_ttl,omitempty"`
+
+	CacheHeaders bool `json:"cache_headers,omitempty"`
+}
+
+// newPolicyConfig creates a PolicyConfig from a ServicePolicy.
+func newPolicyConfig(p ServicePolicy) PolicyConfig {
+	format := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+
+		return d.String()
+	}
+
+	return PolicyConfig{
+		Timeout:   format(p.Timeout),
+		Retries:   p.Retries,
+		RetryWait: format(p.RetryWait),
+		CacheTTL:  format(p.CacheTTL),
+
+		CacheHeaders: p.CacheHeaders,
+	}
+}
+
+// policy parses a ServicePolicy from a PolicyConfig.
+func (p PolicyConfig) policy() (ServicePolicy, error) {
+	parse := func(name string, s string) (time.Duration, error) {
+		if s == "" {
+			return 0, nil
+		}
+
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %v", name, err)
+		}
+
+		return d, nil
+	}
+
+	var (
+		sp  = ServicePolicy{Retries: p.Retries, CacheHeaders: p.CacheHeaders}
+		err error
+	)
+	if sp.Timeout, err = parse("timeout", p.Timeout); err != nil {
+		return ServicePolicy{}, err
+	}
+	if sp.RetryWait, err = parse("retry_wait", p.RetryWait); err != nil {
+		return ServicePolicy{}, err
+	}
+	if sp.CacheTTL, err = parse("cache_ttl", p.CacheTTL); err != nil {
+		return ServicePolicy{}, err
+	}
+
+	return sp, nil
+}
+
+// LoadConfig reads a Config from the JSON file at path.  Unknown fields, and
+// secrets stored inline, are rejected.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+
+	var c Config
+	if err := d.Decode(&c); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Save writes the Config to the JSON file at path.  The file is replaced
+// atomically.
+func (c *Config) Save(path string) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, append(b, '\n'))
+}
+
+// validate verifies that a Config contains no inline secrets.
+func (c *Config) validate() error {
+	for _, ref := range []string{c.ClientSecret, c.AccessToken} {
+		if ref != "" && !isConfigRef(ref) {
+			return ErrInlineSecret
+		}
+	}
+
+	return nil
+}
+
+// Options returns the ClientOptions described by the Config, excluding its
+// credentials and user agent.
+func (c *Config) Options() ([]ClientOption, error) {
+	var opts []ClientOption
+
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithBaseURL(u))
+	}
+	if c.APIVersion != "" {
+		opts = append(opts, WithAPIVersion(c.APIVersion))
+	}
+
+	if c.LazyDecoding {
+		opts = append(opts, WithLazyDecoding())
+	}
+	if c.LenientDecoding {
+		opts = append(opts, WithLenientDecoding())
+	}
+	if c.MaxResponseSize != 0 {
+		opts = append(opts, WithMaxResponseSize(c.MaxResponseSize))
+	}
+
+	if c.RateLimitFile != "" {
+		opts = append(opts, WithRateLimitStore(&FileRateLimitStore{Path: c.RateLimitFile}))
+	}
+
+	if c.Policy != nil {
+		p, err := c.Policy.policy()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithPolicy(p))
+	}
+	for s, pc := range c.Services {
+		p, err := pc.policy()
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %v", s, err)
+		}
+		opts = append(opts, WithServicePolicy(s, p))
+	}
+
+	return opts, nil
+}
+
+// NewClient creates a Client described by the Config, resolving its
+// credential references, and using the input http.Client.  Any additional
+// ClientOptions are applied after those of the Config.
+func (c *Config) NewClient(client *http.Client, opts ...ClientOption) (*Client, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	copts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(copts, opts...)
+
+	var uc *Client
+	if c.AccessToken != "" {
+		token, err := resolveConfigRef(c.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		uc, err = NewAuthenticatedClient(token, client, opts...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		id, err := resolveConfigRef(c.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := resolveConfigRef(c.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		uc, err = NewClient(id, secret, client, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.UserAgent != "" {
+		uc.UserAgent = c.UserAgent
+	}
+
+	return uc, nil
+}
+
+// Config returns a Config describing the settings of the Client, so that they
+// may be saved and shared.  Credentials cannot be recovered from a Client, so
+// the credential references of the returned Config are empty.  Settings
+// which cannot be serialized, such as custom transports, clocks, and
+// interceptors, are omitted.
+func (c *Client) Config() *Config {
+	cfg := &Config{
+		BaseURL:         c.url.String(),
+		LazyDecoding:    c.cfg.lazy,
+		LenientDecoding: c.cfg.lenient,
+		MaxResponseSize: c.cfg.maxSize,
+	}
+	if c.UserAgent != untappdUserAgent {
+		cfg.UserAgent = c.UserAgent
+	}
+
+	if s, ok := c.cfg.rateLimitStore.(*FileRateLimitStore); ok {
+		cfg.RateLimitFile = s.Path
+	}
+
+	if c.cfg.defaultPolicy != (ServicePolicy{}) {
+		p := newPolicyConfig(c.cfg.defaultPolicy)
+		cfg.Policy = &p
+	}
+	if len(c.cfg.policies) > 0 {
+		cfg.Services = make(map[Service]PolicyConfig, len(c.cfg.policies))
+		for s, p := range c.cfg.policies {
+			cfg.Services[s] = newPolicyConfig(p)
+		}
+	}
+
+	return cfg
+}
+
+// isConfigRef reports whether s is a Config credential reference.
+func isConfigRef(s string) bool {
+	return strings.HasPrefix(s, configRefEnv) || strings.HasPrefix(s, configRefFile)
+}
+
+// resolveConfigRef resolves a Config credential reference.  Values which are
+// not references are returned unchanged.
+func resolveConfigRef(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, configRefEnv):
+		name := strings.TrimPrefix(ref, configRefEnv)
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+
+		return v, nil
+	case strings.HasPrefix(ref, configRefFile):
+		b, err := ioutil.ReadFile(strings.TrimPrefix(ref, configRefFile))
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(b)), nil
+	default:
+		return ref, nil
+	}
+}
@@ -0,0 +1,84 @@
+package untappd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestConfigRoundTrip verifies that a Client's settings can be exported to a
+// Config file, and loaded to create an equivalent Client.
+func TestConfigRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewClient("foo", "bar", nil,
+		WithLenientDecoding(),
+		WithMaxResponseSize(1024),
+		WithRateLimitStore(&FileRateLimitStore{Path: filepath.Join(dir, "ratelimit.json")}),
+		WithPolicy(ServicePolicy{Retries: 2}),
+		WithServicePolicy(ServiceBeer, ServicePolicy{CacheTTL: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.UserAgent = "test"
+
+	cfg := c.Config()
+	cfg.ClientID = "foo"
+	cfg.ClientSecret = "env:UNTAPPD_TEST_SECRET"
+
+	path := filepath.Join(dir, "config.json")
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, loaded) {
+		t.Fatalf("unexpected loaded Config:\n- want: %+v\n-  got: %+v", cfg, loaded)
+	}
+
+	if _, err := loaded.NewClient(nil); err == nil {
+		t.Fatal("expected an error for an unset environment variable")
+	}
+
+	os.Setenv("UNTAPPD_TEST_SECRET", "bar")
+	defer os.Unsetenv("UNTAPPD_TEST_SECRET")
+
+	c2, err := loaded.NewClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2.clientSecret != "bar" || c2.UserAgent != "test" {
+		t.Fatalf("unexpected Client credentials or user agent: %q, %q", c2.clientSecret, c2.UserAgent)
+	}
+	if got := c2.Config(); !reflect.DeepEqual(c.Config(), got) {
+		t.Fatalf("unexpected Client settings:\n- want: %+v\n-  got: %+v", c.Config(), got)
+	}
+}
+
+// TestConfigInlineSecret verifies that secrets cannot be stored inline in a
+// Config.
+func TestConfigInlineSecret(t *testing.T) {
+	for _, cfg := range []*Config{
+		{ClientID: "foo", ClientSecret: "bar"},
+		{AccessToken: "baz"},
+	} {
+		if _, err := cfg.NewClient(nil); !errors.Is(err, ErrInlineSecret) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cfg.Save(filepath.Join(os.TempDir(), "untappd-inline.json")); !errors.Is(err, ErrInlineSecret) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package untappd
+
+import "strings"
+
+// countryNames maps ISO 3166-1 alpha-2 country codes to the names of the
+// countries as they are typically reported by the Untappd APIv4.  The
+// constituent countries of the United Kingdom are reported separately by
+// Untappd, and are mapped to their ISO 3166-2 subdivision codes.
+var countryNames = map[string]string{
+	"AD": "Andorra",
+	"AE": "United Arab Emirates",
+	"AF": "Afghanistan",
+	"AG": "Antigua and Barbuda",
+	"AI": "Anguilla",
+	"AL": "Albania",
+	"AM": "Armenia",
+	"AO": "Angola",
+	"AQ": "Antarctica",
+	"AR": "Argentina",
+	"AS": "American Samoa",
+	"AT": "Austria",
+	"AU": "Australia",
+	"AW": "Aruba",
+	"AX": "Åland Islands",
+	"AZ": "Azerbaijan",
+	"BA": "Bosnia and Herzegovina",
+	"BB": "Barbados",
+	"BD": "Bangladesh",
+	"BE": "Belgium",
+	"BF": "Burkina Faso",
+	"BG": "Bulgaria",
+	"BH": "Bahrain",
+	"BI": "Burundi",
+	"BJ": "Benin",
+	"BL": "Saint Barthélemy",
+	"BM": "Bermuda",
+	"BN": "Brunei",
+	"BO": "Bolivia",
+	"BQ": "Caribbean Netherlands",
+	"BR": "Brazil",
+	"BS": "Bahamas",
+	"BT": "Bhutan",
+	"BV": "Bouvet Island",
+	"BW": "Botswana",
+	"BY": "Belarus",
+	"BZ": "Belize",
+	"CA": "Canada",
+	"CC": "Cocos (Keeling) Islands",
+	"CD": "Democratic Republic of the Congo",
+	"CF": "Central African Republic",
+	"CG": "Republic of the Congo",
+	"CH": "Switzerland",
+	"CI": "Côte d'Ivoire",
+	"CK": "Cook Islands",
+	"CL": "Chile",
+	"CM": "Cameroon",
+	"CN": "China",
+	"CO": "Colombia",
+	"CR": "Costa Rica",
+	"CU": "Cuba",
+	"CV": "Cape Verde",
+	"CW": "Curaçao",
+	"CX": "Christmas Island",
+	"CY": "Cyprus",
+	"CZ": "Czech Republic",
+	"DE": "Germany",
+	"DJ": "Djibouti",
+	"DK": "Denmark",
+	"DM": "Dominica",
+	"DO": "Dominican Republic",
+	"DZ": "Algeria",
+	"EC": "Ecuador",
+	"EE": "Estonia",
+	"EG": "Egypt",
+	"EH": "Western Sahara",
+	"ER": "Eritrea",
+	"ES": "Spain",
+	"ET": "Ethiopia",
+	"FI": "Finland",
+	"FJ": "Fiji",
+	"FK": "Falkland Islands",
+	"FM": "Micronesia",
+	"FO": "Faroe Islands",
+	"FR": "France",
+	"GA": "Gabon",
+	"GB": "United Kingdom",
+	"GD": "Grenada",
+	"GE": "Georgia",
+	"GF": "French Guiana",
+	"GG": "Guernsey",
+	"GH": "Ghana",
+	"GI": "Gibraltar",
+	"GL": "Greenland",
+	"GM": "Gambia",
+	"GN": "Guinea",
+	"GP": "Guadeloupe",
+	"GQ": "Equatorial Guinea",
+	"GR": "Greece",
+	"GS": "South Georgia and the South Sandwich Islands",
+	"GT": "Guatemala",
+	"GU": "Guam",
+	"GW": "Guinea-Bissau",
+	"GY": "Guyana",
+	"HK": "Hong Kong",
+	"HM": "Heard Island and McDonald Islands",
+	"HN": "Honduras",
+	"HR": "Croatia",
+	"HT": "Haiti",
+	"HU": "Hungary",
+	"ID": "Indonesia",
+	"IE": "Ireland",
+	"IL": "Israel",
+	"IM": "Isle of Man",
+	"IN": "India",
+	"IO": "British Indian Ocean Territory",
+	"IQ": "Iraq",
+	"IR": "Iran",
+	"IS": "Iceland",
+	"IT": "Italy",
+	"JE": "Jersey",
+	"JM": "Jamaica",
+	"JO": "Jordan",
+	"JP": "Japan",
+	"KE": "Kenya",
+	"KG": "Kyrgyzstan",
+	"KH": "Cambodia",
+	"KI": "Kiribati",
+	"KM": "Comoros",
+	"KN": "Saint Kitts and Nevis",
+	"KP": "North Korea",
+	"KR": "South Korea",
+	"KW": "Kuwait",
+	"KY": "Cayman Islands",
+	"KZ": "Kazakhstan",
+	"LA": "Laos",
+	"LB": "Lebanon",
+	"LC": "Saint Lucia",
+	"LI": "Liechtenstein",
+	"LK": "Sri Lanka",
+	"LR": "Liberia",
+	"LS": "Lesotho",
+	"LT": "Lithuania",
+	"LU": "Luxembourg",
+	"LV": "Latvia",
+	"LY": "Libya",
+	"MA": "Morocco",
+	"MC": "Monaco",
+	"MD": "Moldova",
+	"ME": "Montenegro",
+	"MF": "Saint Martin",
+	"MG": "Madagascar",
+	"MH": "Marshall Islands",
+	"MK": "North Macedonia",
+	"ML": "Mali",
+	"MM": "Myanmar",
+	"MN": "Mongolia",
+	"MO": "Macau",
+	"MP": "Northern Mariana Islands",
+	"MQ": "Martinique",
+	"MR": "Mauritania",
+	"MS": "Montserrat",
+	"MT": "Malta",
+	"MU": "Mauritius",
+	"MV": "Maldives",
+	"MW": "Malawi",
+	"MX": "Mexico",
+	"MY": "Malaysia",
+	"MZ": "Mozambique",
+	"NA": "Namibia",
+	"NC": "New Caledonia",
+	"NE": "Niger",
+	"NF": "Norfolk Island",
+	"NG": "Nigeria",
+	"NI": "Nicaragua",
+	"NL": "Netherlands",
+	"NO": "Norway",
+	"NP": "Nepal",
+	"NR": "Nauru",
+	"NU": "Niue",
+	"NZ": "New Zealand",
+	"OM": "Oman",
+	"PA": "Panama",
+	"PE": "Peru",
+	"PF": "French Polynesia",
+	"PG": "Papua New Guinea",
+	"PH": "Philippines",
+	"PK": "Pakistan",
+	"PL": "Poland",
+	"PM": "Saint Pierre and Miquelon",
+	"PN": "Pitcairn Islands",
+	"PR": "Puerto Rico",
+	"PS": "Palestine",
+	"PT": "Portugal",
+	"PW": "Palau",
+	"PY": "Paraguay",
+	"QA": "Qatar",
+	"RE": "Réunion",
+	"RO": "Romania",
+	"RS": "Serbia",
+	"RU": "Russia",
+	"RW": "Rwanda",
+	"SA": "Saudi Arabia",
+	"SB": "Solomon Islands",
+	"SC": "Seychelles",
+	"SD": "Sudan",
+	"SE": "Sweden",
+	"SG": "Singapore",
+	"SH": "Saint Helena",
+	"SI": "Slovenia",
+	"SJ": "Svalbard and Jan Mayen",
+	"SK": "Slovakia",
+	"SL": "Sierra Leone",
+	"SM": "San Marino",
+	"SN": "Senegal",
+	"SO": "Somalia",
+	"SR": "Suriname",
+	"SS": "South Sudan",
+	"ST": "São Tomé and Príncipe",
+	"SV": "El Salvador",
+	"SX": "Sint Maarten",
+	"SY": "Syria",
+	"SZ": "Eswatini",
+	"TC": "Turks and Caicos Islands",
+	"TD": "Chad",
+	"TF": "French Southern Territories",
+	"TG": "Togo",
+	"TH": "Thailand",
+	"TJ": "Tajikistan",
+	"TK": "Tokelau",
+	"TL": "Timor-Leste",
+	"TM": "Turkmenistan",
+	"TN": "Tunisia",
+	"TO": "Tonga",
+	"TR": "Turkey",
+	"TT": "Trinidad and Tobago",
+	"TV": "Tuvalu",
+	"TW": "Taiwan",
+	"TZ": "Tanzania",
+	"UA": "Ukraine",
+	"UG": "Uganda",
+	"UM": "United States Minor Outlying Islands",
+	"US": "United States",
+	"UY": "Uruguay",
+	"UZ": "Uzbekistan",
+	"VA": "Vatican City",
+	"VC": "Saint Vincent and the Grenadines",
+	"VE": "Venezuela",
+	"VG": "British Virgin Islands",
+	"VI": "U.S. Virgin Islands",
+	"VN": "Vietnam",
+	"VU": "Vanuatu",
+	"WF": "Wallis and Futuna",
+	"WS": "Samoa",
+	"YE": "Yemen",
+	"YT": "Mayotte",
+	"ZA": "South Africa",
+	"ZM": "Zambia",
+	"ZW": "Zimbabwe",
+
+	"GB-ENG": "England",
+	"GB-NIR": "Northern Ireland",
+	"GB-SCT": "Scotland",
+	"GB-WLS": "Wales",
+}
+
+// countryAliases maps alternative names and abbreviations of countries to
+// their codes in countryNames.
+var countryAliases = map[string]string{
+	"america":                  "US",
+	"brasil":                   "BR",
+	"britain":                  "GB",
+	"burma":                    "MM",
+	"czechia":                  "CZ",
+	"deutschland":              "DE",
+	"espana":                   "ES",
+	"españa":                   "ES",
+	"great britain":            "GB",
+	"holland":                  "NL",
+	"ivory coast":              "CI",
+	"korea":                    "KR",
+	"macao":                    "MO",
+	"macedonia":                "MK",
+	"republic of ireland":      "IE",
+	"republic of korea":        "KR",
+	"russian federation":       "RU",
+	"swaziland":                "SZ",
+	"the bahamas":              "BS",
+	"the netherlands":          "NL",
+	"turkiye":                  "TR",
+	"türkiye":                  "TR",
+	"uae":                      "AE",
+	"uk":                       "GB",
+	"united states of america": "US",
+	"us":                       "US",
+	"usa":                      "US",
+	"viet nam":                 "VN",
+}
+
+// countryCodes maps the normalized names, codes, and aliases of countries to
+// their codes in countryNames.
+var countryCodes = func() map[string]string {
+	codes := make(map[string]string, 2*len(countryNames)+len(countryAliases))
+	for code, name := range countryNames {
+		codes[normalizeCountry(code)] = code
+		codes[normalizeCountry(name)] = code
+	}
+	for alias, code := range countryAliases {
+		codes[normalizeCountry(alias)] = code
+	}
+
+	return codes
+}()
+
+// normalizeCountry normalizes a free-form country name for lookup, ignoring
+// case, periods, and repeated whitespace.
+func normalizeCountry(s string) string {
+	s = strings.ToLower(strings.Replace(s, ".", "", -1))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code, such as "US", of the
+// country with the input free-form name, such as "United States", "USA", or
+// "U.S.A.".  Names are compared case-insensitively, and ISO codes are also
+// accepted.  It returns false if the country is not recognized.
+//
+// Untappd reports the constituent countries of the United Kingdom
+// separately, so "England", "Scotland", "Wales", and "Northern Ireland" are
+// mapped to their ISO 3166-2 subdivision codes, such as "GB-ENG".
+func CountryCode(name string) (string, bool) {
+	code, ok := countryCodes[normalizeCountry(name)]
+	return code, ok
+}
+
+// CountryName returns the name of the country with the input ISO 3166 code,
+// as it is typically reported by Untappd.  Codes are compared
+// case-insensitively.  It returns false if the code is not recognized.
+func CountryName(code string) (string, bool) {
+	name, ok := countryNames[strings.ToUpper(strings.TrimSpace(code))]
+	return name, ok
+}
+
+// Location is a place, such as the location of a brewery, venue, or user,
+// with its country normalized so that places may be grouped by country.
+type Location struct {
+	City    string
+	State   string
+	Country string
+}
+
+// ParseLocation parses a free-form location, such as a user's location,
+// into a Location.  Components are separated by commas, and are interpreted
+// as a city, state, and country, in that order.  The last component is only
+// interpreted as a country if it is recognized by CountryCode, so that
+// locations such as "Kalamazoo, MI" are parsed as a city and state.
+func ParseLocation(s string) Location {
+	var parts []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	var l Location
+	if n := len(parts); n > 0 {
+		// A two-letter component following a city is more likely a state,
+		// such as "IN", than a country code
+		last := parts[n-1]
+		if _, ok := CountryCode(last); ok && (n != 2 || len(last) > 2) {
+			l.Country = last
+			parts = parts[:n-1]
+		}
+	}
+	if len(parts) > 0 {
+		l.City = parts[0]
+	}
+	if len(parts) > 1 {
+		l.State = strings.Join(parts[1:], ", ")
+	}
+
+	return l
+}
+
+// CountryCode returns the ISO 3166 code of a Location's country, as returned
+// by CountryCode, or the empty string if the country is not recognized.
+func (l Location) CountryCode() string {
+	code, _ := CountryCode(l.Country)
+	return code
+}
+
+// CountryName returns the normalized name of a Location's country, as
+// returned by CountryName.  If the country is not recognized, its name is
+// returned as reported.
+func (l Location) CountryName() string {
+	if name, ok := CountryName(l.CountryCode()); ok {
+		return name
+	}
+
+	return l.Country
+}
+
+// Place returns the Location of a Brewery.
+func (b *Brewery) Place() Location {
+	return Location{
+		City:    b.Location.City,
+		State:   b.Location.State,
+		Country: b.Country,
+	}
+}
+
+// Place returns the Location of a venue.
+func (l VenueLocation) Place() Location {
+	return Location{
+		City:    l.City,
+		State:   l.State,
+		Country: l.Country,
+	}
+}
+
+// Place returns the Location of a User, parsed from its free-form location
+// using ParseLocation.
+func (u *User) Place() Location {
+	return ParseLocation(u.Location)
+}
@@ -0,0 +1,111 @@
+package untappd
+
+import "testing"
+
+// TestCountryCode verifies that CountryCode and CountryName map between
+// free-form country names and ISO 3166 codes.
+func TestCountryCode(t *testing.T) {
+	var tests = []struct {
+		name string
+		code string
+		ok   bool
+	}{
+		{name: "United States", code: "US", ok: true},
+		{name: "USA", code: "US", ok: true},
+		{name: " u.s.a. ", code: "US", ok: true},
+		{name: "United  States of America", code: "US", ok: true},
+		{name: "us", code: "US", ok: true},
+		{name: "Belgium", code: "BE", ok: true},
+		{name: "The Netherlands", code: "NL", ok: true},
+		{name: "England", code: "GB-ENG", ok: true},
+		{name: "Scotland", code: "GB-SCT", ok: true},
+		{name: "Atlantis"},
+		{name: ""},
+	}
+
+	for _, tt := range tests {
+		code, ok := CountryCode(tt.name)
+		if code != tt.code || ok != tt.ok {
+			t.Fatalf("[%q] unexpected country code: %q, %v != %q, %v", tt.name, code, ok, tt.code, tt.ok)
+		}
+	}
+
+	if name, ok := CountryName("us"); !ok || name != "United States" {
+		t.Fatalf("unexpected country name: %q, %v", name, ok)
+	}
+	if name, ok := CountryName("GB-WLS"); !ok || name != "Wales" {
+		t.Fatalf("unexpected country name: %q, %v", name, ok)
+	}
+	if _, ok := CountryName("XX"); ok {
+		t.Fatal("expected unknown country code")
+	}
+}
+
+// TestParseLocation verifies that ParseLocation splits free-form locations
+// into their city, state, and country.
+func TestParseLocation(t *testing.T) {
+	var tests = []struct {
+		s    string
+		l    Location
+		code string
+	}{
+		{
+			s: "Kalamazoo, MI",
+			l: Location{City: "Kalamazoo", State: "MI"},
+		},
+		{
+			s: "Bloomington, IN",
+			l: Location{City: "Bloomington", State: "IN"},
+		},
+		{
+			s:    "Brooklyn, NY, USA",
+			l:    Location{City: "Brooklyn", State: "NY", Country: "USA"},
+			code: "US",
+		},
+		{
+			s:    "Brussels, Belgium",
+			l:    Location{City: "Brussels", Country: "Belgium"},
+			code: "BE",
+		},
+		{
+			s:    "England",
+			l:    Location{Country: "England"},
+			code: "GB-ENG",
+		},
+		{
+			s: " , ",
+		},
+	}
+
+	for _, tt := range tests {
+		l := ParseLocation(tt.s)
+		if l != tt.l {
+			t.Fatalf("[%q] unexpected Location: %+v != %+v", tt.s, l, tt.l)
+		}
+		if code := l.CountryCode(); code != tt.code {
+			t.Fatalf("[%q] unexpected country code: %q != %q", tt.s, code, tt.code)
+		}
+	}
+
+	if n := (&User{Location: "Ghent, belgium"}).Place().CountryName(); n != "Belgium" {
+		t.Fatalf("unexpected country name: %q", n)
+	}
+	if n := (&Brewery{Country: "Atlantis"}).Place().CountryName(); n != "Atlantis" {
+		t.Fatalf("unexpected country name: %q", n)
+	}
+}
+
+// TestCountDistinctCountriesAliases verifies that CountDistinctCountries
+// groups alternative names of the same country.
+func TestCountDistinctCountriesAliases(t *testing.T) {
+	var checkins []*Checkin
+	for _, country := range []string{"United States", "USA", "england", "England", "Scotland", "Atlantis"} {
+		checkins = append(checkins, &Checkin{
+			Brewery: &Brewery{Country: country},
+		})
+	}
+
+	if n := CountDistinctCountries(checkins); n != 4 {
+		t.Fatalf("unexpected number of countries: %d != %d", n, 4)
+	}
+}
@@ -0,0 +1,88 @@
+package untappd
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// A Cursor records the range of checkin IDs observed while paging through a
+// checkin feed, and produces the min_id and max_id parameters for the next
+// page in either direction.  The zero value is an empty Cursor, ready to use.
+//
+// The Untappd APIv4 treats min_id as exclusive and max_id as inclusive, so
+// the parameters returned by a Cursor never repeat or skip a checkin at a
+// page boundary.
+//
+// The values returned by Newer and Older may be passed directly to the
+// CheckinsMinMaxIDLimit methods of each service.
+type Cursor struct {
+	// Highest and lowest checkin IDs observed.  Both are zero if no
+	// checkins have been observed.
+	Highest int64
+	Lowest  int64
+}
+
+// jsonCursor is the JSON representation of a Cursor.
+type jsonCursor struct {
+	Highest int64 `json:"highest"`
+	Lowest  int64 `json:"lowest"`
+}
+
+// MarshalJSON implements json.Marshaler, so that a Cursor can be persisted
+// as a checkpoint and later restored using UnmarshalJSON.
+func (c Cursor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonCursor(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Cursor) UnmarshalJSON(data []byte) error {
+	var v jsonCursor
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*c = Cursor(v)
+	return nil
+}
+
+// Observe records the IDs of the input checkins.  Nil checkins are ignored.
+func (c *Cursor) Observe(checkins []*Checkin) {
+	for _, ch := range checkins {
+		if ch == nil {
+			continue
+		}
+
+		if c.Highest == 0 || ch.ID > c.Highest {
+			c.Highest = ch.ID
+		}
+		if c.Lowest == 0 || ch.ID < c.Lowest {
+			c.Lowest = ch.ID
+		}
+	}
+}
+
+// Empty reports whether no checkins have been observed by this Cursor.
+func (c *Cursor) Empty() bool {
+	return c.Highest == 0 && c.Lowest == 0
+}
+
+// Newer returns the parameters for a page of checkins which are newer than
+// any observed so far.  If no checkins have been observed, the parameters
+// for the newest page are returned.
+func (c *Cursor) Newer() (minID int64, maxID int64) {
+	return c.Highest, math.MaxInt64
+}
+
+// Older returns the parameters for a page of checkins which are older than
+// any observed so far.  If no checkins have been observed, the parameters
+// for the newest page are returned.
+//
+// ok is false if the oldest possible checkin has already been observed, and
+// no older page exists.
+func (c *Cursor) Older() (minID int64, maxID int64, ok bool) {
+	if c.Empty() {
+		return 0, math.MaxInt64, true
+	}
+
+	return 0, c.Lowest - 1, c.Lowest > 1
+}
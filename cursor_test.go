@@ -0,0 +1,34 @@
+package untappd
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCursor verifies that a Cursor produces the correct parameters for
+// pages newer and older than the checkins it has observed.
+func TestCursor(t *testing.T) {
+	var c Cursor
+
+	if min, max := c.Newer(); min != 0 || max != math.MaxInt64 {
+		t.Fatalf("unexpected newer parameters for empty Cursor: %d, %d", min, max)
+	}
+	if min, max, ok := c.Older(); min != 0 || max != math.MaxInt64 || !ok {
+		t.Fatalf("unexpected older parameters for empty Cursor: %d, %d, %v", min, max, ok)
+	}
+
+	c.Observe([]*Checkin{{ID: 20}, nil, {ID: 30}, {ID: 10}})
+	c.Observe([]*Checkin{{ID: 25}})
+
+	if min, max := c.Newer(); min != 30 || max != math.MaxInt64 {
+		t.Fatalf("unexpected newer parameters: %d, %d", min, max)
+	}
+	if min, max, ok := c.Older(); min != 0 || max != 9 || !ok {
+		t.Fatalf("unexpected older parameters: %d, %d, %v", min, max, ok)
+	}
+
+	c.Observe([]*Checkin{{ID: 1}})
+	if _, _, ok := c.Older(); ok {
+		t.Fatal("expected no older page after observing checkin 1")
+	}
+}
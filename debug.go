@@ -0,0 +1,139 @@
+package untappd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// debugBundlePattern is the pattern of the names of debug bundle
+	// files, as accepted by ioutil.TempFile.
+	debugBundlePattern = "untappd-debug-*.json"
+
+	// modulePath is the module path of this package, used to find its
+	// version in build information.
+	modulePath = "github.com/mdlayher/untappd"
+)
+
+// sensitiveHeaders are the response headers which may carry credentials or
+// session state, and are omitted from debug bundles.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// WithDebugBundles enables capture of debug bundles for bug reports.  When a
+// response from the Untappd APIv4 cannot be decoded, or is not JSON, a debug
+// bundle is written to a new file named "untappd-debug-*.json" in dir.  If
+// dir is empty, the default directory for temporary files is used.
+//
+// Each bundle is a JSON document containing the request method and URL, with
+// the access token and client secret redacted as by RequestURL, the response
+// status and headers, with cookies and authorization headers omitted, the
+// raw response body, the resulting error, and the versions of this package
+// and of Go, so that the failure may be reproduced when attached to an
+// issue.  Response bodies may contain personal information, so bundles
+// should be reviewed before they are shared.
+//
+// While debug bundles are enabled, each response body is held in memory
+// until it is decoded.  Failures to write a bundle are ignored, and do not
+// affect the result of the request.
+func WithDebugBundles(dir string) ClientOption {
+	return func(c *clientConfig) error {
+		c.debugBundles = true
+		c.debugDir = dir
+		return nil
+	}
+}
+
+// A debugBundle is the JSON representation of a debug bundle.
+type debugBundle struct {
+	Time      time.Time `json:"time"`
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+
+	// Body is set if the response body is valid UTF-8, and BodyBase64
+	// otherwise, so that the body is retained exactly.
+	Body       string `json:"body,omitempty"`
+	BodyBase64 []byte `json:"body_base64,omitempty"`
+
+	Error string `json:"error"`
+}
+
+// writeDebugBundle writes a debug bundle for the input response and its raw
+// body, if debug bundles are enabled and err is a decode or protocol error.
+func (c *Client) writeDebugBundle(res *http.Response, body []byte, err error) {
+	var (
+		derr *DecodeError
+		nerr *NonJSONResponseError
+	)
+	if !c.cfg.debugBundles || err == nil || (!errors.As(err, &derr) && !errors.As(err, &nerr)) {
+		return
+	}
+
+	b := debugBundle{
+		Time:       c.clock().Now(),
+		Version:    libraryVersion(),
+		GoVersion:  runtime.Version(),
+		StatusCode: res.StatusCode,
+		Header:     res.Header.Clone(),
+		Error:      err.Error(),
+	}
+	if req := res.Request; req != nil {
+		b.Method = req.Method
+		b.URL = RequestURL(res)
+	}
+	for _, h := range sensitiveHeaders {
+		b.Header.Del(h)
+	}
+	if utf8.Valid(body) {
+		b.Body = string(body)
+	} else {
+		b.BodyBase64 = body
+	}
+
+	f, ferr := ioutil.TempFile(c.cfg.debugDir, debugBundlePattern)
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "\t")
+	_ = enc.Encode(b)
+}
+
+// libraryVersion returns the module version of this package, if the binary
+// was built with module support, or "(devel)" otherwise.
+func libraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, m := range info.Deps {
+		if m.Path != modulePath {
+			continue
+		}
+		if m.Replace != nil {
+			m = m.Replace
+		}
+		if m.Version != "" {
+			return m.Version
+		}
+	}
+
+	return "(devel)"
+}
@@ -0,0 +1,87 @@
+package untappd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestClientDebugBundles verifies that a Client writes a sanitized debug
+// bundle when a response cannot be decoded, and not when it succeeds.
+func TestClientDebugBundles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	body := `{"meta":{"code":200},"response":{"beer":{"bid":1,"created_at":"not a time"}}}`
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		w.Header().Set("X-Ratelimit-Remaining", "99")
+		if strings.HasSuffix(r.URL.Path, "/2/") {
+			w.Write(blackNoteBeerJSON)
+			return
+		}
+
+		w.Write([]byte(body))
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithDebugBundles(dir))
+
+	if _, _, err := c.Beer.Info(2, false); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = c.Beer.Info(1, false)
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "untappd-debug-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(paths); l != 1 {
+		t.Fatalf("unexpected number of debug bundles: %d != %d", l, 1)
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var b debugBundle
+	if err := json.NewDecoder(f).Decode(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Method != "GET" || !strings.Contains(b.URL, "/v4/beer/info/1/") {
+		t.Fatalf("unexpected request in debug bundle: %q %q", b.Method, b.URL)
+	}
+	if strings.Contains(b.URL, "client_secret=bar") || !strings.Contains(b.URL, "client_secret=REDACTED") {
+		t.Fatalf("expected client secret to be redacted: %q", b.URL)
+	}
+	if b.StatusCode != http.StatusOK || b.Header.Get("X-Ratelimit-Remaining") != "99" {
+		t.Fatalf("unexpected response in debug bundle: %d %v", b.StatusCode, b.Header)
+	}
+	if v := b.Header.Get("Set-Cookie"); v != "" {
+		t.Fatalf("unexpected cookie in debug bundle: %q", v)
+	}
+	if b.Body != body || b.BodyBase64 != nil {
+		t.Fatalf("unexpected body in debug bundle: %q", b.Body)
+	}
+	if b.Error != derr.Error() {
+		t.Fatalf("unexpected error in debug bundle: %q", b.Error)
+	}
+	if b.Version == "" || b.GoVersion == "" || b.Time.IsZero() {
+		t.Fatalf("unexpected versions in debug bundle: %+v", b)
+	}
+}
@@ -0,0 +1,57 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestWithDryRun verifies that requests which modify data are not sent in
+// dry-run mode, and that a response is synthesized from their parameters.
+func TestWithDryRun(t *testing.T) {
+	var sent []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method)
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	var dry []string
+	applyTestOptions(t, c, WithDryRun(func(_ context.Context, r *RequestInfo) {
+		dry = append(dry, r.Method+" "+r.Endpoint)
+	}))
+
+	checkin, res, err := c.Auth.Checkin(CheckinRequest{
+		BeerID:    10,
+		GMTOffset: -5,
+		TimeZone:  "EST",
+		Comment:   "hello world",
+		Rating:    3.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsDryRun(res) {
+		t.Fatal("expected a dry-run response")
+	}
+	if checkin.ID != 0 || checkin.Beer == nil || checkin.Beer.ID != 10 ||
+		checkin.Comment != "hello world" || checkin.UserRating != 3.5 {
+		t.Fatalf("unexpected synthesized checkin: %+v", checkin)
+	}
+	if len(dry) != 1 || dry[0] != "POST checkin/add" {
+		t.Fatalf("unexpected dry-run requests: %v", dry)
+	}
+
+	// Requests which do not modify data are still sent
+	_, res, err = c.Beer.Info(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsDryRun(res) {
+		t.Fatal("unexpected dry-run response for GET request")
+	}
+	if len(sent) != 1 || sent[0] != "GET" {
+		t.Fatalf("unexpected requests sent: %v", sent)
+	}
+}
@@ -0,0 +1,97 @@
+package untappd
+
+import "net/url"
+
+// Equal methods compare the descriptive fields of response structs, ignoring
+// fields which change from one response to the next, such as ratings, counts,
+// statistics, and activity.  url.URL and time.Time fields are compared by
+// their string and instant, rather than by their internal representation as
+// reflect.DeepEqual would.
+
+// Equal reports whether b and o describe the same Beer.  Ratings, counts,
+// statistics, and fields relative to the authenticated user, such as
+// UserRating and HaveHad, are ignored, and the breweries of each Beer are
+// compared using Brewery.Equal.  Two nil Beers are equal.
+func (b *Beer) Equal(o *Beer) bool {
+	if b == nil || o == nil {
+		return b == o
+	}
+
+	return b.ID == o.ID &&
+		b.Name == o.Name &&
+		urlEqual(b.Label, o.Label) &&
+		b.ABV == o.ABV &&
+		b.IBU == o.IBU &&
+		b.Slug == o.Slug &&
+		b.Style == o.Style &&
+		b.Description == o.Description &&
+		b.StyleID == o.StyleID &&
+		b.StyleFamily == o.StyleFamily &&
+		b.Created.Equal(o.Created) &&
+		b.Homebrew == o.Homebrew &&
+		b.InProduction == o.InProduction &&
+		b.Brewery.Equal(o.Brewery)
+}
+
+// Equal reports whether b and o describe the same Brewery.  Ratings,
+// statistics, follower counts, and the lists of beers, owners, and
+// collaborators are ignored.  Two nil Breweries are equal.
+func (b *Brewery) Equal(o *Brewery) bool {
+	if b == nil || o == nil {
+		return b == o
+	}
+
+	return b.ID == o.ID &&
+		b.Name == o.Name &&
+		b.Slug == o.Slug &&
+		urlEqual(b.Logo, o.Logo) &&
+		b.Country == o.Country &&
+		b.Active == o.Active &&
+		b.Location == o.Location &&
+		b.Contact.Twitter == o.Contact.Twitter &&
+		b.Contact.Instagram == o.Contact.Instagram &&
+		urlEqual(b.Contact.Facebook, o.Contact.Facebook) &&
+		urlEqual(b.Contact.URL, o.Contact.URL) &&
+		b.Claimed.Claimed == o.Claimed.Claimed &&
+		b.Claimed.Slug == o.Claimed.Slug &&
+		b.Type == o.Type &&
+		b.TypeID == o.TypeID &&
+		b.Independent == o.Independent &&
+		b.InProduction == o.InProduction &&
+		b.Description == o.Description
+}
+
+// Equal reports whether v and o describe the same Venue.  The time a Venue
+// was last updated, and its lists of beers, media, and checkins, are
+// ignored.  Two nil Venues are equal.
+func (v *Venue) Equal(o *Venue) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+
+	if len(v.Categories) != len(o.Categories) {
+		return false
+	}
+	for i := range v.Categories {
+		if v.Categories[i] != o.Categories[i] {
+			return false
+		}
+	}
+
+	return v.ID == o.ID &&
+		v.Name == o.Name &&
+		v.Category == o.Category &&
+		v.Public == o.Public &&
+		v.Verified == o.Verified &&
+		v.ParentID == o.ParentID &&
+		v.Location == o.Location &&
+		v.Contact.Twitter == o.Contact.Twitter &&
+		v.Contact.Instagram == o.Contact.Instagram &&
+		urlEqual(v.Contact.URL, o.Contact.URL) &&
+		v.Foursquare == o.Foursquare
+}
+
+// urlEqual reports whether two url.URLs have the same string representation.
+func urlEqual(a, b url.URL) bool {
+	return a.String() == b.String()
+}
@@ -0,0 +1,100 @@
+package untappd
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestBeerEqual verifies that Beer.Equal compares descriptive fields and
+// ignores volatile ones.
+func TestBeerEqual(t *testing.T) {
+	u, err := url.Parse("https://untappd.com/beer/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A URL which differs from u only in its internal representation
+	uu := *u
+	uu.RawPath = "/beer/2"
+
+	created := time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC)
+	beer := func() *Beer {
+		return &Beer{
+			ID:           2,
+			Name:         "Two Hearted Ale",
+			Label:        *u,
+			Created:      created,
+			InProduction: true,
+			Brewery:      &Brewery{ID: 1, Name: "Bell's"},
+		}
+	}
+
+	var tests = []struct {
+		desc string
+		fn   func(b *Beer)
+		ok   bool
+	}{
+		{desc: "identical", fn: func(b *Beer) {}, ok: true},
+		{desc: "volatile fields", fn: func(b *Beer) {
+			b.Rating.Score = 4.5
+			b.Stats.TotalCount = 100
+			b.UserRating = 5
+			b.HaveHad = true
+			b.Friends = []FriendRating{{Rating: 3}}
+			b.Brewery.Stats.TotalCount = 10
+		}, ok: true},
+		{desc: "URL representation", fn: func(b *Beer) { b.Label = uu }, ok: true},
+		{desc: "time zone", fn: func(b *Beer) { b.Created = created.In(time.FixedZone("EST", -5*60*60)) }, ok: true},
+		{desc: "name", fn: func(b *Beer) { b.Name = "Bell's Two Hearted Ale" }},
+		{desc: "ABV", fn: func(b *Beer) { b.ABV = 7 }},
+		{desc: "homebrew", fn: func(b *Beer) { b.Homebrew = true }},
+		{desc: "in production", fn: func(b *Beer) { b.InProduction = false }},
+		{desc: "brewery", fn: func(b *Beer) { b.Brewery.Name = "Bell's Brewery, Inc." }},
+		{desc: "no brewery", fn: func(b *Beer) { b.Brewery = nil }},
+	}
+
+	for _, tt := range tests {
+		b := beer()
+		tt.fn(b)
+
+		if ok := beer().Equal(b); ok != tt.ok {
+			t.Fatalf("[%s] unexpected Equal result: %v != %v", tt.desc, ok, tt.ok)
+		}
+	}
+
+	if !(*Beer)(nil).Equal(nil) || beer().Equal(nil) {
+		t.Fatal("unexpected Equal result for nil Beer")
+	}
+}
+
+// TestVenueEqual verifies that Venue.Equal compares descriptive fields and
+// ignores volatile ones.
+func TestVenueEqual(t *testing.T) {
+	venue := func() *Venue {
+		return &Venue{
+			ID:         1,
+			Name:       "Eccentric Cafe",
+			Categories: []VenueCategory{{ID: "1", Name: "Bar", Primary: true}},
+			Location:   VenueLocation{City: "Kalamazoo"},
+		}
+	}
+
+	a, b := venue(), venue()
+	b.Updated = time.Now()
+	b.TopBeers = []*Beer{{ID: 1}}
+	b.Checkins = []*Checkin{{ID: 1}}
+	if !a.Equal(b) {
+		t.Fatal("expected venues with different activity to be equal")
+	}
+
+	b.Categories[0].Primary = false
+	if a.Equal(b) {
+		t.Fatal("expected venues with different categories to differ")
+	}
+
+	b = venue()
+	b.Location.City = "Comstock"
+	if a.Equal(b) {
+		t.Fatal("expected venues with different locations to differ")
+	}
+}
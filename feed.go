@@ -0,0 +1,148 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// A MergedFeed polls the checkins of several Users, and merges them into a
+// single stream ordered by checkin time.  A Cursor is kept for each User, so
+// that each poll only retrieves checkins which are newer than those already
+// returned.
+//
+// A MergedFeed is not safe for concurrent use.
+type MergedFeed struct {
+	// Cursors contains the Cursor for each User, keyed by username.
+	// Cursors may be persisted, and restored into a new MergedFeed, so that
+	// polling resumes from the same position after a restart.  Users without
+	// a Cursor have their most recent page of checkins returned by the next
+	// poll.
+	Cursors map[string]*Cursor
+
+	client    *Client
+	usernames []string
+}
+
+// NewMergedFeed creates a MergedFeed which polls the checkins of the Users
+// with the input usernames, using the input Client.
+func NewMergedFeed(c *Client, usernames ...string) *MergedFeed {
+	return &MergedFeed{
+		Cursors:   make(map[string]*Cursor, len(usernames)),
+		client:    c,
+		usernames: usernames,
+	}
+}
+
+// Poll queries for the checkins of each User which are newer than those
+// returned by previous polls, and returns them merged in order of checkin
+// time, oldest first.  Users are polled one at a time.
+//
+// If an error occurs, the checkins retrieved from the Users polled so far are
+// returned along with the error, and the remaining Users' Cursors are left
+// unchanged, so they are polled again next time.
+func (f *MergedFeed) Poll(ctx context.Context) ([]*Checkin, error) {
+	var all []*Checkin
+	var err error
+	for _, username := range f.usernames {
+		var checkins []*Checkin
+		checkins, err = f.poll(ctx, username)
+		if err != nil {
+			break
+		}
+
+		all = append(all, checkins...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if !all[i].Created.Equal(all[j].Created) {
+			return all[i].Created.Before(all[j].Created)
+		}
+
+		return all[i].ID < all[j].ID
+	})
+
+	return all, err
+}
+
+// Run polls the MergedFeed repeatedly, waiting for the input interval after
+// each poll, and invokes fn for each checkin in order.  Run stops when the
+// context is canceled, or when Poll or fn returns an error, and returns the
+// error.  If the Untappd APIv4 is unavailable, Run resumes polling once the
+// outage is expected to end, rather than stopping.
+func (f *MergedFeed) Run(ctx context.Context, interval time.Duration, fn func(ctx context.Context, c *Checkin) error) error {
+	clk := f.client.clock()
+	for {
+		checkins, err := f.Poll(ctx)
+		for _, c := range checkins {
+			if ferr := fn(ctx, c); ferr != nil {
+				return ferr
+			}
+		}
+		if errors.Is(err, ErrServiceUnavailable) {
+			// Continue polling once an outage ends
+			if err := f.client.waitOutage(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := sleepUntil(ctx, clk, clk.Now().Add(interval)); err != nil {
+			return err
+		}
+	}
+}
+
+// poll queries for a single User's checkins which are newer than those
+// observed by the User's Cursor, paging backwards until the Cursor's range
+// is reached, and then updates the Cursor.
+func (f *MergedFeed) poll(ctx context.Context, username string) ([]*Checkin, error) {
+	cur, ok := f.Cursors[username]
+	if !ok {
+		cur = &Cursor{}
+	}
+	minID, maxID := cur.Newer()
+
+	var all []*Checkin
+	for {
+		q := url.Values{"limit": []string{strconv.Itoa(maxCheckinsLimit)}}
+		if minID != 0 {
+			q.Set("min_id", strconv.FormatInt(minID, 10))
+		}
+		if maxID != math.MaxInt64 {
+			q.Set("max_id", strconv.FormatInt(maxID, 10))
+		}
+
+		checkins, _, err := f.client.getCheckinsContext(ctx, "user/checkins/"+username, q)
+		if err != nil {
+			return nil, err
+		}
+
+		var page Cursor
+		for _, c := range checkins {
+			if c != nil {
+				all = append(all, c)
+			}
+		}
+		page.Observe(checkins)
+
+		// An empty Cursor only retrieves the most recent page, and a
+		// partial page indicates that no newer checkins remain
+		if cur.Empty() || page.Empty() || len(checkins) < maxCheckinsLimit || page.Lowest-1 <= minID {
+			break
+		}
+		maxID = page.Lowest - 1
+	}
+
+	cur.Observe(all)
+	f.Cursors[username] = cur
+
+	return all, nil
+}
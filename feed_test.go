@@ -0,0 +1,74 @@
+package untappd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMergedFeedPoll verifies that a MergedFeed merges the checkins of
+// several users in order of checkin time, and only returns new checkins on
+// subsequent polls.
+func TestMergedFeedPoll(t *testing.T) {
+	created := time.Date(2014, time.December, 13, 19, 0, 0, 0, time.UTC)
+
+	// Checkin IDs and minutes after created for each user
+	feeds := map[string][][2]int{
+		"alice": {{12, 30}, {10, 10}},
+		"bob":   {{11, 20}, {9, 0}},
+	}
+
+	var minIDs []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		username := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v4/user/checkins/"), "/")
+		minID := r.URL.Query().Get("min_id")
+		minIDs = append(minIDs, username+":"+minID)
+		min, _ := strconv.Atoi(minID)
+
+		var items []string
+		for _, ch := range feeds[username] {
+			if ch[0] <= min {
+				continue
+			}
+
+			items = append(items, fmt.Sprintf(`{"checkin_id": %d, "created_at": %q}`,
+				ch[0], created.Add(time.Duration(ch[1])*time.Minute).Format(time.RFC1123Z)))
+		}
+
+		fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"checkins": {"count": %d, "items": [%s]}}}`,
+			len(items), strings.Join(items, ","))
+	})
+	defer done()
+
+	f := NewMergedFeed(c, "alice", "bob")
+	ctx := context.Background()
+
+	checkins, err := f.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, ch := range checkins {
+		ids = append(ids, fmt.Sprint(ch.ID))
+	}
+	if got, want := strings.Join(ids, ","), "9,10,11,12"; got != want {
+		t.Fatalf("unexpected merged checkins: %q != %q", got, want)
+	}
+
+	feeds["bob"] = append([][2]int{{13, 40}}, feeds["bob"]...)
+	checkins, err = f.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkins) != 1 || checkins[0].ID != 13 {
+		t.Fatalf("unexpected new checkins: %d", len(checkins))
+	}
+
+	if got, want := strings.Join(minIDs, ","), "alice:,bob:,alice:12,bob:11"; got != want {
+		t.Fatalf("unexpected min_id parameters: %q != %q", got, want)
+	}
+}
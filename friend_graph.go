@@ -0,0 +1,144 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+)
+
+// A FriendGraph is a network of Untappd users and their friendships, as
+// returned by UserService.FriendGraph.  Users are identified by username.
+type FriendGraph struct {
+	// Root is the username of the User whose friends were traversed first.
+	Root string
+
+	// Users contains each User discovered during traversal, keyed by
+	// username.  Users beyond the traversal depth are included, but their
+	// friends are not.
+	Users map[string]*User
+
+	// Depth contains the number of friendships between Root and each User
+	// in Users, keyed by username.  Root has depth zero.
+	Depth map[string]int
+
+	// Friends contains the usernames of the friends of each User whose
+	// friends were traversed, keyed by username, in the order returned by
+	// the Untappd APIv4.
+	Friends map[string][]string
+}
+
+// Edges returns the number of distinct friendships in the FriendGraph.
+func (g *FriendGraph) Edges() int {
+	type edge struct{ a, b string }
+
+	seen := make(map[edge]struct{})
+	for a, friends := range g.Friends {
+		for _, b := range friends {
+			e := edge{a, b}
+			if b < a {
+				e = edge{b, a}
+			}
+			seen[e] = struct{}{}
+		}
+	}
+
+	return len(seen)
+}
+
+// FriendGraph traverses the friends of a User, and the friends of those
+// friends, breadth first, up to the input depth.  A depth of one retrieves
+// only the User's friends, and a depth of two also retrieves their friends.
+// The username parameter specifies the User at the root of the graph.
+//
+// Each User's friends are fetched once, even if the User is reachable
+// through multiple friendships, so cycles in the graph are traversed only
+// once.  Before fetching each User's friends, if the Untappd APIv4 has
+// reported that no requests remain in the current rate limit window,
+// FriendGraph waits for the window to reset, as measured by the Client's
+// Clock.
+//
+// Users whose profiles are private are included, with IsPrivate set, but
+// their friends are not traversed.  If any other error occurs, or the
+// context is canceled, the users traversed so far are returned along with
+// the error.  Progress is reported to a
+// ProgressFunc set using WithProgress, separately for each User's friends.
+func (u *UserService) FriendGraph(ctx context.Context, username string, depth int) (*FriendGraph, error) {
+	g := &FriendGraph{
+		Root:    username,
+		Users:   make(map[string]*User),
+		Depth:   map[string]int{username: 0},
+		Friends: make(map[string][]string),
+	}
+
+	queue := []string{username}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		d := g.Depth[name]
+		if d >= depth {
+			continue
+		}
+
+		if err := u.client.waitRateLimit(ctx); err != nil {
+			return g, err
+		}
+
+		friends, err := u.allFriends(ctx, name)
+		if err != nil {
+			// Private friends are recorded, but not traversed
+			if name != username && errors.Is(err, ErrPrivateProfile) {
+				g.Users[name].IsPrivate = true
+				continue
+			}
+
+			return g, err
+		}
+
+		names := make([]string, 0, len(friends))
+		for _, f := range friends {
+			if f == nil {
+				continue
+			}
+			names = append(names, f.UserName)
+
+			if _, ok := g.Depth[f.UserName]; ok {
+				continue
+			}
+			g.Users[f.UserName] = f
+			g.Depth[f.UserName] = d + 1
+			queue = append(queue, f.UserName)
+		}
+		g.Friends[name] = names
+	}
+
+	return g, nil
+}
+
+// allFriends fetches all of a User's friends, paging through the friends
+// list.
+func (u *UserService) allFriends(ctx context.Context, username string) ([]*User, error) {
+	var all []*User
+	err := u.client.walkPages(ctx, pageWalk{op: "user/friends/" + username}, func(ctx context.Context) (int, bool, error) {
+		users, _, err := u.friends(ctx, username, len(all), maxFriendsLimit)
+		if err != nil {
+			return 0, false, err
+		}
+
+		all = append(all, users...)
+		return len(users), len(users) == maxFriendsLimit, nil
+	})
+
+	return all, err
+}
+
+// waitRateLimit waits until the current rate limit window resets, if the
+// Untappd APIv4 has reported that no requests remain in it.  The rate limit is
+// interpreted as by concurrency, using DefaultHourlyBudget if the limit itself
+// is not reported.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	if c.concurrency(1) > 0 {
+		return nil
+	}
+
+	return c.wait(ctx, WaitEvent{Reason: WaitRateLimit}, c.RateLimit().Reset())
+}
@@ -0,0 +1,150 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClientUserFriendGraph verifies that Client.User.FriendGraph traverses
+// friends to the requested depth, fetching each User's friends once.
+func TestClientUserFriendGraph(t *testing.T) {
+	network := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"a", "c", "d"},
+		"c": {"a"},
+		"d": {"e"},
+	}
+
+	requests := make(map[string]int)
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v4/user/friends/"), "/")
+		requests[name]++
+
+		items := make([]string, 0, len(network[name]))
+		for _, f := range network[name] {
+			items = append(items, fmt.Sprintf(`{"user": {"user_name": %q}}`, f))
+		}
+
+		fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"count": %d, "items": [%s]}}`,
+			len(items), strings.Join(items, ","))
+	})
+	defer done()
+
+	g, err := c.User.FriendGraph(context.Background(), "a", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		if n := requests[name]; n != 1 {
+			t.Fatalf("unexpected number of requests for %q: %d != %d", name, n, 1)
+		}
+	}
+	if n := requests["d"]; n != 0 {
+		t.Fatalf("unexpected requests beyond depth: %d", n)
+	}
+
+	if l := len(g.Users); l != 3 {
+		t.Fatalf("unexpected number of users: %d != %d", l, 3)
+	}
+	if d := g.Depth["d"]; d != 2 {
+		t.Fatalf("unexpected depth for %q: %d != %d", "d", d, 2)
+	}
+	if _, ok := g.Users["e"]; ok {
+		t.Fatal("unexpected user beyond depth")
+	}
+	if n := g.Edges(); n != 4 {
+		t.Fatalf("unexpected number of edges: %d != %d", n, 4)
+	}
+}
+
+// TestClientUserFriendGraphRateLimit verifies that Client.User.FriendGraph
+// waits for the rate limit window to reset once no requests remain, even if
+// the limit itself is not reported.
+func TestClientUserFriendGraphRateLimit(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		remaining := "10"
+		if requests == 1 {
+			remaining = "0"
+		}
+		w.Header().Set(headerRateLimitRemaining, remaining)
+
+		w.Write([]byte(`{"meta": {"code": 200}, "response": {"count": 1, "items": [
+			{"user": {"user_name": "b"}}
+		]}}`))
+	})
+	defer done()
+
+	clk := &outageTestClock{now: time.Date(2016, time.May, 1, 12, 0, 0, 0, time.UTC)}
+	var events []WaitEvent
+	applyTestOptions(t, c,
+		WithClock(clk),
+		WithWaitFunc(func(ctx context.Context, e WaitEvent) {
+			events = append(events, e)
+		}),
+	)
+
+	if _, err := c.User.FriendGraph(context.Background(), "a", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 2)
+	}
+	if l := len(events); l != 1 {
+		t.Fatalf("unexpected number of wait events: %d != %d", l, 1)
+	}
+	if e := events[0]; e.Reason != WaitRateLimit || e.Wait != rateLimitWindow {
+		t.Fatalf("unexpected wait event: %+v", e)
+	}
+}
+
+// TestClientUserFriendGraphPrivate verifies that Client.User.FriendGraph
+// records Users whose profiles are private, without traversing their
+// friends.
+func TestClientUserFriendGraphPrivate(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v4/user/friends/"), "/")
+		if name == "b" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(privateProfileErrJSON)
+			return
+		}
+
+		w.Write([]byte(`{"meta": {"code": 200}, "response": {"count": 2, "items": [
+			{"user": {"user_name": "b", "is_private": 1}},
+			{"user": {"user_name": "c"}}
+		]}}`))
+	})
+	defer done()
+
+	g, err := c.User.FriendGraph(context.Background(), "a", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u := g.Users["b"]; u == nil || !u.IsPrivate {
+		t.Fatalf("expected private user: %+v", u)
+	}
+	if u := g.Users["c"]; u == nil || u.IsPrivate {
+		t.Fatalf("unexpected private user: %+v", u)
+	}
+	if _, ok := g.Friends["b"]; ok {
+		t.Fatal("unexpected friends for private user")
+	}
+	if l := len(g.Friends["c"]); l != 2 {
+		t.Fatalf("unexpected number of friends for %q: %d != %d", "c", l, 2)
+	}
+
+	// The root User's profile is not skipped
+	if _, err := c.User.FriendGraph(context.Background(), "b", 1); !errors.Is(err, ErrPrivateProfile) {
+		t.Fatalf("unexpected error for private root: %v", err)
+	}
+}
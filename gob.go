@@ -0,0 +1,11 @@
+package untappd
+
+// Response types such as Beer, Brewery, User, Venue, Checkin, and Library may
+// be encoded using encoding/gob, so that decoded values can be stored and
+// restored without parsing Untappd APIv4 JSON again.  Each type is encoded as
+// a plain struct, so a gob.Encoder sends its type information only once per
+// stream.
+//
+// Toasts, comments, and media of a Checkin which were deferred by a Client
+// using WithLazyDecoding are not encoded.  Call LoadToasts, LoadComments, and
+// LoadMedia before encoding a Checkin to retain them.
@@ -0,0 +1,90 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestGobRoundTrip verifies that response types can be encoded and decoded
+// using encoding/gob without losing information.
+func TestGobRoundTrip(t *testing.T) {
+	u, err := url.Parse("https://untappd.com/user/mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC)
+
+	brewery := &Brewery{ID: 1, Name: "Bell's Brewery, Inc.", Type: BreweryTypeMicro, Contact: BreweryContact{URL: *u}}
+	beer := &Beer{ID: 2, Name: "Two Hearted Ale", ABV: 7, Created: created, Brewery: brewery}
+	user := &User{UID: 3, UserName: "mdlayher", UntappdURL: *u}
+	in := &Library{
+		User:  user,
+		Beers: []*Beer{beer},
+		Checkins: []*Checkin{{
+			ID:      4,
+			Created: created,
+			User:    user,
+			Beer:    beer,
+			Venue:   &Venue{ID: 5, Name: "Eccentric Cafe", Categories: []VenueCategory{{Name: "Bar"}}},
+			Toasts:  []*Toast{},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out Library
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	// gob does not distinguish between nil and empty slices
+	in.Checkins[0].Toasts = nil
+	if !reflect.DeepEqual(in, &out) {
+		t.Fatalf("unexpected decoded Library:\n- want: %+v\n-  got: %+v", in, out)
+	}
+}
+
+// TestGobSize verifies that a stream of response types encoded using
+// encoding/gob is more compact than the same values encoded as JSON.
+func TestGobSize(t *testing.T) {
+	u, err := url.Parse("https://untappd.com/user/mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC)
+
+	checkins := make([]*Checkin, 100)
+	for i := range checkins {
+		brewery := &Brewery{ID: int64(i), Name: "Bell's Brewery, Inc.", Type: BreweryTypeMicro, Contact: BreweryContact{URL: *u}}
+		checkins[i] = &Checkin{
+			ID:      int64(i),
+			Created: created,
+			Comment: "cheers",
+			User:    &User{UID: 3, UserName: "mdlayher", UntappdURL: *u},
+			Beer:    &Beer{ID: int64(i), Name: "Two Hearted Ale", ABV: 7, Created: created, Brewery: brewery},
+			Brewery: brewery,
+			Venue:   &Venue{ID: 5, Name: "Eccentric Cafe", Categories: []VenueCategory{{Name: "Bar"}}},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(checkins); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(checkins)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g, j := buf.Len(), len(b); g >= j {
+		t.Fatalf("gob encoding is not more compact than JSON: %d >= %d bytes", g, j)
+	}
+}
@@ -0,0 +1,63 @@
+package untappd
+
+import (
+	"net/url"
+)
+
+// Approximate widths, in pixels, of the image sizes served by Untappd.
+const (
+	imageWidthSmall  = 100
+	imageWidthMedium = 320
+	imageWidthLarge  = 640
+
+	// imageWidthOriginal indicates an original or high resolution image
+	// of unknown width, which is assumed to be larger than any other size.
+	imageWidthOriginal = 0
+)
+
+// Image is a single size of an image hosted by Untappd, such as a beer label
+// or a checkin photo.
+type Image struct {
+	// Approximate width of the image in pixels.  A width of zero indicates
+	// an original or high resolution image of unknown width.
+	Width int
+
+	// Link to the image.
+	URL url.URL
+}
+
+// ImageSet is a set of sizes of the same image, ordered from smallest to
+// largest.
+type ImageSet []Image
+
+// BestFor returns the URL of the smallest image in the set which is at least
+// the specified width in pixels.  If no image is wide enough, the URL of the
+// largest image is returned.  If the set is empty, an empty URL is returned.
+func (s ImageSet) BestFor(width int) url.URL {
+	for _, img := range s {
+		if img.Width == imageWidthOriginal || img.Width >= width {
+			return img.URL
+		}
+	}
+
+	if len(s) == 0 {
+		return url.URL{}
+	}
+
+	return s[len(s)-1].URL
+}
+
+// newImageSet creates an ImageSet from a list of images ordered from smallest
+// to largest, omitting any images with empty URLs.
+func newImageSet(images ...Image) ImageSet {
+	var s ImageSet
+	for _, img := range images {
+		if img.URL.String() == "" {
+			continue
+		}
+
+		s = append(s, img)
+	}
+
+	return s
+}
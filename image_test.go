@@ -0,0 +1,41 @@
+package untappd
+
+import (
+	"net/url"
+	"testing"
+)
+
+// TestImageSetBestFor verifies that ImageSet.BestFor selects the smallest
+// image which satisfies the requested width.
+func TestImageSetBestFor(t *testing.T) {
+	set := newImageSet(
+		Image{Width: imageWidthSmall, URL: url.URL{Path: "/sm.jpg"}},
+		Image{Width: imageWidthMedium, URL: url.URL{}},
+		Image{Width: imageWidthLarge, URL: url.URL{Path: "/lg.jpg"}},
+		Image{Width: imageWidthOriginal, URL: url.URL{Path: "/og.jpg"}},
+	)
+
+	if l := len(set); l != 3 {
+		t.Fatalf("unexpected ImageSet length: %d != %d", l, 3)
+	}
+
+	var tests = []struct {
+		description string
+		set         ImageSet
+		width       int
+		path        string
+	}{
+		{"zero width", set, 0, "/sm.jpg"},
+		{"exact width", set, 100, "/sm.jpg"},
+		{"skips missing size", set, 200, "/lg.jpg"},
+		{"original", set, 1000, "/og.jpg"},
+		{"falls back to largest", set[:2], 1000, "/lg.jpg"},
+		{"empty", nil, 100, ""},
+	}
+
+	for _, tt := range tests {
+		if u := tt.set.BestFor(tt.width); u.Path != tt.path {
+			t.Fatalf("unexpected URL for test %q: %q != %q", tt.description, u.Path, tt.path)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RequestInfo describes a request to the Untappd APIv4, before credentials
+// are added.
+type RequestInfo struct {
+	// HTTP method and API endpoint, such as "GET" and "beer/info/1".
+	Method   string
+	Endpoint string
+
+	// Query string and POST body parameters.
+	Query url.Values
+	Body  url.Values
+}
+
+// An Interceptor contains hooks which are invoked by a Client around each
+// request to the Untappd APIv4.  Unlike an http.RoundTripper, an Interceptor
+// observes the request parameters and results as Go values.  Either hook may
+// be nil.
+type Interceptor struct {
+	// BeforeRequest is invoked before each request is sent.  It may modify
+	// or replace the request's query string and body parameters, which may
+	// be nil.  If it returns an error, the request is not sent and the error
+	// is returned.
+	BeforeRequest func(ctx context.Context, r *RequestInfo) error
+
+	// AfterResponse is invoked after each successful response is decoded,
+	// with the value which will be returned to the caller, such as a *Beer
+	// or []*Checkin.  It may modify the value in place.  If it returns an
+	// error, the error is returned in place of the value.
+	AfterResponse func(ctx context.Context, r *RequestInfo, v interface{}) error
+}
+
+// WithInterceptor adds an Interceptor to a Client.  Interceptors are invoked
+// in the order they were added.
+func WithInterceptor(i Interceptor) ClientOption {
+	return func(c *clientConfig) error {
+		c.interceptors = append(c.interceptors, i)
+		return nil
+	}
+}
+
+// requestInfoKey is the context key used to associate a RequestInfo with an
+// HTTP request, so it is available to AfterResponse hooks.
+type requestInfoKey struct{}
+
+// beforeRequest invokes the BeforeRequest hooks of all Interceptors, and
+// returns a context which carries the RequestInfo.
+func (c *Client) beforeRequest(ctx context.Context, r *RequestInfo) (context.Context, error) {
+	if len(c.interceptors) == 0 {
+		return ctx, nil
+	}
+
+	for _, i := range c.interceptors {
+		if i.BeforeRequest == nil {
+			continue
+		}
+		if err := i.BeforeRequest(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return context.WithValue(ctx, requestInfoKey{}, r), nil
+}
+
+// afterResponse invokes the AfterResponse hooks of all Interceptors with the
+// value decoded from an HTTP response.
+func (c *Client) afterResponse(res *http.Response, v interface{}) error {
+	if len(c.interceptors) == 0 || res == nil || res.Request == nil {
+		return nil
+	}
+
+	ctx := res.Request.Context()
+	r, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	if r == nil {
+		return nil
+	}
+
+	for _, i := range c.interceptors {
+		if i.AfterResponse == nil {
+			continue
+		}
+		if err := i.AfterResponse(ctx, r, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
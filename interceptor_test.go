@@ -0,0 +1,88 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestInterceptors verifies that Interceptors may modify request parameters
+// before a request is sent, and observe and enrich decoded results.
+func TestInterceptors(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"compact": []string{"true"},
+			"audit":   []string{"1"},
+		})
+
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	var seen []string
+	cfg := clientConfig{}
+	opts := []ClientOption{
+		WithInterceptor(Interceptor{
+			BeforeRequest: func(ctx context.Context, r *RequestInfo) error {
+				seen = append(seen, "before "+r.Method+" "+r.Endpoint)
+				r.Query.Set("audit", "1")
+				return nil
+			},
+		}),
+		WithInterceptor(Interceptor{
+			AfterResponse: func(ctx context.Context, r *RequestInfo, v interface{}) error {
+				seen = append(seen, "after "+r.Endpoint)
+
+				b, ok := v.(*Beer)
+				if !ok {
+					t.Fatalf("unexpected result type: %T", v)
+				}
+				b.Description = "enriched"
+				return nil
+			},
+		}),
+	}
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cfg.apply(c); err != nil {
+		t.Fatal(err)
+	}
+
+	b, _, err := c.Beer.Info(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Description != "enriched" {
+		t.Fatalf("unexpected beer description: %q", b.Description)
+	}
+
+	want := []string{"before GET beer/info/1", "after beer/info/1"}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Fatalf("unexpected interceptor calls: %v != %v", seen, want)
+	}
+}
+
+// TestInterceptorBeforeRequestError verifies that an error returned by a
+// BeforeRequest hook prevents the request from being sent.
+func TestInterceptorBeforeRequestError(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not have been sent")
+	})
+	defer done()
+
+	errTest := errors.New("denied")
+	c.interceptors = []Interceptor{{
+		BeforeRequest: func(ctx context.Context, r *RequestInfo) error {
+			return errTest
+		},
+	}}
+
+	if _, _, err := c.Beer.Info(1, false); err != errTest {
+		t.Fatalf("unexpected error: %v != %v", err, errTest)
+	}
+}
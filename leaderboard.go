@@ -0,0 +1,176 @@
+package untappd
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// A LeaderboardEntry contains the checkin statistics of a single User within
+// the time window of a Leaderboard.
+type LeaderboardEntry struct {
+	// Username of the User, and information about the User, if any was
+	// present in the User's checkins.
+	Username string
+	User     *User
+
+	// Number of checkins, distinct beers, and distinct beer styles.
+	Checkins    int
+	UniqueBeers int
+	Styles      int
+
+	// Average rating of the User's rated checkins, and the number of rated
+	// checkins.  AverageRating is zero if no checkins were rated.
+	AverageRating float64
+	Ratings       int
+}
+
+// A Leaderboard ranks Users by their checkin activity within a time window,
+// as returned by NewLeaderboard or UserService.Leaderboard.
+type Leaderboard struct {
+	// Time window of the Leaderboard.  Checkins created at or after Since,
+	// and before Until, are counted.
+	Since time.Time
+	Until time.Time
+
+	// Entries for each User, ordered by username.
+	Entries []*LeaderboardEntry
+}
+
+// NewLeaderboard computes a Leaderboard from the input checkins, counting
+// only checkins created within the time window from since to until.  An entry
+// is created for each of the input usernames, even those without checkins
+// in the window, and for each other User who has checkins in the window.
+// Checkins without a User are ignored.
+func NewLeaderboard(checkins []*Checkin, usernames []string, since time.Time, until time.Time) *Leaderboard {
+	type stats struct {
+		entry  *LeaderboardEntry
+		beers  map[int64]struct{}
+		styles map[string]struct{}
+		sum    float64
+	}
+
+	users := make(map[string]*stats)
+	get := func(username string) *stats {
+		s, ok := users[username]
+		if !ok {
+			s = &stats{
+				entry:  &LeaderboardEntry{Username: username},
+				beers:  make(map[int64]struct{}),
+				styles: make(map[string]struct{}),
+			}
+			users[username] = s
+		}
+
+		return s
+	}
+
+	for _, username := range usernames {
+		get(username)
+	}
+
+	for _, c := range checkins {
+		if c == nil || c.User == nil || c.Created.Before(since) || !c.Created.Before(until) {
+			continue
+		}
+
+		s := get(c.User.UserName)
+		s.entry.User = c.User
+		s.entry.Checkins++
+
+		if c.Beer != nil {
+			s.beers[c.Beer.ID] = struct{}{}
+			if c.Beer.Style != "" {
+				s.styles[c.Beer.Style] = struct{}{}
+			}
+		}
+
+		if c.UserRating > 0 {
+			s.sum += c.UserRating
+			s.entry.Ratings++
+		}
+	}
+
+	l := &Leaderboard{
+		Since:   since,
+		Until:   until,
+		Entries: make([]*LeaderboardEntry, 0, len(users)),
+	}
+	for _, s := range users {
+		s.entry.UniqueBeers = len(s.beers)
+		s.entry.Styles = len(s.styles)
+		if s.entry.Ratings > 0 {
+			s.entry.AverageRating = s.sum / float64(s.entry.Ratings)
+		}
+
+		l.Entries = append(l.Entries, s.entry)
+	}
+	sort.Slice(l.Entries, func(i, j int) bool {
+		return l.Entries[i].Username < l.Entries[j].Username
+	})
+
+	return l
+}
+
+// ByCheckins returns the Leaderboard's entries ranked by number of checkins,
+// highest first.  Ties are ordered by username.
+func (l *Leaderboard) ByCheckins() []*LeaderboardEntry {
+	return l.rank(func(e *LeaderboardEntry) float64 { return float64(e.Checkins) })
+}
+
+// ByUniqueBeers returns the Leaderboard's entries ranked by number of
+// distinct beers, highest first.  Ties are ordered by username.
+func (l *Leaderboard) ByUniqueBeers() []*LeaderboardEntry {
+	return l.rank(func(e *LeaderboardEntry) float64 { return float64(e.UniqueBeers) })
+}
+
+// ByStyles returns the Leaderboard's entries ranked by number of distinct
+// beer styles, highest first.  Ties are ordered by username.
+func (l *Leaderboard) ByStyles() []*LeaderboardEntry {
+	return l.rank(func(e *LeaderboardEntry) float64 { return float64(e.Styles) })
+}
+
+// ByAverageRating returns the Leaderboard's entries ranked by average rating,
+// highest first.  Entries without rated checkins are ranked last.  Ties are
+// ordered by username.
+func (l *Leaderboard) ByAverageRating() []*LeaderboardEntry {
+	return l.rank(func(e *LeaderboardEntry) float64 { return e.AverageRating })
+}
+
+// rank returns a copy of the Leaderboard's entries, sorted by the input
+// metric in descending order.
+func (l *Leaderboard) rank(metric func(e *LeaderboardEntry) float64) []*LeaderboardEntry {
+	out := make([]*LeaderboardEntry, len(l.Entries))
+	copy(out, l.Entries)
+
+	// Entries are already ordered by username, which breaks ties
+	sort.SliceStable(out, func(i, j int) bool {
+		return metric(out[i]) > metric(out[j])
+	})
+
+	return out
+}
+
+// Leaderboard queries for the checkins of each of the Users with the input
+// usernames, such as a User's friends, within the time window from since to
+// until, and computes a Leaderboard from them as with NewLeaderboard.  Each
+// User's checkins are fetched using CheckinsBetween, so a long time window
+// requires many requests.
+//
+// If an error occurs, a Leaderboard of the checkins fetched so far is
+// returned along with the error.  Progress is reported to a ProgressFunc set
+// using WithProgress, separately for each User.
+func (u *UserService) Leaderboard(ctx context.Context, usernames []string, since time.Time, until time.Time) (*Leaderboard, error) {
+	var all []*Checkin
+	var err error
+	for _, username := range usernames {
+		var checkins []*Checkin
+		checkins, err = u.CheckinsBetween(ctx, username, since, until)
+		all = append(all, checkins...)
+		if err != nil {
+			break
+		}
+	}
+
+	return NewLeaderboard(all, usernames, since, until), err
+}
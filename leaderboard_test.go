@@ -0,0 +1,68 @@
+package untappd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNewLeaderboard verifies that NewLeaderboard computes statistics for
+// each user within its time window, and ranks users by each statistic.
+func TestNewLeaderboard(t *testing.T) {
+	since := time.Date(2014, time.December, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 1, 0)
+
+	alice := &User{UserName: "alice"}
+	bob := &User{UserName: "bob"}
+
+	ipa := &Beer{ID: 1, Style: "IPA - American"}
+	stout := &Beer{ID: 2, Style: "Stout - Imperial / Double"}
+	ipa2 := &Beer{ID: 3, Style: "IPA - American"}
+
+	checkins := []*Checkin{
+		{User: alice, Beer: ipa, UserRating: 4, Created: since},
+		{User: alice, Beer: ipa, UserRating: 3, Created: since.Add(time.Hour)},
+		{User: alice, Beer: ipa2, Created: since.Add(2 * time.Hour)},
+		{User: bob, Beer: ipa, UserRating: 4.5, Created: since.AddDate(0, 0, 1)},
+		{User: bob, Beer: stout, UserRating: 4.5, Created: since.AddDate(0, 0, 2)},
+
+		// Outside the time window
+		{User: bob, Beer: stout, Created: since.Add(-time.Second)},
+		{User: bob, Beer: stout, Created: until},
+	}
+
+	l := NewLeaderboard(checkins, []string{"alice", "bob", "carol"}, since, until)
+	if n := len(l.Entries); n != 3 {
+		t.Fatalf("unexpected number of entries: %d != %d", n, 3)
+	}
+
+	a := l.Entries[0]
+	if a.Username != "alice" || a.Checkins != 3 || a.UniqueBeers != 2 || a.Styles != 1 ||
+		a.AverageRating != 3.5 || a.Ratings != 2 {
+		t.Fatalf("unexpected entry: %+v", a)
+	}
+
+	names := func(entries []*LeaderboardEntry) string {
+		var s string
+		for _, e := range entries {
+			s += e.Username[:1]
+		}
+		return s
+	}
+
+	var tests = []struct {
+		description string
+		entries     []*LeaderboardEntry
+		want        string
+	}{
+		{"checkins", l.ByCheckins(), "abc"},
+		{"unique beers", l.ByUniqueBeers(), "abc"},
+		{"styles", l.ByStyles(), "bac"},
+		{"average rating", l.ByAverageRating(), "bac"},
+	}
+
+	for _, tt := range tests {
+		if got := names(tt.entries); got != tt.want {
+			t.Fatalf("unexpected ranking by %s: %q != %q", tt.description, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package untappd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A DecodeWarning describes a single field of an Untappd APIv4 response which
+// could not be decoded while lenient decoding is enabled.  The field is left
+// as its zero value in the result.
+type DecodeWarning struct {
+	// Path to the field in the response JSON, such as
+	// "response.checkins.items[3].venue.foursquare.foursquare_url".
+	Field string
+
+	// Raw JSON value of the field.
+	Value string
+
+	// Err is the error which occurred while decoding the field.
+	Err error
+}
+
+// String returns the string representation of a DecodeWarning.
+func (w DecodeWarning) String() string {
+	return fmt.Sprintf("%s: cannot decode %s: %v", w.Field, w.Value, w.Err)
+}
+
+// WithLenientDecoding enables lenient decoding of API responses.  Normally,
+// a single malformed field, such as an invalid URL or an unexpected boolean
+// value, causes the entire response to fail to decode.  With lenient
+// decoding, each malformed field is instead left as its zero value, and a
+// DecodeWarning is recorded.  Warnings may be retrieved from the HTTP
+// response returned by each method using DecodeWarnings.
+//
+// Responses which decode successfully are not inspected further, so lenient
+// decoding only incurs additional cost for responses with malformed fields.
+func WithLenientDecoding() ClientOption {
+	return func(c *clientConfig) error {
+		c.lenient = true
+		return nil
+	}
+}
+
+// DecodeWarnings returns the DecodeWarnings recorded while decoding the input
+// HTTP response, if lenient decoding is enabled using WithLenientDecoding.
+// If all fields were decoded successfully, it returns nil.
+func DecodeWarnings(res *http.Response) []DecodeWarning {
+	if res == nil || res.Request == nil {
+		return nil
+	}
+
+	w, _ := res.Request.Context().Value(decodeWarningsKey{}).([]DecodeWarning)
+	return w
+}
+
+// decodeWarningsKey is the context key used to associate DecodeWarnings with
+// an HTTP response's request.
+type decodeWarningsKey struct{}
+
+// A DecodeError is returned when an Untappd APIv4 response cannot be decoded.
+// It identifies the endpoint and, where possible, the JSON path of the field
+// which could not be decoded.
+type DecodeError struct {
+	// API endpoint of the request, such as "beer/checkins/1".
+	Endpoint string
+
+	// Path to the offending field in the response JSON, such as
+	// "response.checkins.items[3].venue.last_updated".  Field is empty if
+	// the field could not be determined.
+	Field string
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+// Error returns the string representation of a DecodeError.
+func (e *DecodeError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("decoding %s response: %v", e.Endpoint, e.Err)
+	}
+
+	return fmt.Sprintf("decoding %s response: field %s: %v", e.Endpoint, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decode decodes the body of an HTTP response from the input endpoint into v.
+// If decoding fails, a *DecodeError is returned, unless lenient decoding is
+// enabled and the failure can be attributed to individual fields.
+//
+// The body is read into memory, so that a failure can be attributed to a
+// field.  If b is not nil, it is the body, which has already been read.
+func (c *Client) decode(res *http.Response, endpoint string, v interface{}, b []byte) error {
+	if b == nil {
+		var err error
+		b, err = ioutil.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	// Most responses decode successfully, so only inspect the body after
+	// a failure
+	err := decodeJSON(res, v)
+	var nerr *NonJSONResponseError
+	if err == nil || errors.As(err, &nerr) {
+		return err
+	}
+
+	clean, warnings, serr := sanitizeJSON(b, reflect.TypeOf(v))
+	if !c.cfg.lenient || serr != nil || len(warnings) == 0 {
+		derr := &DecodeError{
+			Endpoint: endpoint,
+			Err:      err,
+		}
+		if len(warnings) > 0 {
+			derr.Field = warnings[0].Field
+		}
+
+		return derr
+	}
+
+	// Start over with a fresh value, so no partially decoded fields remain
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	if err := json.Unmarshal(clean, v); err != nil {
+		return &DecodeError{
+			Endpoint: endpoint,
+			Err:      err,
+		}
+	}
+
+	ctx := context.WithValue(res.Request.Context(), decodeWarningsKey{}, warnings)
+	res.Request = res.Request.WithContext(ctx)
+
+	return nil
+}
+
+// unmarshalerType is the reflect.Type of json.Unmarshaler.
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// sanitizeJSON removes each value from a JSON document which cannot be
+// decoded into its corresponding field of type t, returning the resulting
+// document and a DecodeWarning for each removed value.
+func sanitizeJSON(data []byte, t reflect.Type) ([]byte, []DecodeWarning, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var tree interface{}
+	if err := d.Decode(&tree); err != nil {
+		return nil, nil, err
+	}
+
+	var s sanitizer
+	if !s.walk(tree, t, "") {
+		return nil, nil, errors.New("cannot decode response")
+	}
+
+	b, err := json.Marshal(tree)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, s.warnings, nil
+}
+
+// A sanitizer walks a generic JSON value alongside the Go type it will be
+// decoded into, removing values which cannot be decoded.
+type sanitizer struct {
+	warnings []DecodeWarning
+}
+
+// walk reports whether node can be decoded into type t, removing any nested
+// values which cannot be decoded.
+func (s *sanitizer) walk(node interface{}, t reflect.Type, path string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// null decodes into any type
+	if node == nil {
+		return true
+	}
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		err := tryDecode(node, t)
+		if err == nil {
+			return true
+		}
+
+		// Wrapper types such as responseVenue may fail only due to one of
+		// their nested fields
+		if m, ok := node.(map[string]interface{}); ok && t.Kind() == reflect.Struct {
+			s.fields(m, t, path)
+			if err = tryDecode(node, t); err == nil {
+				return true
+			}
+		}
+
+		s.warn(path, node, err)
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if m, ok := node.(map[string]interface{}); ok {
+			s.fields(m, t, path)
+			return true
+		}
+	case reflect.Slice, reflect.Array:
+		if a, ok := node.([]interface{}); ok {
+			for i := range a {
+				if !s.walk(a[i], t.Elem(), path+"["+strconv.Itoa(i)+"]") {
+					a[i] = nil
+				}
+			}
+			return true
+		}
+	case reflect.Map:
+		if m, ok := node.(map[string]interface{}); ok {
+			for _, k := range sortedKeys(m) {
+				if !s.walk(m[k], t.Elem(), joinPath(path, k)) {
+					delete(m, k)
+				}
+			}
+			return true
+		}
+	}
+
+	if err := tryDecode(node, t); err != nil {
+		s.warn(path, node, err)
+		return false
+	}
+
+	return true
+}
+
+// fields walks each member of a JSON object which corresponds to a field of
+// struct type t, removing members which cannot be decoded.
+func (s *sanitizer) fields(m map[string]interface{}, t reflect.Type, path string) {
+	for _, k := range sortedKeys(m) {
+		v := m[k]
+		ft, ok := fieldType(t, k)
+		if !ok {
+			// Unknown members are ignored by encoding/json
+			continue
+		}
+
+		if !s.walk(v, ft, joinPath(path, k)) {
+			delete(m, k)
+		}
+	}
+}
+
+// warn records a DecodeWarning.
+func (s *sanitizer) warn(path string, node interface{}, err error) {
+	b, _ := json.Marshal(node)
+	s.warnings = append(s.warnings, DecodeWarning{
+		Field: path,
+		Value: string(b),
+		Err:   err,
+	})
+}
+
+// tryDecode attempts to decode a generic JSON value into a new value of
+// type t.
+func tryDecode(node interface{}, t reflect.Type) error {
+	b, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, reflect.New(t).Interface())
+}
+
+// fieldType returns the type of the field of struct type t which is decoded
+// from the JSON object member with the input name, preferring an exact match
+// as encoding/json does.
+func fieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	if ft, ok := findField(t, name, false); ok {
+		return ft, true
+	}
+
+	return findField(t, name, true)
+}
+
+// findField implements fieldType, including fields promoted from embedded
+// structs.
+func findField(t reflect.Type, name string, fold bool) (reflect.Type, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tname := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && tname == "" {
+			et := f.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				if ft, ok := findField(et, name, fold); ok {
+					return ft, true
+				}
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+		if tname == "" {
+			tname = f.Name
+		}
+
+		if tname == name || (fold && strings.EqualFold(tname, name)) {
+			return f.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// sortedKeys returns the member names of a JSON object in sorted order, so
+// that warnings are reported deterministically.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// joinPath appends a JSON object member name to a path.
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
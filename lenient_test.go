@@ -0,0 +1,92 @@
+package untappd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientLenientDecoding verifies that malformed fields are zeroed and
+// reported as warnings with lenient decoding, instead of failing the entire
+// response.
+func TestClientLenientDecoding(t *testing.T) {
+	body := []byte(`{"response":{"checkins":{"count":3,"items":[
+		{"checkin_id":3,"checkin_comment":"ok"},
+		{"checkin_id":2,"created_at":"not a time","venue":{"venue_id":1,"venue_name":"Bar","last_updated":"yesterday"}},
+		{"checkin_id":1,"created_at":"Sat, 24 Oct 2015 22:01:15 +0000"}
+	]}}}`)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	})
+	defer done()
+
+	_, _, err := c.Beer.Checkins(1)
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("unexpected error with strict decoding: %v", err)
+	}
+	if derr.Endpoint != "beer/checkins/1" || derr.Field != "response.checkins.items[1].created_at" {
+		t.Fatalf("unexpected decode error context: %q, %q", derr.Endpoint, derr.Field)
+	}
+	var perr *time.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected decode error to wrap *time.ParseError: %v", err)
+	}
+
+	applyTestOptions(t, c, WithLenientDecoding())
+
+	checkins, res, err := c.Beer.Checkins(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 3 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 3)
+	}
+	if ch := checkins[1]; ch.ID != 2 || !ch.Created.IsZero() || ch.Venue == nil || ch.Venue.Name != "Bar" {
+		t.Fatalf("unexpected leniently decoded checkin: %+v", ch)
+	}
+	if checkins[2].Created.IsZero() {
+		t.Fatal("expected valid checkin time to be decoded")
+	}
+
+	warnings := DecodeWarnings(res)
+	if l := len(warnings); l != 2 {
+		t.Fatalf("unexpected number of warnings: %d != %d: %v", l, 2, warnings)
+	}
+
+	fields := make(map[string]string)
+	for _, w := range warnings {
+		fields[w.Field] = w.Value
+	}
+	want := map[string]string{
+		"response.checkins.items[1].created_at":         `"not a time"`,
+		"response.checkins.items[1].venue.last_updated": `"yesterday"`,
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Fatalf("unexpected warning value for %q: %q != %q", k, fields[k], v)
+		}
+	}
+}
+
+// TestClientLenientDecodingNoWarnings verifies that no warnings are reported
+// for well-formed responses.
+func TestClientLenientDecodingNoWarnings(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(checkinsPageJSON(10, 5))
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithLenientDecoding())
+
+	_, res, err := c.Beer.Checkins(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := DecodeWarnings(res); w != nil {
+		t.Fatalf("unexpected warnings: %v", w)
+	}
+}
@@ -0,0 +1,138 @@
+package untappd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A Library is the complete Untappd history of a User, as returned by
+// UserService.DownloadLibrary.
+type Library struct {
+	// Information about the User.
+	User *User
+
+	// Each distinct beer the User has checked in, sorted by the most recent
+	// checkin.
+	Beers []*Beer
+
+	// Each of the User's checkins, newest first.
+	Checkins []*Checkin
+
+	// Each of the User's badges.
+	Badges []*Badge
+
+	// Each beer on the User's wish list, most recently added first.
+	WishList []*Beer
+}
+
+// DownloadLibrary queries for a User's information, distinct beers, checkins,
+// badges, and wish list, and returns them in a single Library.  The username
+// parameter specifies the User whose library will be returned.
+//
+// The User's information is fetched first, and the remaining parts of the
+// library are then fetched concurrently, with one request in flight at a
+// time for each part, so that a large history does not exhaust the rate
+// limit in a burst.  If an error occurs, the remaining requests are canceled,
+// and the partial Library is returned along with the first error.  If the
+// User's profile is private, the error matches ErrPrivateProfile when using
+// errors.Is, so that callers may skip the User.
+//
+// Progress is reported to a ProgressFunc set using WithProgress, separately
+// for each part of the library.  The User's totals are used to estimate the
+// time remaining.
+func (u *UserService) DownloadLibrary(ctx context.Context, username string) (*Library, error) {
+	user, _, err := u.info(ctx, username, false)
+	if err != nil {
+		return &Library{}, err
+	}
+	lib := Library{User: user}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		ferr error
+		wg   sync.WaitGroup
+	)
+
+	// fetch runs fn concurrently, recording only the first error
+	fetch := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := fn(); err != nil {
+				mu.Lock()
+				if ferr == nil {
+					ferr = err
+				}
+				mu.Unlock()
+
+				cancel()
+			}
+		}()
+	}
+
+	fetch(func() (err error) {
+		lib.Beers, err = u.allBeers(ctx, pageWalk{
+			op:    "user/beers/" + username,
+			total: user.Stats.TotalBeers,
+		}, username, SortDate)
+		return err
+	})
+	fetch(func() (err error) {
+		lib.Checkins, err = u.client.allCheckins(ctx, pageWalk{
+			op:    "user/checkins/" + username,
+			total: user.Stats.TotalCheckins,
+		}, &Cursor{}, "user/checkins/"+username, nil, time.Time{}, time.Time{})
+		return err
+	})
+	fetch(func() error {
+		w := pageWalk{
+			op:    "user/badges/" + username,
+			total: user.Stats.TotalBadges,
+		}
+
+		// Offsets count every entry returned, including nil entries which
+		// are skipped
+		var offset int
+		return u.client.walkPages(ctx, w, func(ctx context.Context) (int, bool, error) {
+			badges, _, err := u.badges(ctx, username, offset, maxBadgesLimit)
+			if err != nil {
+				return 0, false, err
+			}
+			offset += len(badges)
+
+			for _, b := range badges {
+				if b != nil {
+					lib.Badges = append(lib.Badges, b)
+				}
+			}
+			return len(badges), len(badges) == maxBadgesLimit, nil
+		})
+	})
+	fetch(func() error {
+		w := pageWalk{op: "user/wishlist/" + username}
+
+		var offset int
+		return u.client.walkPages(ctx, w, func(ctx context.Context) (int, bool, error) {
+			beers, _, err := u.wishList(ctx, username, offset, maxBeersLimit, SortDate)
+			if err != nil {
+				return 0, false, err
+			}
+			offset += len(beers)
+
+			for _, b := range beers {
+				if b != nil {
+					lib.WishList = append(lib.WishList, b)
+				}
+			}
+			return len(beers), len(beers) == maxBeersLimit, nil
+		})
+	})
+
+	wg.Wait()
+	return &lib, ferr
+}
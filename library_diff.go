@@ -0,0 +1,104 @@
+package untappd
+
+// A LibraryDiff describes the changes between two snapshots of a User's
+// Library, as returned by Library.Diff.
+type LibraryDiff struct {
+	// Checkins which were not present in the previous Library, newest
+	// first.
+	Checkins []*Checkin
+
+	// Distinct beers which were not present in the previous Library, in
+	// the order of the current Library.
+	Beers []*Beer
+
+	// Beers present in both Libraries whose rating by the User changed.
+	Ratings []RatingChange
+
+	// Badges which were not present in the previous Library, or which have
+	// reached a higher level since.
+	Badges []*Badge
+}
+
+// A RatingChange describes a change to a User's rating of a Beer.
+type RatingChange struct {
+	// The Beer, as it appears in the current Library.
+	Beer *Beer
+
+	// The User's previous and current rating for the Beer.
+	Old float64
+	New float64
+}
+
+// Empty reports whether the LibraryDiff contains no changes.
+func (d *LibraryDiff) Empty() bool {
+	return len(d.Checkins) == 0 && len(d.Beers) == 0 &&
+		len(d.Ratings) == 0 && len(d.Badges) == 0
+}
+
+// Diff compares this Library with a previous snapshot of the same User's
+// Library, and reports new checkins, new distinct beers, rating changes, and
+// newly earned badges.  Items are matched by ID.  If prev is nil, all items
+// in this Library are reported as new.
+func (l *Library) Diff(prev *Library) *LibraryDiff {
+	if prev == nil {
+		prev = &Library{}
+	}
+
+	var d LibraryDiff
+
+	checkins := make(map[int64]struct{}, len(prev.Checkins))
+	for _, c := range prev.Checkins {
+		if c != nil {
+			checkins[c.ID] = struct{}{}
+		}
+	}
+	for _, c := range l.Checkins {
+		if c == nil {
+			continue
+		}
+		if _, ok := checkins[c.ID]; !ok {
+			d.Checkins = append(d.Checkins, c)
+		}
+	}
+
+	beers := make(map[int64]*Beer, len(prev.Beers))
+	for _, b := range prev.Beers {
+		if b != nil {
+			beers[b.ID] = b
+		}
+	}
+	for _, b := range l.Beers {
+		if b == nil {
+			continue
+		}
+
+		old, ok := beers[b.ID]
+		switch {
+		case !ok:
+			d.Beers = append(d.Beers, b)
+		case old.UserRating != b.UserRating:
+			d.Ratings = append(d.Ratings, RatingChange{
+				Beer: b,
+				Old:  old.UserRating,
+				New:  b.UserRating,
+			})
+		}
+	}
+
+	badges := make(map[int64]*Badge, len(prev.Badges))
+	for _, b := range prev.Badges {
+		if b != nil {
+			badges[b.ID] = b
+		}
+	}
+	for _, b := range l.Badges {
+		if b == nil {
+			continue
+		}
+		if old, ok := badges[b.ID]; !ok || b.Level > old.Level {
+			d.Badges = append(d.Badges, b)
+		}
+	}
+
+	return &d
+}
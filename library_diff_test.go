@@ -0,0 +1,61 @@
+package untappd
+
+import "testing"
+
+// TestLibraryDiff verifies that Library.Diff reports new checkins, beers,
+// rating changes, and badges.
+func TestLibraryDiff(t *testing.T) {
+	prev := &Library{
+		Checkins: []*Checkin{{ID: 2}, {ID: 1}},
+		Beers: []*Beer{
+			{ID: 10, UserRating: 3.5},
+			{ID: 11, UserRating: 4},
+		},
+		Badges: []*Badge{
+			{ID: 100},
+			{ID: 101, IsLevel: true, Level: 1},
+		},
+	}
+
+	cur := &Library{
+		Checkins: []*Checkin{{ID: 4}, {ID: 3}, {ID: 2}, {ID: 1}},
+		Beers: []*Beer{
+			{ID: 12, UserRating: 5},
+			{ID: 10, UserRating: 4.25},
+			{ID: 11, UserRating: 4},
+		},
+		Badges: []*Badge{
+			{ID: 102},
+			{ID: 101, IsLevel: true, Level: 2},
+			{ID: 100},
+		},
+	}
+
+	d := cur.Diff(prev)
+	if d.Empty() {
+		t.Fatal("expected a non-empty diff")
+	}
+
+	if l := len(d.Checkins); l != 2 || d.Checkins[0].ID != 4 || d.Checkins[1].ID != 3 {
+		t.Fatalf("unexpected new checkins: %d", l)
+	}
+	if l := len(d.Beers); l != 1 || d.Beers[0].ID != 12 {
+		t.Fatalf("unexpected new beers: %d", l)
+	}
+	if l := len(d.Ratings); l != 1 {
+		t.Fatalf("unexpected number of rating changes: %d != %d", l, 1)
+	}
+	if r := d.Ratings[0]; r.Beer.ID != 10 || r.Old != 3.5 || r.New != 4.25 {
+		t.Fatalf("unexpected rating change: %+v", r)
+	}
+	if l := len(d.Badges); l != 2 || d.Badges[0].ID != 102 || d.Badges[1].ID != 101 {
+		t.Fatalf("unexpected new badges: %d", l)
+	}
+
+	if !cur.Diff(cur).Empty() {
+		t.Fatal("expected an empty diff for identical libraries")
+	}
+	if l := len(cur.Diff(nil).Checkins); l != 4 {
+		t.Fatalf("unexpected new checkins for nil library: %d != %d", l, 4)
+	}
+}
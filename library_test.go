@@ -0,0 +1,152 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestClientUserDownloadLibrary verifies that Client.User.DownloadLibrary
+// fetches each part of a User's library.
+func TestClientUserDownloadLibrary(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case "/v4/user/info/mdlayher":
+			w.Write([]byte(`{"response":{"user":{"user_name":"mdlayher"}}}`))
+		case "/v4/user/beers/mdlayher":
+			w.Write([]byte(`{"response":{"beers":{"count":2,"items":[{"beer":{"bid":1}},{"beer":{"bid":2}}]}}}`))
+		case "/v4/user/checkins/mdlayher":
+			w.Write(checkinsPageJSON(3, 3))
+		case "/v4/user/badges/mdlayher":
+			w.Write([]byte(`{"response":{"count":1,"items":[{"badge_id":1}]}}`))
+		case "/v4/user/wishlist/mdlayher":
+			w.Write([]byte(`{"response":{"beers":{"count":1,"items":[{"beer":{"bid":3}}]}}}`))
+		default:
+			t.Errorf("unexpected request path: %q", r.URL.Path)
+		}
+	})
+	defer done()
+
+	lib, err := c.User.DownloadLibrary(context.Background(), "mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lib.User == nil || lib.User.UserName != "mdlayher" {
+		t.Fatalf("unexpected user: %+v", lib.User)
+	}
+	if l := len(lib.Beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+	if l := len(lib.Checkins); l != 3 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 3)
+	}
+	if l := len(lib.Badges); l != 1 {
+		t.Fatalf("unexpected number of badges: %d != %d", l, 1)
+	}
+	if l := len(lib.WishList); l != 1 || lib.WishList[0].ID != 3 {
+		t.Fatalf("unexpected wish list: %v", lib.WishList)
+	}
+}
+
+// TestClientUserDownloadLibraryOffsets verifies that Client.User.DownloadLibrary
+// advances badge and wish list offsets past nil entries in each page.
+func TestClientUserDownloadLibraryOffsets(t *testing.T) {
+	// page returns a full page, of which the last entry is missing and
+	// decoded as nil
+	page := func(item string, n int) string {
+		items := make([]string, n-1)
+		for i := range items {
+			items[i] = fmt.Sprintf(item, i+1)
+		}
+
+		return fmt.Sprintf(`{"count":%d,"items":[%s]}`, n, strings.Join(items, ","))
+	}
+
+	// Parts of the library are fetched concurrently
+	var (
+		mu      sync.Mutex
+		offsets = make(map[string][]string)
+	)
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		offset := r.URL.Query().Get("offset")
+		mu.Lock()
+		offsets[path] = append(offsets[path], offset)
+		mu.Unlock()
+
+		switch path {
+		case "/v4/user/info/mdlayher":
+			w.Write([]byte(`{"response":{"user":{"user_name":"mdlayher"}}}`))
+		case "/v4/user/beers/mdlayher":
+			w.Write([]byte(`{"response":{"beers":{"count":0,"items":[]}}}`))
+		case "/v4/user/checkins/mdlayher":
+			w.Write(checkinsPageJSON(0, 0))
+		case "/v4/user/badges/mdlayher":
+			if offset == "0" {
+				fmt.Fprintf(w, `{"response":%s}`, page(`{"badge_id":%d}`, maxBadgesLimit))
+				return
+			}
+			w.Write([]byte(`{"response":{"count":1,"items":[{"badge_id":100}]}}`))
+		case "/v4/user/wishlist/mdlayher":
+			if offset == "0" {
+				fmt.Fprintf(w, `{"response":{"beers":%s}}`, page(`{"beer":{"bid":%d}}`, maxBeersLimit))
+				return
+			}
+			w.Write([]byte(`{"response":{"beers":{"count":1,"items":[{"beer":{"bid":100}}]}}}`))
+		default:
+			t.Errorf("unexpected request path: %q", r.URL.Path)
+		}
+	})
+	defer done()
+
+	lib, err := c.User.DownloadLibrary(context.Background(), "mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"/v4/user/badges/mdlayher":   {"0", strconv.Itoa(maxBadgesLimit)},
+		"/v4/user/wishlist/mdlayher": {"0", strconv.Itoa(maxBeersLimit)},
+	} {
+		if got := offsets[path]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected offsets for %q: %v != %v", path, got, want)
+		}
+	}
+	if l := len(lib.Badges); l != maxBadgesLimit {
+		t.Fatalf("unexpected number of badges: %d != %d", l, maxBadgesLimit)
+	}
+	if l := len(lib.WishList); l != maxBeersLimit {
+		t.Fatalf("unexpected number of wish list beers: %d != %d", l, maxBeersLimit)
+	}
+}
+
+// TestClientUserDownloadLibraryError verifies that Client.User.DownloadLibrary
+// returns the partial library and the first error which occurs.
+func TestClientUserDownloadLibraryError(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/badges/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(apiErrJSON)
+			return
+		}
+
+		w.Write([]byte(`{"response":{}}`))
+	})
+	defer done()
+
+	lib, err := c.User.DownloadLibrary(context.Background(), "mdlayher")
+	var uerr *Error
+	if !errors.As(err, &uerr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lib == nil {
+		t.Fatal("expected partial library")
+	}
+}
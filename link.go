@@ -0,0 +1,315 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxLinkRedirects is the maximum number of redirects followed by
+	// Client.ResolveLink.
+	maxLinkRedirects = 10
+)
+
+// ErrUnrecognizedLink is returned by ParseURL and Client.ResolveLink when a
+// link does not lead to an Untappd beer, brewery, checkin, user, or venue.
+var ErrUnrecognizedLink = errors.New("unrecognized Untappd link")
+
+// A LinkType is the type of object an Untappd link leads to.
+type LinkType string
+
+// LinkType constants which correspond to the objects an Untappd link may lead
+// to.
+const (
+	LinkBeer    LinkType = "beer"
+	LinkBrewery LinkType = "brewery"
+	LinkCheckin LinkType = "checkin"
+	LinkUser    LinkType = "user"
+	LinkVenue   LinkType = "venue"
+)
+
+// A Link identifies the object an Untappd link leads to, as returned by
+// ParseURL and Client.ResolveLink.
+type Link struct {
+	Type LinkType
+
+	// ID of the object.  Links to users identify them by user name in
+	// Slug, and have no ID.
+	ID int64
+
+	// Slug of the object, such as "bell-s-brewery-two-hearted-ale", or the
+	// user name of a user, if present in the link.
+	Slug string
+}
+
+// ResolveLink resolves an Untappd link, such as a short link shared from the
+// Untappd app or the payload of a scanned QR code, to the object it leads to.
+// The resulting ID may be passed to a method such as BeerService.Info to
+// retrieve the object itself, or the link may be passed to one of the
+// companion methods, such as Client.ResolveBeer.
+//
+// Links to untappd.com, and app links of the form untappd://beer/12345, are
+// parsed without performing any requests, and are not followed if they lead
+// to some other page.  Other HTTP and HTTPS links, such as untp.beer short
+// links, are requested using the Client's http.Client, and their redirects
+// followed until a link to untappd.com is reached, without consuming any of
+// the Client's rate limit.  Only short links on known Untappd short link
+// hosts, such as untp.beer, are requested, and redirects which leave those
+// hosts or untappd.com are not followed, so that links from untrusted
+// sources, such as scanned QR codes, cannot cause requests to other hosts.
+//
+// If the link does not lead to a beer, brewery, checkin, user, or venue,
+// ErrUnrecognizedLink is returned.
+func (c *Client) ResolveLink(ctx context.Context, link string) (*Link, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow redirects one at a time, so that the final page need not be
+	// fetched once its location is known
+	hc := *c.client
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	for i := 0; ; i++ {
+		l, ok, final := parseLink(u)
+		if ok {
+			return l, nil
+		}
+		if final || i == maxLinkRedirects {
+			return nil, ErrUnrecognizedLink
+		}
+		if !isShortLinkHost(u.Hostname()) {
+			return nil, fmt.Errorf("%w: %s is not an Untappd short link host", ErrUnrecognizedLink, u.Hostname())
+		}
+
+		next, err := c.redirect(ctx, &hc, u)
+		if err != nil {
+			return nil, err
+		}
+		u = next
+	}
+}
+
+// redirect requests the input URL using the input http.Client, and returns the
+// location to which the response redirects.
+func (c *Client) redirect(ctx context.Context, hc *http.Client, u *url.URL) (*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, fmt.Errorf("%w: %s returned HTTP %d", ErrUnrecognizedLink, u, res.StatusCode)
+	}
+
+	loc, err := res.Location()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s redirected without a location", ErrUnrecognizedLink, u)
+	}
+
+	return loc, nil
+}
+
+// ResolveBeer resolves a link using Client.ResolveLink, and retrieves the beer
+// it leads to.  If the link does not lead to a beer, ErrUnrecognizedLink is
+// returned.
+func (c *Client) ResolveBeer(ctx context.Context, link string) (*Beer, error) {
+	l, err := c.resolveLinkType(ctx, link, LinkBeer)
+	if err != nil {
+		return nil, err
+	}
+
+	beer, _, err := (&BeerService{client: c}).info(ctx, l.ID, false)
+	return beer, err
+}
+
+// ResolveBrewery resolves a link using Client.ResolveLink, and retrieves the
+// brewery it leads to.  If the link does not lead to a brewery,
+// ErrUnrecognizedLink is returned.
+func (c *Client) ResolveBrewery(ctx context.Context, link string) (*Brewery, error) {
+	l, err := c.resolveLinkType(ctx, link, LinkBrewery)
+	if err != nil {
+		return nil, err
+	}
+
+	brewery, _, err := (&BreweryService{client: c}).info(ctx, l.ID, false)
+	return brewery, err
+}
+
+// ResolveUser resolves a link using Client.ResolveLink, and retrieves the
+// user it leads to.  If the link does not lead to a user,
+// ErrUnrecognizedLink is returned.
+func (c *Client) ResolveUser(ctx context.Context, link string) (*User, error) {
+	l, err := c.resolveLinkType(ctx, link, LinkUser)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := (&UserService{client: c}).info(ctx, l.Slug, false)
+	return user, err
+}
+
+// ResolveVenue resolves a link using Client.ResolveLink, and retrieves the
+// venue it leads to.  If the link does not lead to a venue,
+// ErrUnrecognizedLink is returned.
+func (c *Client) ResolveVenue(ctx context.Context, link string) (*Venue, error) {
+	l, err := c.resolveLinkType(ctx, link, LinkVenue)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, _, err := (&VenueService{client: c}).info(ctx, l.ID, false)
+	return venue, err
+}
+
+// resolveLinkType resolves a link using Client.ResolveLink, and verifies that
+// it leads to an object of the input type.
+func (c *Client) resolveLinkType(ctx context.Context, link string, t LinkType) (*Link, error) {
+	l, err := c.ResolveLink(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	if l.Type != t {
+		return nil, fmt.Errorf("%w: link leads to a %s, not a %s", ErrUnrecognizedLink, l.Type, t)
+	}
+
+	return l, nil
+}
+
+// ParseURL parses a link to an object on untappd.com, such as
+// https://untappd.com/b/bell-s-brewery-two-hearted-ale/4509 or
+// https://untappd.com/user/mdlayher, or an Untappd app link, such as
+// untappd://beer/4509, without performing any requests.  Use
+// Client.ResolveLink to resolve short links as well.
+//
+// If the link does not lead to a beer, brewery, checkin, user, or venue,
+// ErrUnrecognizedLink is returned.
+func ParseURL(rawurl string) (*Link, error) {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return nil, err
+	}
+
+	l, ok, _ := parseLink(u)
+	if !ok {
+		return nil, ErrUnrecognizedLink
+	}
+
+	return l, nil
+}
+
+// parseLink parses a link to untappd.com, or an Untappd app link, reporting
+// whether the link was recognized, and whether the link is final, so that it
+// must not be followed if unrecognized.
+func parseLink(u *url.URL) (l *Link, ok bool, final bool) {
+	var parts []string
+	switch {
+	case u.Scheme == "untappd":
+		// App links place the type in the host, e.g. untappd://beer/12345
+		parts = append([]string{u.Host}, splitPath(u.Path)...)
+	case (u.Scheme == "http" || u.Scheme == "https") && isUntappdHost(u.Hostname()):
+		parts = splitPath(u.Path)
+	case u.Scheme == "http" || u.Scheme == "https":
+		return nil, false, false
+	default:
+		return nil, false, true
+	}
+
+	var (
+		t        LinkType
+		slug, id string
+	)
+	switch {
+	// /b/two-hearted-ale/4509, /w/bells-brewery/2507, and
+	// /v/eccentric-cafe/5024
+	case len(parts) == 3 && parts[0] == "b":
+		t, slug, id = LinkBeer, parts[1], parts[2]
+	case len(parts) == 3 && parts[0] == "w":
+		t, slug, id = LinkBrewery, parts[1], parts[2]
+	case len(parts) == 3 && parts[0] == "v":
+		t, slug, id = LinkVenue, parts[1], parts[2]
+	// /user/mdlayher/checkin/123456789
+	case len(parts) == 4 && parts[0] == "user" && parts[2] == "checkin":
+		t, id = LinkCheckin, parts[3]
+	// /user/mdlayher, which has no ID
+	case len(parts) == 2 && parts[0] == "user":
+		return &Link{Type: LinkUser, Slug: parts[1]}, true, true
+	// /beer/4509, /brewery/2507, /venue/5024, /checkin/123456789, and
+	// /c/123456789
+	case len(parts) == 2:
+		switch parts[0] {
+		case "beer":
+			t = LinkBeer
+		case "brewery":
+			t = LinkBrewery
+		case "venue":
+			t = LinkVenue
+		case "checkin", "c":
+			t = LinkCheckin
+		default:
+			return nil, false, true
+		}
+		id = parts[1]
+	default:
+		return nil, false, true
+	}
+
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n <= 0 {
+		return nil, false, true
+	}
+
+	return &Link{Type: t, ID: n, Slug: slug}, true, true
+}
+
+// isUntappdHost reports whether host is untappd.com or one of its subdomains.
+func isUntappdHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "untappd.com" || strings.HasSuffix(host, ".untappd.com")
+}
+
+// shortLinkHosts are the hosts of Untappd short links, which are followed by
+// Client.ResolveLink.
+var shortLinkHosts = []string{"untp.beer"}
+
+// isShortLinkHost reports whether host is an Untappd short link host.
+func isShortLinkHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range shortLinkHosts {
+		if host == h {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitPath splits a URL path into its non-empty segments.
+func splitPath(p string) []string {
+	var parts []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,268 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestClientResolveLink verifies that Client.ResolveLink parses Untappd
+// links, and follows redirects from short links.
+func TestClientResolveLink(t *testing.T) {
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+
+		switch r.URL.Path {
+		case "/abc":
+			http.Redirect(w, r, "/def", http.StatusFound)
+		case "/def":
+			http.Redirect(w, r, "https://untappd.com/b/bell-s-brewery-two-hearted-ale/4509", http.StatusMovedPermanently)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/home":
+			http.Redirect(w, r, "https://untappd.com/home", http.StatusFound)
+		case "/elsewhere":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("API should not be requested")
+	})
+	defer done()
+
+	// Serve short links from the test server
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyTestOptions(t, c, WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host != "untp.beer" {
+			t.Fatalf("unexpected request to host: %q", r.URL.Host)
+		}
+
+		req := r.Clone(r.Context())
+		req.URL.Scheme, req.URL.Host = u.Scheme, u.Host
+
+		res, err := http.DefaultTransport.RoundTrip(req)
+		if err == nil {
+			// Resolve relative redirects against the short link
+			res.Request = r
+		}
+		return res, err
+	})))
+
+	var tests = []struct {
+		desc string
+		link string
+		l    *Link
+		n    int
+	}{
+		{
+			desc: "beer",
+			link: "https://untappd.com/b/bell-s-brewery-two-hearted-ale/4509",
+			l:    &Link{Type: LinkBeer, ID: 4509, Slug: "bell-s-brewery-two-hearted-ale"},
+		},
+		{
+			desc: "beer ID",
+			link: "https://untappd.com/beer/4509?ref=share",
+			l:    &Link{Type: LinkBeer, ID: 4509},
+		},
+		{
+			desc: "venue",
+			link: " http://www.untappd.com/v/bells-eccentric-cafe/5024\n",
+			l:    &Link{Type: LinkVenue, ID: 5024, Slug: "bells-eccentric-cafe"},
+		},
+		{
+			desc: "checkin",
+			link: "https://untappd.com/user/mdlayher/checkin/123456789/",
+			l:    &Link{Type: LinkCheckin, ID: 123456789},
+		},
+		{
+			desc: "short checkin",
+			link: "https://untappd.com/c/123456789",
+			l:    &Link{Type: LinkCheckin, ID: 123456789},
+		},
+		{
+			desc: "app link",
+			link: "untappd://beer/4509",
+			l:    &Link{Type: LinkBeer, ID: 4509},
+		},
+		{
+			desc: "short link",
+			link: "https://untp.beer/abc",
+			l:    &Link{Type: LinkBeer, ID: 4509, Slug: "bell-s-brewery-two-hearted-ale"},
+			n:    2,
+		},
+		{
+			desc: "redirect loop",
+			link: "https://untp.beer/loop",
+			n:    maxLinkRedirects,
+		},
+		{
+			desc: "redirect to unrecognized page",
+			link: "https://untp.beer/home",
+			n:    1,
+		},
+		{
+			desc: "redirect to other host",
+			link: "https://untp.beer/elsewhere",
+			n:    1,
+		},
+		{
+			desc: "other host",
+			link: "http://169.254.169.254/latest/meta-data/",
+		},
+		{
+			desc: "not found",
+			link: "https://untp.beer/foo",
+			n:    1,
+		},
+		{
+			desc: "invalid ID",
+			link: "https://untappd.com/b/two-hearted-ale/foo",
+		},
+		{
+			desc: "not a link",
+			link: "two hearted ale",
+		},
+	}
+
+	for _, tt := range tests {
+		n = 0
+
+		l, err := c.ResolveLink(context.Background(), tt.link)
+		if tt.l == nil {
+			if !errors.Is(err, ErrUnrecognizedLink) {
+				t.Fatalf("[%s] unexpected error: %v", tt.desc, err)
+			}
+		} else if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		if !reflect.DeepEqual(tt.l, l) {
+			t.Fatalf("[%s] unexpected Link: %+v != %+v", tt.desc, tt.l, l)
+		}
+		if n != tt.n {
+			t.Fatalf("[%s] unexpected number of requests: %d != %d", tt.desc, n, tt.n)
+		}
+	}
+}
+
+// TestParseURL verifies that ParseURL extracts the type, ID, and slug of
+// objects from untappd.com links.
+func TestParseURL(t *testing.T) {
+	var tests = []struct {
+		desc string
+		s    string
+		l    *Link
+	}{
+		{
+			desc: "beer",
+			s:    "https://untappd.com/b/bell-s-brewery-two-hearted-ale/4509",
+			l:    &Link{Type: LinkBeer, ID: 4509, Slug: "bell-s-brewery-two-hearted-ale"},
+		},
+		{
+			desc: "brewery",
+			s:    "https://untappd.com/w/bell-s-brewery/2507",
+			l:    &Link{Type: LinkBrewery, ID: 2507, Slug: "bell-s-brewery"},
+		},
+		{
+			desc: "brewery ID",
+			s:    "https://untappd.com/brewery/2507",
+			l:    &Link{Type: LinkBrewery, ID: 2507},
+		},
+		{
+			desc: "user",
+			s:    "https://untappd.com/user/mdlayher",
+			l:    &Link{Type: LinkUser, Slug: "mdlayher"},
+		},
+		{
+			desc: "venue",
+			s:    "https://untappd.com/v/bells-eccentric-cafe/678",
+			l:    &Link{Type: LinkVenue, ID: 678, Slug: "bells-eccentric-cafe"},
+		},
+		{
+			desc: "app link",
+			s:    "untappd://user/mdlayher",
+			l:    &Link{Type: LinkUser, Slug: "mdlayher"},
+		},
+		{
+			desc: "short link",
+			s:    "https://untp.beer/abc",
+		},
+		{
+			desc: "unrecognized page",
+			s:    "https://untappd.com/user/mdlayher/beers",
+		},
+	}
+
+	for _, tt := range tests {
+		l, err := ParseURL(tt.s)
+		if tt.l == nil {
+			if !errors.Is(err, ErrUnrecognizedLink) {
+				t.Fatalf("[%s] unexpected error: %v", tt.desc, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%s] %v", tt.desc, err)
+		}
+
+		if !reflect.DeepEqual(tt.l, l) {
+			t.Fatalf("[%s] unexpected Link: %+v != %+v", tt.desc, tt.l, l)
+		}
+	}
+}
+
+// TestClientResolveBeer verifies that Client.ResolveBeer retrieves the beer
+// a link leads to.
+func TestClientResolveBeer(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/v4/beer/info/1/" {
+			t.Fatalf("unexpected path: %q", p)
+		}
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	beer, err := c.ResolveBeer(context.Background(), "https://untappd.com/b/bells-black-note/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beer.Name != "Black Note Stout" {
+		t.Fatalf("unexpected beer: %s", beer)
+	}
+}
+
+// TestClientResolveUser verifies that Client.ResolveUser retrieves the user
+// a link leads to, and rejects links to other objects.
+func TestClientResolveUser(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/v4/user/info/gregavola/" {
+			t.Fatalf("unexpected path: %q", p)
+		}
+		w.Write(gregavolaUserJSON)
+	})
+	defer done()
+
+	user, err := c.ResolveUser(context.Background(), "https://untappd.com/user/gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UserName != "gregavola" {
+		t.Fatalf("unexpected user: %q", user.UserName)
+	}
+
+	if _, err := c.ResolveUser(context.Background(), "https://untappd.com/v/bells-eccentric-cafe/678"); !errors.Is(err, ErrUnrecognizedLink) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
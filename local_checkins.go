@@ -17,8 +17,8 @@ type LocalCheckinsRequest struct {
 	// Optional parameters
 
 	// Minimum and maximum checkin IDs to query
-	MinID int
-	MaxID int
+	MinID int64
+	MaxID int64
 
 	// Maximum number of results to return
 	Limit int
@@ -54,8 +54,18 @@ func (l *LocalService) Checkins(latitude float64, longitude float64) ([]*Checkin
 // local area where recent checkins will be queried.
 //
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
+// one call.  If limit is greater than 25, the checkins are fetched using
+// multiple calls, and combined.
 func (l *LocalService) CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*Checkin, *http.Response, error) {
+	if err := r.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return l.client.getCheckinsLimit("thepub/local", r.values(), r.Limit, maxFeedCheckinsLimit)
+}
+
+// values returns the query parameters for a LocalCheckinsRequest.
+func (r LocalCheckinsRequest) values() url.Values {
 	// Add required parameters
 	q := url.Values{
 		"lat": []string{formatFloat(r.Latitude)},
@@ -64,10 +74,10 @@ func (l *LocalService) CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*C
 
 	// Add optional parameters, if not empty
 	if r.MinID != 0 {
-		q.Set("min_id", strconv.Itoa(r.MinID))
+		q.Set("min_id", strconv.FormatInt(r.MinID, 10))
 	}
 	if r.MaxID != 0 {
-		q.Set("max_id", strconv.Itoa(r.MaxID))
+		q.Set("max_id", strconv.FormatInt(r.MaxID, 10))
 	}
 
 	if r.Limit != 0 {
@@ -81,5 +91,5 @@ func (l *LocalService) CheckinsMinMaxIDLimitRadius(r LocalCheckinsRequest) ([]*C
 		q.Set("dist_pref", string(r.Units))
 	}
 
-	return l.client.getCheckins("thepub/local", q)
+	return q
 }
@@ -63,11 +63,11 @@ func TestClientLocalCheckinsMinMaxIDLimitRadiusOffsetLimitOK(t *testing.T) {
 	var lng = -1.00
 	sLng := formatFloat(lng)
 
-	var minID = 1
-	sMinID := strconv.Itoa(minID)
+	var minID int64 = 1
+	sMinID := strconv.FormatInt(minID, 10)
 
-	var maxID = math.MaxInt32
-	sMaxID := strconv.Itoa(maxID)
+	var maxID int64 = math.MaxInt64
+	sMaxID := strconv.FormatInt(maxID, 10)
 
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
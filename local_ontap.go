@@ -0,0 +1,100 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// A TapList approximates the beers on tap at a Venue, using recent checkins
+// in a local area, as returned by LocalService.OnTap.
+type TapList struct {
+	// The Venue and its distance from the queried location, as reported
+	// by LocalService.Venues.
+	Venue    *Venue
+	Distance float64
+
+	// Distinct beers recently checked in at the Venue, most recently
+	// checked in first.
+	Beers []*TapBeer
+}
+
+// A TapBeer is a Beer recently checked in at a Venue.
+type TapBeer struct {
+	// The Beer, including basic information retrieved using
+	// BeerService.InfoBatch.
+	Beer *Beer
+
+	// Number of recent checkins of the Beer at the Venue, and the time of
+	// the most recent one.
+	Checkins int
+	LastSeen time.Time
+}
+
+// OnTap approximates the beers on tap at venues in a local area, using only
+// public data.  The local area is specified as for LocalService.Venues, which
+// is used to retrieve the venues with recent checkin activity, nearest first.
+// Basic information about each distinct beer checked in at those venues is
+// then retrieved using BeerService.InfoBatch.
+//
+// Beers which are served at a venue, but have not been checked in recently,
+// are not reported, so the results are only an approximation of each
+// venue's tap list.
+//
+// If information about some beers cannot be retrieved, those beers retain
+// the information present in their checkins, and the *BatchError from
+// BeerService.InfoBatch is returned along with the results.
+func (l *LocalService) OnTap(ctx context.Context, latitude float64, longitude float64, radius int, units Distance) ([]*TapList, error) {
+	venues, err := l.Venues(ctx, latitude, longitude, radius, units)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]*TapList, 0, len(venues))
+	var ids []int64
+	for _, v := range venues {
+		tl := &TapList{
+			Venue:    v.Venue,
+			Distance: v.Distance,
+		}
+
+		// Checkins are newest first, so the first checkin of each beer is
+		// its most recent
+		byID := make(map[int64]*TapBeer)
+		for _, c := range v.Checkins {
+			if c.Beer == nil {
+				continue
+			}
+
+			tb, ok := byID[c.Beer.ID]
+			if !ok {
+				tb = &TapBeer{
+					Beer:     c.Beer,
+					LastSeen: c.Created,
+				}
+				byID[c.Beer.ID] = tb
+				tl.Beers = append(tl.Beers, tb)
+				ids = append(ids, c.Beer.ID)
+			}
+			tb.Checkins++
+		}
+
+		lists = append(lists, tl)
+	}
+
+	beers, err := (&BeerService{client: l.client}).InfoBatch(ctx, ids, true)
+	var berr *BatchError
+	if err != nil && !errors.As(err, &berr) {
+		return nil, err
+	}
+
+	for _, tl := range lists {
+		for _, tb := range tl.Beers {
+			if b, ok := beers[tb.Beer.ID]; ok {
+				tb.Beer = b
+			}
+		}
+	}
+
+	return lists, err
+}
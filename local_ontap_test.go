@@ -0,0 +1,83 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestClientLocalOnTap verifies that Client.Local.OnTap reports the distinct
+// beers recently checked in at each nearby venue.
+func TestClientLocalOnTap(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		var id int
+		switch p := r.URL.Path; {
+		case strings.HasPrefix(p, "/v4/venue/info/"):
+			fmt.Sscanf(p, "/v4/venue/info/%d/", &id)
+			fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"venue": {
+  "venue_id": %d,
+  "venue_name": "Venue %d",
+  "location": {"lat": %d, "lng": 0}
+}}}`, id, id, id)
+		case strings.HasPrefix(p, "/v4/beer/info/"):
+			fmt.Sscanf(p, "/v4/beer/info/%d/", &id)
+			if id == 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write(invalidBreweryErrJSON)
+				return
+			}
+
+			fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"beer": {"bid": %d, "beer_name": "Beer %d", "beer_style": "Stout"}}}`, id, id)
+		case p == "/v4/thepub/local/":
+			// Checkin ID, venue ID, and beer ID, newest first
+			var items []string
+			for _, v := range [][3]int{{10, 1, 1}, {9, 1, 2}, {8, 2, 1}, {7, 1, 1}, {6, 2, 3}} {
+				items = append(items, fmt.Sprintf(`{
+  "checkin_id": %d,
+  "created_at": "Sat, 21 May 2016 00:%02d:00 +0000",
+  "venue": {"venue_id": %d, "venue_name": "Venue %d"},
+  "beer": {"bid": %d, "beer_name": "Checkin Beer %d"}
+}`, v[0], v[0], v[1], v[1], v[2], v[2]))
+			}
+
+			fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"checkins": {"count": %d, "items": [%s]}}}`,
+				len(items), strings.Join(items, ","))
+		default:
+			t.Fatalf("unexpected path: %q", p)
+		}
+	})
+	defer done()
+
+	lists, err := c.Local.OnTap(context.Background(), 0, 0, 0, "")
+	var berr *BatchError
+	if !errors.As(err, &berr) || len(berr.Errors) != 1 || berr.Errors[3] == nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if l := len(lists); l != 2 {
+		t.Fatalf("unexpected number of venues: %d != %d", l, 2)
+	}
+
+	// Venue 1 is nearest, and its most recent checkin is of beer 1
+	tl := lists[0]
+	if id := tl.Venue.ID; id != 1 {
+		t.Fatalf("unexpected nearest venue: %d != %d", id, 1)
+	}
+	if l := len(tl.Beers); l != 2 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 2)
+	}
+	if tb := tl.Beers[0]; tb.Beer.ID != 1 || tb.Checkins != 2 || tb.LastSeen.Minute() != 10 {
+		t.Fatalf("unexpected beer: %+v", tb)
+	}
+	if name := tl.Beers[1].Beer.Name; name != "Beer 2" {
+		t.Fatalf("unexpected beer name: %q", name)
+	}
+
+	// Beer 3 could not be retrieved, so it retains its checkin information
+	if name := lists[1].Beers[1].Beer.Name; name != "Checkin Beer 3" {
+		t.Fatalf("unexpected beer name: %q", name)
+	}
+}
@@ -0,0 +1,123 @@
+package untappd
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+const (
+	// earthRadiusMiles and earthRadiusKilometers are the mean radius of the
+	// Earth, used to compute distances between coordinates.
+	earthRadiusMiles      = 3958.8
+	earthRadiusKilometers = 6371.0
+)
+
+// A NearbyVenue is a Venue with recent checkin activity in a local area, as
+// returned by LocalService.Venues.
+type NearbyVenue struct {
+	// The Venue, including basic information retrieved using
+	// VenueService.Info.
+	Venue *Venue
+
+	// Distance from the queried location to the Venue, in the units of
+	// the query.
+	Distance float64
+
+	// Recent checkins at the Venue in the local checkins feed, newest
+	// first.
+	Checkins []*Checkin
+}
+
+// Venues queries for the venues in a local area which have recent checkin
+// activity, specified by latitude, longitude, and a radius in the input
+// distance units.  If radius is zero, a radius of 25 is used, and if units
+// is empty, DistanceMiles is used.
+//
+// The most recent checkins in the area are grouped by venue, and basic
+// information about each distinct venue is then retrieved using
+// VenueService.InfoBatch.  Venues are sorted by their distance from the input location,
+// nearest first.  Checkins without a venue are ignored.  If an error occurs,
+// the venues retrieved so far are not returned.
+func (l *LocalService) Venues(ctx context.Context, latitude float64, longitude float64, radius int, units Distance) ([]*NearbyVenue, error) {
+	if radius == 0 {
+		radius = 25
+	}
+	if units == "" {
+		units = DistanceMiles
+	}
+
+	r := LocalCheckinsRequest{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Limit:     maxFeedCheckinsLimit,
+		Radius:    radius,
+		Units:     units,
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	checkins, _, err := l.client.getCheckinsContext(ctx, "thepub/local", r.values())
+	if err != nil {
+		return nil, err
+	}
+
+	// Group checkins by venue, preserving the order in which venues were
+	// first observed
+	var venues []*NearbyVenue
+	byID := make(map[int64]*NearbyVenue)
+	for _, c := range checkins {
+		if c == nil || c.Venue == nil {
+			continue
+		}
+
+		v, ok := byID[c.Venue.ID]
+		if !ok {
+			v = &NearbyVenue{Venue: c.Venue}
+			byID[c.Venue.ID] = v
+			venues = append(venues, v)
+		}
+		v.Checkins = append(v.Checkins, c)
+	}
+
+	ids := make([]int64, 0, len(venues))
+	for _, v := range venues {
+		ids = append(ids, v.Venue.ID)
+	}
+
+	infos, err := (&VenueService{client: l.client}).InfoBatch(ctx, ids, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range venues {
+		v.Venue = infos[v.Venue.ID]
+
+		loc := v.Venue.Location
+		v.Distance = distance(latitude, longitude, loc.Latitude, loc.Longitude, units)
+	}
+
+	sort.SliceStable(venues, func(i, j int) bool {
+		return venues[i].Distance < venues[j].Distance
+	})
+
+	return venues, nil
+}
+
+// distance returns the great-circle distance between two coordinates, in the
+// input units.
+func distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64, units Distance) float64 {
+	radius := earthRadiusMiles
+	if units == DistanceKilometers {
+		radius = earthRadiusKilometers
+	}
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLng := rad(lat2-lat1), rad(lng2-lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * radius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,83 @@
+package untappd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestClientLocalVenues verifies that Client.Local.Venues groups local
+// checkins by venue, retrieves each venue once, and sorts venues by distance.
+func TestClientLocalVenues(t *testing.T) {
+	// Venue ID to latitude, offset north from the query location
+	venues := map[int]float64{1: 1.0, 2: 0.1}
+
+	// Venues are retrieved concurrently
+	var (
+		mu    sync.Mutex
+		infos = make(map[string]int)
+	)
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v4/venue/info/") {
+			mu.Lock()
+			infos[r.URL.Path]++
+			mu.Unlock()
+			if r.URL.Query().Get("compact") != "true" {
+				t.Fatal("expected compact venue info request")
+			}
+
+			var id int
+			fmt.Sscanf(r.URL.Path, "/v4/venue/info/%d/", &id)
+			fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"venue": {
+  "venue_id": %d,
+  "venue_name": "Venue %d",
+  "location": {"lat": %f, "lng": 0}
+}}}`, id, id, venues[id])
+			return
+		}
+
+		if p := r.URL.Path; p != "/v4/thepub/local/" {
+			t.Fatalf("unexpected path: %q", p)
+		}
+
+		items := make([]string, 0, 3)
+		for i, id := range []int{1, 2, 1} {
+			items = append(items, fmt.Sprintf(`{"checkin_id": %d, "venue": {"venue_id": %d, "venue_name": "Venue %d"}}`, 10-i, id, id))
+		}
+		items = append(items, `{"checkin_id": 1, "venue": []}`)
+
+		fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"checkins": {"count": %d, "items": [%s]}}}`,
+			len(items), strings.Join(items, ","))
+	})
+	defer done()
+
+	nearby, err := c.Local.Venues(context.Background(), 0, 0, 0, DistanceKilometers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(nearby); l != 2 {
+		t.Fatalf("unexpected number of venues: %d != %d", l, 2)
+	}
+	for path, n := range infos {
+		if n != 1 {
+			t.Fatalf("unexpected number of requests for %q: %d != %d", path, n, 1)
+		}
+	}
+
+	if id := nearby[0].Venue.ID; id != 2 {
+		t.Fatalf("unexpected nearest venue: %d != %d", id, 2)
+	}
+	if l := len(nearby[1].Checkins); l != 2 {
+		t.Fatalf("unexpected number of checkins for venue: %d != %d", l, 2)
+	}
+
+	// One degree of latitude is roughly 111 kilometers
+	if d := nearby[1].Distance; math.Abs(d-111.2) > 0.1 {
+		t.Fatalf("unexpected distance: %f", d)
+	}
+}
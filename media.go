@@ -0,0 +1,213 @@
+package untappd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultMaxMediaSize is the maximum size of a single media file which
+	// will be downloaded by a MediaDownloader, if no other size is specified.
+	DefaultMaxMediaSize = 10 << 20
+)
+
+var (
+	// ErrMediaTooLarge is returned when a media file exceeds the maximum
+	// size permitted by a MediaDownloader.
+	ErrMediaTooLarge = errors.New("media exceeds maximum size")
+
+	// ErrNotImage is returned when a media URL does not return image
+	// content.
+	ErrNotImage = errors.New("media is not an image")
+)
+
+// MediaDownloader downloads images referenced by Untappd APIv4 responses,
+// such as beer labels, brewery logos, badge images, and checkin photos.
+//
+// If a cache directory is set, downloaded media is stored on disk, along with
+// its content type, and served from there by subsequent calls to Download for
+// the same URL.
+type MediaDownloader struct {
+	// Dir is the directory used to cache downloaded media.  If empty,
+	// media is not cached.
+	Dir string
+
+	// MaxSize is the maximum size in bytes of a single media file.  If
+	// zero, DefaultMaxMediaSize is used.
+	MaxSize int64
+
+	client *http.Client
+}
+
+// NewMediaDownloader creates a MediaDownloader which caches media in the
+// input directory, using the input http.Client.  If the directory is empty,
+// no caching is performed.  If the http.Client is nil, http.DefaultClient
+// will be used.
+func NewMediaDownloader(dir string, client *http.Client) *MediaDownloader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &MediaDownloader{
+		Dir:    dir,
+		client: client,
+	}
+}
+
+// Download retrieves the media located at the input URL, returning its
+// contents and the content type reported by the server, whether or not it is
+// served from the cache.  Only image content is accepted, including SVG
+// images, and media larger than MaxSize results in ErrMediaTooLarge.  The
+// context is used for the request, if the media is not cached.
+func (d *MediaDownloader) Download(ctx context.Context, u url.URL) ([]byte, string, error) {
+	// Check the cache before going to the network
+	path := d.cachePath(u)
+	if path != "" {
+		if b, cType, err := readCachedMedia(path); err == nil {
+			return b, cType, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	if c := res.StatusCode; c < 200 || c > 299 {
+		return nil, "", fmt.Errorf("media download failed: HTTP %03d", c)
+	}
+
+	// Validate content type, both as reported by the server and as
+	// detected from the content itself
+	cType := res.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(cType)
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return nil, "", ErrNotImage
+	}
+
+	max := d.maxSize()
+	if res.ContentLength > max {
+		return nil, "", ErrMediaTooLarge
+	}
+
+	// Read one byte beyond the limit to determine if the limit was exceeded
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, max+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(b)) > max {
+		return nil, "", ErrMediaTooLarge
+	}
+
+	// SVG images are XML text, which cannot be detected as an image, so
+	// only reject SVG content which is detected as an HTML page
+	detected := http.DetectContentType(b)
+	if mediaType == "image/svg+xml" {
+		if strings.HasPrefix(detected, "text/html") {
+			return nil, "", ErrNotImage
+		}
+	} else if !strings.HasPrefix(detected, "image/") {
+		return nil, "", ErrNotImage
+	}
+
+	if path != "" {
+		// Media is written last, so that it is never cached without its
+		// content type
+		if err := writeFileAtomic(path+contentTypeSuffix, []byte(cType)); err != nil {
+			return nil, "", err
+		}
+		if err := writeFileAtomic(path, b); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return b, cType, nil
+}
+
+// contentTypeSuffix is appended to the path of cached media to store its
+// content type.
+const contentTypeSuffix = ".content-type"
+
+// readCachedMedia reads cached media and its content type from the input
+// path.
+func readCachedMedia(path string) ([]byte, string, error) {
+	cType, err := ioutil.ReadFile(path + contentTypeSuffix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return b, string(cType), nil
+}
+
+// maxSize returns the maximum media size for this MediaDownloader.
+func (d *MediaDownloader) maxSize() int64 {
+	if d.MaxSize > 0 {
+		return d.MaxSize
+	}
+
+	return DefaultMaxMediaSize
+}
+
+// cachePath returns the path where media for the input URL is cached, or
+// an empty string if caching is disabled.
+func (d *MediaDownloader) cachePath(u url.URL) string {
+	if d.Dir == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(u.String()))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+filepath.Ext(u.Path))
+}
+
+// writeFileAtomic writes data to a temporary file, syncs it to disk, and
+// renames it to path, so that readers never observe a partially written
+// file, even after a crash.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), ".untappd-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,162 @@
+package untappd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+// TestMediaDownloaderDownloadCached verifies that MediaDownloader.Download
+// retrieves an image and serves subsequent requests from its cache.
+func TestMediaDownloaderDownloadCached(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pngImage)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "untappd-media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	u, err := url.Parse(srv.URL + "/label.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewMediaDownloader(dir, nil)
+	for i := 0; i < 2; i++ {
+		b, cType, err := d.Download(context.Background(), *u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, pngImage) {
+			t.Fatalf("unexpected media contents: %v != %v", b, pngImage)
+		}
+		if want := "image/png"; cType != want {
+			t.Fatalf("unexpected content type: %q != %q", cType, want)
+		}
+	}
+
+	if hits != 1 {
+		t.Fatalf("unexpected number of HTTP requests: %d != %d", hits, 1)
+	}
+}
+
+// TestMediaDownloaderDownloadCanceled verifies that MediaDownloader.Download
+// uses the input context for its request.
+func TestMediaDownloaderDownloadCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/label.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := NewMediaDownloader("", nil).Download(ctx, *u); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error for canceled context: %v", err)
+	}
+}
+
+// TestMediaDownloaderDownloadSVG verifies that MediaDownloader.Download
+// accepts SVG images, and returns the same content type from its cache as
+// from the server.
+func TestMediaDownloaderDownloadSVG(t *testing.T) {
+	const cType = "image/svg+xml; charset=utf-8"
+	svg := []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"/>`)
+
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", cType)
+		w.Write(svg)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "untappd-media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	u, err := url.Parse(srv.URL + "/badge.svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewMediaDownloader(dir, nil)
+	for i := 0; i < 2; i++ {
+		b, got, err := d.Download(context.Background(), *u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, svg) {
+			t.Fatalf("unexpected media contents: %q != %q", b, svg)
+		}
+		if got != cType {
+			t.Fatalf("unexpected content type for download %d: %q != %q", i, got, cType)
+		}
+	}
+
+	if hits != 1 {
+		t.Fatalf("unexpected number of HTTP requests: %d != %d", hits, 1)
+	}
+}
+
+// TestMediaDownloaderDownloadErrors verifies that MediaDownloader.Download
+// rejects non-image and oversized media.
+func TestMediaDownloaderDownloadErrors(t *testing.T) {
+	var tests = []struct {
+		description string
+		cType       string
+		body        []byte
+		maxSize     int64
+		expErr      error
+	}{
+		{"HTML content type", "text/html", []byte("<html></html>"), 0, ErrNotImage},
+		{"HTML content", "image/png", []byte("<html></html>"), 0, ErrNotImage},
+		{"HTML content as SVG", "image/svg+xml", []byte("<html></html>"), 0, ErrNotImage},
+		{"too large", "image/png", pngImage, 4, ErrMediaTooLarge},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", tt.cType)
+			w.Write(tt.body)
+		}))
+
+		u, err := url.Parse(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d := NewMediaDownloader("", nil)
+		d.MaxSize = tt.maxSize
+
+		_, _, err = d.Download(context.Background(), *u)
+		srv.Close()
+		if err != tt.expErr {
+			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.expErr)
+		}
+	}
+}
+
+// pngImage is the header of a PNG image, which is enough to satisfy
+// content type detection.
+var pngImage = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
@@ -0,0 +1,144 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Notification represents an Untappd notification for the authenticated
+// user, such as a toast or comment on one of their checkins.
+type Notification struct {
+	// Metadata from Untappd.
+	ID   int64
+	Type string
+
+	// Time when this notification was created.
+	Created time.Time
+
+	// If applicable, the checkin which this notification concerns.
+	CheckinID int64
+
+	// The user who caused the notification.  May be nil if no user
+	// information was returned.
+	User *User
+}
+
+// rawNotification is the raw JSON representation of an Untappd notification.
+// Its data is unmarshaled from JSON and then exported to a Notification struct.
+type rawNotification struct {
+	ID        int64    `json:"notification_id"`
+	Type      string   `json:"notification_type"`
+	Created   Time     `json:"created_at"`
+	CheckinID int64    `json:"checkin_id"`
+	User      *rawUser `json:"user"`
+}
+
+// export creates an exported Notification from a rawNotification struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawNotification) export() *Notification {
+	n := &Notification{
+		ID:        r.ID,
+		Type:      r.Type,
+		Created:   time.Time(r.Created),
+		CheckinID: r.CheckinID,
+	}
+
+	if r.User != nil {
+		n.User = r.User.export()
+	}
+
+	return n
+}
+
+// Notifications queries for the authenticated user's recent notifications,
+// newest first.
+func (a *AuthService) Notifications() ([]*Notification, *http.Response, error) {
+	return a.notifications(context.Background())
+}
+
+// notifications implements Notifications, binding the HTTP request to the
+// input context.
+func (a *AuthService) notifications(ctx context.Context) ([]*Notification, *http.Response, error) {
+	// Temporary struct to unmarshal notifications JSON
+	var v struct {
+		Response struct {
+			Notifications struct {
+				Count int                `json:"count"`
+				Items []*rawNotification `json:"items"`
+			} `json:"notifications"`
+		} `json:"response"`
+	}
+
+	// Perform request for the authenticated user's notifications
+	res, err := a.client.requestContext(ctx, "GET", "notifications", nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	// Build result slice from struct
+	notifications := make([]*Notification, 0, len(v.Response.Notifications.Items))
+	for _, n := range v.Response.Notifications.Items {
+		notifications = append(notifications, n.export())
+	}
+
+	if err := a.client.afterResponse(res, notifications); err != nil {
+		return nil, res, err
+	}
+
+	return notifications, res, nil
+}
+
+// PollNotifications queries for the authenticated user's notifications, and
+// invokes fn for each notification whose ID has not been recorded by the
+// input Tracker, oldest first.  A notification's ID is recorded once fn
+// returns nil, so a notification which fails to be handled is retried on the
+// next poll.  If fn returns an error, polling stops and the error is
+// returned.
+//
+// If interval is zero or negative, notifications are queried once.
+// Otherwise, notifications are queried again after each interval, until the
+// context is canceled.  The Tracker may be persisted between runs using its
+// WriteTo and ReadFrom methods, so that notifications are not handled again
+// after a restart.  A Tracker used for notifications should not also be
+// used for checkins.
+//
+// If interval is positive and the Untappd APIv4 is unavailable, polling
+// resumes once the outage is expected to end, rather than stopping.
+func (a *AuthService) PollNotifications(ctx context.Context, t *Tracker, interval time.Duration, fn func(ctx context.Context, n *Notification) error) error {
+	for {
+		notifications, _, err := a.notifications(ctx)
+		if err != nil {
+			// Continue polling once an outage ends
+			if interval > 0 && errors.Is(err, ErrServiceUnavailable) {
+				if err := a.client.waitOutage(ctx); err != nil {
+					return err
+				}
+				continue
+			}
+
+			return err
+		}
+
+		for i := len(notifications) - 1; i >= 0; i-- {
+			n := notifications[i]
+			if t.Has(n.ID) {
+				continue
+			}
+			if err := fn(ctx, n); err != nil {
+				return err
+			}
+			t.Seen(n.ID)
+		}
+
+		if interval <= 0 {
+			return nil
+		}
+
+		clk := a.client.clock()
+		if err := sleepUntil(ctx, clk, clk.Now().Add(interval)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestClientAuthNotifications verifies that Client.Auth.Notifications returns
+// a valid list of notifications.
+func TestClientAuthNotifications(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		method := "GET"
+		if m := r.Method; m != method {
+			t.Fatalf("unexpected HTTP method: %q != %q", m, method)
+		}
+
+		path := "/v4/notifications/"
+		if p := r.URL.Path; p != path {
+			t.Fatalf("unexpected path: %q != %q", p, path)
+		}
+
+		w.Write(notificationsJSON(3, 2, 1))
+	})
+	defer done()
+
+	notifications, _, err := c.Auth.Notifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(notifications); l != 3 {
+		t.Fatalf("unexpected number of notifications: %d != %d", l, 3)
+	}
+
+	n := notifications[0]
+	if n.ID != 3 || n.Type != "toast" || n.CheckinID != 100 || n.User == nil || n.User.UserName != "gregavola" {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+	if n.Created.IsZero() {
+		t.Fatal("expected notification creation time")
+	}
+}
+
+// TestClientAuthPollNotifications verifies that Client.Auth.PollNotifications
+// handles each notification once, oldest first, and retries notifications
+// which could not be handled.
+func TestClientAuthPollNotifications(t *testing.T) {
+	var poll int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		poll++
+		if poll == 1 {
+			w.Write(notificationsJSON(2, 1))
+			return
+		}
+
+		w.Write(notificationsJSON(4, 3, 2, 1))
+	})
+	defer done()
+
+	tr := NewTracker(0)
+	errFail := errors.New("failed")
+
+	var handled []int64
+	handle := func(_ context.Context, n *Notification) error {
+		if n.ID == 4 && poll == 2 {
+			return errFail
+		}
+
+		handled = append(handled, n.ID)
+		return nil
+	}
+
+	ctx := context.Background()
+	if err := c.Auth.PollNotifications(ctx, tr, 0, handle); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Auth.PollNotifications(ctx, tr, 0, handle); !errors.Is(err, errFail) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Auth.PollNotifications(ctx, tr, 0, handle); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int64{1, 2, 3, 4}
+	if len(handled) != len(want) {
+		t.Fatalf("unexpected handled notifications: %v != %v", handled, want)
+	}
+	for i := range want {
+		if handled[i] != want[i] {
+			t.Fatalf("unexpected handled notifications: %v != %v", handled, want)
+		}
+	}
+}
+
+// notificationsJSON returns a notifications response containing toast
+// notifications with the input IDs.
+func notificationsJSON(ids ...int64) []byte {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, fmt.Sprintf(`{
+  "notification_id": %d,
+  "notification_type": "toast",
+  "created_at": "Sat, 13 Dec 2014 19:15:38 +0000",
+  "checkin_id": 100,
+  "user": {
+    "uid": 1,
+    "user_name": "gregavola"
+  }
+}`, id))
+	}
+
+	return []byte(fmt.Sprintf(`{
+  "meta": {
+    "code": 200
+  },
+  "response": {
+    "notifications": {
+      "count": %d,
+      "items": [%s]
+    }
+  }
+}`, len(items), strings.Join(items, ",")))
+}
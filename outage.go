@@ -0,0 +1,270 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultOutageWait is the time after which requests are attempted
+	// again following an outage, if the Untappd APIv4 does not specify a
+	// retry time.
+	defaultOutageWait = 5 * time.Minute
+
+	// breakerThreshold is the number of consecutive server errors after
+	// which a Client's circuit breaker opens, and breakerWait is the time
+	// for which it remains open.  breakerWait doubles with each further
+	// server error, up to defaultOutageWait.
+	breakerThreshold = 5
+	breakerWait      = 30 * time.Second
+)
+
+var (
+	// ErrServiceUnavailable is matched by errors.Is when the Untappd APIv4
+	// is down for maintenance or otherwise unavailable to all requests.
+	// The returned error is a *ServiceUnavailableError.
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
+
+// A ServiceUnavailableError is returned when the Untappd APIv4 reports that
+// it is down for maintenance, using a Retry-After header or a maintenance
+// page, or when a Client's circuit breaker opens after several consecutive
+// requests fail with server errors, such as HTML error pages returned by an
+// intermediary in place of the API.  A single server error is returned as
+// is, and does not indicate an outage.
+//
+// Once an outage is detected, a Client and its clones fail each request with
+// a ServiceUnavailableError, without sending it, until RetryAt.  Pollers such
+// as MergedFeed.Run and AuthService.PollNotifications wait until RetryAt,
+// rather than stopping.  After RetryAt, requests are sent again, and the
+// outage ends with the first response which is not a server error.  While a
+// circuit breaker is open, each further server error reopens it for twice as
+// long.
+type ServiceUnavailableError struct {
+	// HTTP status code of the response which indicated the outage.
+	StatusCode int
+
+	// Estimated time when the Untappd APIv4 will be available again, from
+	// the Retry-After header of the response if specified.
+	RetryAt time.Time
+
+	// The error which indicated the outage, such as an *Error or
+	// *NonJSONResponseError.
+	Err error
+
+	// refused is set if the request was not sent, because the outage was
+	// already known.
+	refused bool
+}
+
+// Error returns the string representation of a ServiceUnavailableError.
+func (e *ServiceUnavailableError) Error() string {
+	return fmt.Sprintf("service unavailable until %s: %v", e.RetryAt.Format(time.RFC3339), e.Err)
+}
+
+// Is reports whether target is ErrServiceUnavailable.
+func (e *ServiceUnavailableError) Is(target error) bool {
+	return target == ErrServiceUnavailable
+}
+
+// Unwrap returns the error which indicated the outage.
+func (e *ServiceUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// An outageState records an ongoing outage of the Untappd APIv4, and acts as
+// a circuit breaker by counting consecutive server errors.  Outages affect
+// all credentials, so an outageState is shared by all clones of a Client.
+type outageState struct {
+	mu       sync.Mutex
+	err      *ServiceUnavailableError
+	failures int
+}
+
+// Unavailable reports whether the Client has detected an outage of the
+// Untappd APIv4 which has not yet ended, and if so, the estimated time when
+// it will be available again.
+//
+// Unavailable is safe for concurrent use.
+func (c *Client) Unavailable() (time.Time, bool) {
+	if err := c.checkOutage(); err != nil {
+		return err.(*ServiceUnavailableError).RetryAt, true
+	}
+
+	return time.Time{}, false
+}
+
+// checkOutage returns the *ServiceUnavailableError for an ongoing outage, if
+// its estimated retry time has not yet passed.
+func (c *Client) checkOutage() error {
+	c.outage.mu.Lock()
+	defer c.outage.mu.Unlock()
+
+	if c.outage.err == nil || !c.clock().Now().Before(c.outage.err.RetryAt) {
+		return nil
+	}
+
+	e := *c.outage.err
+	e.refused = true
+	return &e
+}
+
+// endOutage records that the Untappd APIv4 is available again, and closes
+// the circuit breaker.
+func (c *Client) endOutage() {
+	c.outage.mu.Lock()
+	defer c.outage.mu.Unlock()
+
+	c.outage.err = nil
+	c.outage.failures = 0
+}
+
+// detectOutage returns a *ServiceUnavailableError, and records an outage, if
+// the input response and the error which it produced indicate that the
+// Untappd APIv4 is unavailable, or if the circuit breaker opens.  Otherwise,
+// err is returned.  res is nil if the request failed without a response.
+func (c *Client) detectOutage(res *http.Response, err error) error {
+	if err == nil || isContextError(err) {
+		return err
+	}
+
+	outage := res != nil && isOutage(res, err)
+	if !outage && !isServerFailure(res, err) {
+		// The API is responding, even if this request failed
+		c.endOutage()
+		return err
+	}
+
+	now := c.clock().Now()
+
+	c.outage.mu.Lock()
+	defer c.outage.mu.Unlock()
+
+	c.outage.failures++
+
+	var (
+		retryAt time.Time
+		code    int
+	)
+	if res != nil {
+		code = res.StatusCode
+	}
+	switch {
+	case outage:
+		retryAt = retryAfter(res.Header, now)
+	case c.outage.failures >= breakerThreshold:
+		retryAt = now.Add(breakerBackoff(c.outage.failures))
+	default:
+		return err
+	}
+
+	e := &ServiceUnavailableError{
+		StatusCode: code,
+		RetryAt:    retryAt,
+		Err:        err,
+	}
+	c.outage.err = e
+	return e
+}
+
+// isServerFailure reports whether a request failed because the Untappd APIv4
+// could not serve it, so that the failure counts toward the circuit breaker:
+// a request which failed without a response, an HTTP 502, 503, or 504, or
+// another server error whose body is not an APIv4 error.  The APIv4 returns
+// HTTP 500 for ordinary errors such as invalid parameters, which do not count.
+func isServerFailure(res *http.Response, err error) bool {
+	if res == nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	if res.StatusCode < http.StatusInternalServerError {
+		return false
+	}
+
+	var aerr *Error
+	return !errors.As(err, &aerr) || aerr.Type == ""
+}
+
+// breakerBackoff returns the time for which the circuit breaker remains open
+// after the input number of consecutive server errors.
+func breakerBackoff(failures int) time.Duration {
+	d := breakerWait
+	for i := breakerThreshold; i < failures && d < defaultOutageWait; i++ {
+		d *= 2
+	}
+	if d > defaultOutageWait {
+		d = defaultOutageWait
+	}
+
+	return d
+}
+
+// isOutage reports whether the input response and the error which it
+// produced explicitly indicate that the Untappd APIv4 is unavailable, rather
+// than failing a single request.
+func isOutage(res *http.Response, err error) bool {
+	// Retry-After accompanies planned maintenance
+	if res.StatusCode == http.StatusServiceUnavailable && res.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	// Maintenance pages may be served in place of the API
+	var nerr *NonJSONResponseError
+	if errors.As(err, &nerr) {
+		return mentionsMaintenance(nerr.Snippet)
+	}
+
+	var aerr *Error
+	if errors.As(err, &aerr) {
+		return mentionsMaintenance(aerr.Type) || mentionsMaintenance(aerr.Detail) || mentionsMaintenance(aerr.DeveloperFriendly)
+	}
+
+	return false
+}
+
+// mentionsMaintenance reports whether s mentions maintenance.
+func mentionsMaintenance(s string) bool {
+	return strings.Contains(strings.ToLower(s), "maintenance")
+}
+
+// retryAfter returns the time specified by a Retry-After header, either as a
+// number of seconds or as an HTTP date, relative to the input current time.
+// If no valid time is specified, defaultOutageWait is used.
+func retryAfter(h http.Header, now time.Time) time.Time {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return now.Add(time.Duration(n) * time.Second)
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t
+	}
+
+	return now.Add(defaultOutageWait)
+}
+
+// waitOutage waits until the estimated end of an ongoing outage, if any,
+// reporting a WaitEvent with reason WaitOutage.  It returns an error only if
+// the context is canceled.
+func (c *Client) waitOutage(ctx context.Context) error {
+	err := c.checkOutage()
+	if err == nil {
+		return ctx.Err()
+	}
+
+	e := err.(*ServiceUnavailableError)
+	return c.wait(ctx, WaitEvent{
+		Reason:     WaitOutage,
+		StatusCode: e.StatusCode,
+		Err:        e,
+	}, e.RetryAt)
+}
@@ -0,0 +1,315 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestClientOutage verifies that a Client detects a maintenance page, fails
+// requests without sending them until the Retry-After time, and resumes
+// requests afterward.
+func TestClientOutage(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("<html><body>Down for maintenance</body></html>"))
+			return
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	clk := &outageTestClock{now: time.Date(2016, time.May, 1, 12, 0, 0, 0, time.UTC)}
+	applyTestOptions(t, c, WithClock(clk))
+
+	_, err := c.request("GET", "beer/info/1", nil, nil, nil)
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var uerr *ServiceUnavailableError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	retryAt := clk.Now().Add(120 * time.Second)
+	if uerr.StatusCode != http.StatusServiceUnavailable || !uerr.RetryAt.Equal(retryAt) {
+		t.Fatalf("unexpected service unavailable error: %+v", uerr)
+	}
+	if !errors.Is(err, ErrNonJSONResponse) {
+		t.Fatalf("expected underlying non-JSON response error: %v", err)
+	}
+
+	// Clones share the outage, and do not send requests until it ends
+	nc, err := c.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nc.request("GET", "beer/info/1", nil, nil, nil); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected error during outage: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("unexpected number of requests during outage: %d != %d", requests, 1)
+	}
+	if at, ok := c.Unavailable(); !ok || !at.Equal(retryAt) {
+		t.Fatalf("unexpected unavailability: %v, %v", at, ok)
+	}
+
+	clk.Advance(2 * time.Minute)
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error after outage: %v", err)
+	}
+	if _, ok := nc.Unavailable(); ok {
+		t.Fatal("expected outage to end")
+	}
+}
+
+// TestClientOutageAPIError verifies that a Client only detects an outage for
+// a single APIv4 error if it indicates maintenance.
+func TestClientOutageAPIError(t *testing.T) {
+	var tests = []struct {
+		description string
+		body        string
+		outage      bool
+	}{
+		{
+			description: "maintenance",
+			body:        `{"meta":{"code":503,"error_detail":"Untappd is currently down for maintenance.","error_type":"maintenance"}}`,
+			outage:      true,
+		},
+		{
+			description: "other",
+			body:        `{"meta":{"code":503,"error_detail":"Please try again.","error_type":"unavailable"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(tt.body))
+			})
+			defer done()
+
+			_, err := c.request("GET", "beer/info/1", nil, nil, nil)
+			if got := errors.Is(err, ErrServiceUnavailable); got != tt.outage {
+				t.Fatalf("unexpected outage detection: %v != %v: %v", got, tt.outage, err)
+			}
+
+			var aerr *Error
+			if !errors.As(err, &aerr) || aerr.Code != http.StatusServiceUnavailable {
+				t.Fatalf("expected underlying APIv4 error: %v", err)
+			}
+		})
+	}
+}
+
+// TestClientOutageCircuitBreaker verifies that a Client does not detect an
+// outage for a single server error, but opens its circuit breaker after
+// several consecutive server errors, and closes it after a success.
+func TestClientOutageCircuitBreaker(t *testing.T) {
+	var (
+		requests int
+		ok       bool
+	)
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ok {
+			w.Write([]byte("{}"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	})
+	defer done()
+
+	clk := &outageTestClock{now: time.Date(2016, time.May, 1, 12, 0, 0, 0, time.UTC)}
+	applyTestOptions(t, c, WithClock(clk))
+
+	for i := 1; i < breakerThreshold; i++ {
+		_, err := c.request("GET", "beer/info/1", nil, nil, nil)
+		if !errors.Is(err, ErrNonJSONResponse) || errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("unexpected error for server error %d: %v", i, err)
+		}
+		if _, down := c.Unavailable(); down {
+			t.Fatalf("unexpected outage after server error %d", i)
+		}
+	}
+
+	_, err := c.request("GET", "beer/info/1", nil, nil, nil)
+	var uerr *ServiceUnavailableError
+	if !errors.As(err, &uerr) || uerr.StatusCode != http.StatusBadGateway || !uerr.RetryAt.Equal(clk.Now().Add(breakerWait)) {
+		t.Fatalf("unexpected error when circuit breaker opens: %v", err)
+	}
+
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected error while circuit breaker is open: %v", err)
+	}
+	if requests != breakerThreshold {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, breakerThreshold)
+	}
+
+	// A further server error reopens the circuit breaker for longer
+	clk.Advance(breakerWait)
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); !errors.As(err, &uerr) || !uerr.RetryAt.Equal(clk.Now().Add(2*breakerWait)) {
+		t.Fatalf("unexpected error when circuit breaker reopens: %v", err)
+	}
+
+	ok = true
+	clk.Advance(2 * breakerWait)
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error after circuit breaker closes: %v", err)
+	}
+
+	// Server errors are counted again from zero
+	ok = false
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected outage after circuit breaker closes: %v", err)
+	}
+}
+
+// TestClientOutageCircuitBreakerAPIErrors verifies that APIv4 errors returned
+// with HTTP 500 do not open the circuit breaker, and that they reset its count
+// of server errors.
+func TestClientOutageCircuitBreakerAPIErrors(t *testing.T) {
+	var badGateway bool
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if badGateway {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(invalidBeerErrJSON)
+	})
+	defer done()
+
+	for i := 1; i <= 2*breakerThreshold; i++ {
+		_, _, err := c.Beer.Info(1, false)
+		var aerr *Error
+		if !errors.As(err, &aerr) || aerr.Type != "invalid_param" || errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("unexpected error for API error %d: %v", i, err)
+		}
+	}
+
+	for i := 1; i <= 2*breakerThreshold; i++ {
+		badGateway = i%breakerThreshold != 0
+		_, _, err := c.Beer.Info(1, false)
+		if errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("unexpected outage after request %d: %v", i, err)
+		}
+	}
+}
+
+// TestClientOutageCircuitBreakerTransport verifies that requests which fail
+// without a response open the circuit breaker.
+func TestClientOutageCircuitBreakerTransport(t *testing.T) {
+	c, err := NewClient("foo", "bar", &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < breakerThreshold; i++ {
+		if _, _, err := c.Beer.Info(1, false); err == nil || errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("unexpected error for transport error %d: %v", i, err)
+		}
+	}
+
+	if _, _, err := c.Beer.Info(1, false); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected error when circuit breaker opens: %v", err)
+	}
+}
+
+// TestClientAuthPollNotificationsOutage verifies that
+// Client.Auth.PollNotifications waits for an outage to end, rather than
+// stopping.
+func TestClientAuthPollNotificationsOutage(t *testing.T) {
+	var poll int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		poll++
+		if poll == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"meta":{"code":503,"error_detail":"Scheduled maintenance.","error_type":"maintenance"}}`))
+			return
+		}
+
+		w.Write(notificationsJSON(1))
+	})
+	defer done()
+
+	clk := &outageTestClock{now: time.Date(2016, time.May, 1, 12, 0, 0, 0, time.UTC)}
+	var events []WaitEvent
+	applyTestOptions(t, c,
+		WithClock(clk),
+		WithWaitFunc(func(ctx context.Context, e WaitEvent) {
+			events = append(events, e)
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errDone := errors.New("done")
+	err := c.Auth.PollNotifications(ctx, NewTracker(0), time.Minute, func(_ context.Context, n *Notification) error {
+		return errDone
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if poll != 2 {
+		t.Fatalf("unexpected number of polls: %d != %d", poll, 2)
+	}
+	if l := len(events); l != 1 {
+		t.Fatalf("unexpected number of wait events: %d != %d", l, 1)
+	}
+	if e := events[0]; e.Reason != WaitOutage || e.Wait != 30*time.Second || !errors.Is(e.Err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected wait event: %+v", e)
+	}
+}
+
+// outageTestClock is a Clock whose time only changes when advanced, or when
+// a timer created by After fires immediately.
+type outageTestClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *outageTestClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *outageTestClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *outageTestClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,305 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrDeadlineApproaching is returned by methods which fetch multiple
+	// pages of results, when the next page is not expected to complete
+	// before the context's deadline.  Results fetched before stopping are
+	// returned along with this error.
+	ErrDeadlineApproaching = errors.New("context deadline approaching")
+)
+
+const (
+	// maxCheckinsLimit, maxBeersLimit, and maxBadgesLimit are the maximum
+	// number of items which may be returned by one call to the checkins,
+	// beers, and badges APIs.
+	maxCheckinsLimit = 50
+	maxBeersLimit    = 50
+	maxBadgesLimit   = 50
+
+	// maxFriendsLimit and maxSearchLimit are the maximum number of items
+	// which may be returned by one call to the friends and search APIs.
+	maxFriendsLimit = 25
+	maxSearchLimit  = 50
+
+	// maxFeedCheckinsLimit is the maximum number of checkins which may be
+	// returned by one call to the beer, brewery, venue, and local checkins
+	// APIs.
+	maxFeedCheckinsLimit = 25
+)
+
+// pageFunc fetches a single page of results, reporting the number of items
+// fetched and whether more pages are available.
+type pageFunc func(ctx context.Context) (n int, more bool, err error)
+
+// A pageWalk describes a multi-page operation performed by walkPages.
+type pageWalk struct {
+	// Operation name and expected total number of items, reported to
+	// ProgressFuncs.  total is zero if unknown.
+	op    string
+	total int
+}
+
+// walkPages invokes fn repeatedly until no more pages are available, or an
+// error occurs.  After each page, progress is reported to the context's
+// ProgressFunc, if any.
+//
+// If the context has a deadline, walkPages stops with ErrDeadlineApproaching
+// before starting a page which is not expected to complete in time, based on
+// the slowest page fetched so far, as measured by the Client's Clock.
+func (c *Client) walkPages(ctx context.Context, w pageWalk, fn pageFunc) error {
+	clk := c.clock()
+	progress := progressFromContext(ctx)
+
+	p := Progress{
+		Operation: w.op,
+		Total:     w.total,
+	}
+	began := clk.Now()
+
+	var slowest time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && slowest > 0 && deadline.Sub(clk.Now()) < slowest {
+			return ErrDeadlineApproaching
+		}
+
+		start := clk.Now()
+		n, more, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		now := clk.Now()
+		if d := now.Sub(start); d > slowest {
+			slowest = d
+		}
+
+		if progress != nil {
+			p.Pages++
+			p.Items += n
+			p.Done = !more
+			p.RateLimit = c.RateLimit()
+			p.ETA = p.eta(now.Sub(began))
+			progress(p)
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+// AllCheckins queries for all of a User's checkins, fetching pages of
+// checkins until the User's entire checkin history has been retrieved.
+// The username parameter specifies the User whose checkins will be returned.
+//
+// If the context has a deadline, AllCheckins stops before fetching a page
+// which is not expected to complete in time, and returns the checkins
+// fetched so far along with ErrDeadlineApproaching.  If any other error
+// occurs, the checkins fetched so far are also returned.  Progress is
+// reported to a ProgressFunc set using WithProgress.  The checkins API does
+// not report the total number of checkins, so progress reports have no Total
+// or ETA.  DownloadLibrary reports both, using the User's checkin count.
+func (u *UserService) AllCheckins(ctx context.Context, username string) ([]*Checkin, error) {
+	return u.AllCheckinsFrom(ctx, username, &Cursor{})
+}
+
+// AllCheckinsFrom is like AllCheckins, but resumes from a checkpoint.  Only
+// checkins older than those already observed by the input Cursor are
+// fetched, and the Cursor is updated after each page is fetched.
+//
+// If AllCheckinsFrom returns an error, such as when the rate limit is
+// exceeded, the Cursor may be persisted using its MarshalJSON method, and
+// later restored and passed to AllCheckinsFrom to resume from the last
+// completed page.  An empty Cursor fetches the User's entire checkin history.
+func (u *UserService) AllCheckinsFrom(ctx context.Context, username string, cur *Cursor) ([]*Checkin, error) {
+	return u.client.allCheckins(ctx, pageWalk{op: "user/checkins/" + username}, cur, "user/checkins/"+username, nil, time.Time{}, time.Time{})
+}
+
+// CheckinsSince queries for all of a User's checkins which were created at
+// or after the input time, such as "everything since January 1st".  It is
+// equivalent to CheckinsBetween with no upper bound.
+func (u *UserService) CheckinsSince(ctx context.Context, username string, since time.Time) ([]*Checkin, error) {
+	return u.CheckinsBetween(ctx, username, since, time.Time{})
+}
+
+// CheckinsBetween queries for all of a User's checkins which were created
+// within the half-open interval [start, end).  If start is zero, there is no
+// lower bound, and if end is zero, there is no upper bound.
+//
+// Checkins are fetched from newest to oldest, as with AllCheckins, but
+// pagination stops as soon as a checkin created before start is reached,
+// rather than retrieving the User's entire checkin history.  Errors and
+// deadlines are handled as with AllCheckins.
+func (u *UserService) CheckinsBetween(ctx context.Context, username string, start time.Time, end time.Time) ([]*Checkin, error) {
+	return u.client.allCheckins(ctx, pageWalk{op: "user/checkins/" + username}, &Cursor{}, "user/checkins/"+username, nil, start, end)
+}
+
+// AllBeers queries for all of a User's checked-in beers, fetching pages of
+// beers until the User's entire beer list has been retrieved.  The username
+// parameter specifies the User whose beers will be returned, and the sort
+// parameter specifies the order of the results.
+//
+// If the context has a deadline, AllBeers stops before fetching a page
+// which is not expected to complete in time, and returns the beers fetched
+// so far along with ErrDeadlineApproaching.  If any other error occurs, the
+// beers fetched so far are also returned.  Progress is reported to a
+// ProgressFunc set using WithProgress.
+func (u *UserService) AllBeers(ctx context.Context, username string, sort Sort) ([]*Beer, error) {
+	return u.allBeers(ctx, pageWalk{op: "user/beers/" + username}, username, sort)
+}
+
+// allBeers implements AllBeers, reporting progress for the input pageWalk.
+func (u *UserService) allBeers(ctx context.Context, w pageWalk, username string, sort Sort) ([]*Beer, error) {
+	var all []*Beer
+	err := u.client.walkPages(ctx, w, func(ctx context.Context) (int, bool, error) {
+		beers, _, err := u.beers(ctx, username, len(all), maxBeersLimit, sort, BeerFilter{})
+		if err != nil {
+			return 0, false, err
+		}
+
+		all = append(all, beers...)
+		return len(beers), len(beers) == maxBeersLimit, nil
+	})
+
+	return all, err
+}
+
+// allCheckins fetches all checkins from a checkins endpoint which are older
+// than those observed by cur, paging backwards through checkin IDs using the
+// max_id parameter, and reporting progress for the input pageWalk.  Any
+// parameters in q are sent with each request.
+//
+// Only checkins created within [start, end) are returned, where zero times
+// are unbounded.  Since checkins are returned newest first, no further pages
+// are fetched once a checkin created before start is reached.
+func (c *Client) allCheckins(ctx context.Context, w pageWalk, cur *Cursor, endpoint string, q url.Values, start time.Time, end time.Time) ([]*Checkin, error) {
+	var all []*Checkin
+
+	// A checkpoint may indicate that no older checkins remain
+	if _, _, ok := cur.Older(); !ok {
+		return nil, nil
+	}
+
+	err := c.walkPages(ctx, w, func(ctx context.Context) (int, bool, error) {
+		pq := url.Values{}
+		for k, v := range q {
+			pq[k] = v
+		}
+		pq.Set("limit", strconv.Itoa(maxCheckinsLimit))
+		if !cur.Empty() {
+			_, maxID, _ := cur.Older()
+			pq.Set("max_id", strconv.FormatInt(maxID, 10))
+		}
+
+		checkins, _, err := c.getCheckinsContext(ctx, endpoint, pq)
+		if err != nil {
+			return 0, false, err
+		}
+
+		var (
+			n, got  int
+			reached bool
+		)
+		for _, ch := range checkins {
+			if ch == nil {
+				continue
+			}
+			got++
+
+			if !start.IsZero() && ch.Created.Before(start) {
+				reached = true
+				continue
+			}
+			if !end.IsZero() && !ch.Created.Before(end) {
+				continue
+			}
+
+			all = append(all, ch)
+			n++
+		}
+		cur.Observe(checkins)
+
+		// The next page begins with the checkin preceding the oldest
+		// checkin observed so far
+		_, _, older := cur.Older()
+		return n, got == maxCheckinsLimit && older && !reached, nil
+	})
+
+	return all, err
+}
+
+// getCheckinsLimit fetches up to limit checkins from a checkins endpoint.  If
+// limit exceeds max, the maximum number of checkins returned by one call to
+// the endpoint, multiple pages are fetched by paging backwards through
+// checkin IDs, and the results are combined.  Any other parameters in q are
+// sent with each request.
+//
+// The returned HTTP response is that of the last page fetched.
+func (c *Client) getCheckinsLimit(endpoint string, q url.Values, limit int, max int) ([]*Checkin, *http.Response, error) {
+	if limit <= max {
+		return c.getCheckins(endpoint, q)
+	}
+
+	var (
+		all []*Checkin
+		res *http.Response
+		cur Cursor
+	)
+
+	for len(all) < limit {
+		n := limit - len(all)
+		if n > max {
+			n = max
+		}
+
+		pq := url.Values{}
+		for k, v := range q {
+			pq[k] = v
+		}
+		pq.Set("limit", strconv.Itoa(n))
+		if !cur.Empty() {
+			_, maxID, ok := cur.Older()
+			if !ok {
+				break
+			}
+			pq.Set("max_id", strconv.FormatInt(maxID, 10))
+		}
+
+		var checkins []*Checkin
+		var err error
+		checkins, res, err = c.getCheckins(endpoint, pq)
+		if err != nil {
+			return nil, res, err
+		}
+
+		var got int
+		for _, ch := range checkins {
+			if ch == nil {
+				continue
+			}
+
+			all = append(all, ch)
+			got++
+		}
+		cur.Observe(checkins)
+
+		// A short page indicates that no more checkins are available
+		if got < n {
+			break
+		}
+	}
+
+	return all, res, nil
+}
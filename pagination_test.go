@@ -0,0 +1,346 @@
+package untappd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClientUserAllCheckinsOK verifies that Client.User.AllCheckins pages
+// backwards through checkin IDs until no checkins remain.
+func TestClientUserAllCheckinsOK(t *testing.T) {
+	var maxIDs []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		maxID := r.URL.Query().Get("max_id")
+		maxIDs = append(maxIDs, maxID)
+
+		// Serve two full pages, and one partial page
+		start := 150
+		if maxID != "" {
+			start, _ = strconv.Atoi(maxID)
+		}
+		n := maxCheckinsLimit
+		if start <= maxCheckinsLimit {
+			n = 10
+		}
+
+		w.Write(checkinsPageJSON(start, n))
+	})
+	defer done()
+
+	checkins, err := c.User.AllCheckins(context.Background(), "mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 110 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 110)
+	}
+	if want := []string{"", "100", "50"}; strings.Join(maxIDs, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected max_id parameters: %v != %v", maxIDs, want)
+	}
+}
+
+// TestClientUserAllCheckinsDeadlineApproaching verifies that
+// Client.User.AllCheckins stops before a page which cannot complete in time,
+// and returns the checkins fetched so far.
+func TestClientUserAllCheckinsDeadlineApproaching(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+
+		start := 1000
+		if maxID := r.URL.Query().Get("max_id"); maxID != "" {
+			start, _ = strconv.Atoi(maxID)
+		}
+
+		w.Write(checkinsPageJSON(start, maxCheckinsLimit))
+	})
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*delay/2)
+	defer cancel()
+
+	checkins, err := c.User.AllCheckins(ctx, "mdlayher")
+	if err != ErrDeadlineApproaching {
+		t.Fatalf("unexpected error: %v != %v", err, ErrDeadlineApproaching)
+	}
+	if l := len(checkins); l != 2*maxCheckinsLimit {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 2*maxCheckinsLimit)
+	}
+}
+
+// TestClientUserCheckinsBetween verifies that Client.User.CheckinsBetween
+// returns only checkins within its time window, and stops paging once a
+// checkin older than the window is reached.
+func TestClientUserCheckinsBetween(t *testing.T) {
+	// One checkin per hour, with the newest checkin at ID 150
+	newest := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	created := func(id int) time.Time {
+		return newest.Add(-time.Duration(150-id) * time.Hour)
+	}
+
+	var pages int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		pages++
+
+		start := 150
+		if maxID := r.URL.Query().Get("max_id"); maxID != "" {
+			start, _ = strconv.Atoi(maxID)
+		}
+
+		items := make([]string, maxCheckinsLimit)
+		for i := range items {
+			id := start - i
+			items[i] = fmt.Sprintf(`{"checkin_id":%d,"created_at":%q}`, id, created(id).Format(time.RFC1123Z))
+		}
+
+		fmt.Fprintf(w, `{"response":{"checkins":{"count":%d,"items":[%s]}}}`, len(items), strings.Join(items, ","))
+	})
+	defer done()
+
+	checkins, err := c.User.CheckinsBetween(context.Background(), "mdlayher", created(80), created(140))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 60 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 60)
+	}
+	if first, last := checkins[0].ID, checkins[len(checkins)-1].ID; first != 139 || last != 80 {
+		t.Fatalf("unexpected checkin IDs: %d-%d != %d-%d", first, last, 139, 80)
+	}
+	if pages != 2 {
+		t.Fatalf("unexpected number of pages fetched: %d != %d", pages, 2)
+	}
+
+	// All checkins since the window start
+	pages = 0
+	checkins, err = c.User.CheckinsSince(context.Background(), "mdlayher", created(120))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(checkins); l != 31 || pages != 1 {
+		t.Fatalf("unexpected number of checkins and pages: %d, %d != %d, %d", l, pages, 31, 1)
+	}
+}
+
+// TestClientUserAllBeersOK verifies that Client.User.AllBeers pages through
+// beers using offsets until a partial page is returned.
+func TestClientUserAllBeersOK(t *testing.T) {
+	c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		n := maxBeersLimit
+		if r.URL.Query().Get("offset") != "0" {
+			n = 1
+		}
+
+		items := make([]string, n)
+		for i := range items {
+			items[i] = `{"beer":{"bid":1}}`
+		}
+
+		fmt.Fprintf(w, `{"response":{"beers":{"count":%d,"items":[%s]}}}`, n, strings.Join(items, ","))
+	})
+	defer done()
+
+	beers, err := c.User.AllBeers(context.Background(), "mdlayher", SortDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != maxBeersLimit+1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, maxBeersLimit+1)
+	}
+}
+
+// checkinsPageJSON generates a page of n checkins JSON, with descending IDs
+// beginning at start.
+func checkinsPageJSON(start int, n int) []byte {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"checkin_id":%d}`, start-i)
+	}
+
+	return []byte(fmt.Sprintf(`{"response":{"checkins":{"count":%d,"items":[%s]}}}`, n, strings.Join(items, ",")))
+}
+
+// TestClientBeerCheckinsChunked verifies that Client.Beer.CheckinsMinMaxIDLimit
+// splits a limit greater than the API's maximum into multiple requests.
+func TestClientBeerCheckinsChunked(t *testing.T) {
+	var params []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		params = append(params, q.Get("max_id")+":"+q.Get("limit"))
+
+		start, _ := strconv.Atoi(q.Get("max_id"))
+		n, _ := strconv.Atoi(q.Get("limit"))
+
+		// Only 60 checkins exist
+		if start-n < 40 {
+			n = start - 40
+		}
+
+		w.Write(checkinsPageJSON(start, n))
+	})
+	defer done()
+
+	checkins, _, err := c.Beer.CheckinsMinMaxIDLimit(1, 0, 100, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(checkins); l != 60 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 60)
+	}
+	if want := []string{"100:25", "75:25", "50:20"}; strings.Join(params, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected max_id and limit parameters: %v != %v", params, want)
+	}
+	for i, ch := range checkins {
+		if want := int64(100 - i); ch.ID != want {
+			t.Fatalf("unexpected checkin ID at index %d: %d != %d", i, ch.ID, want)
+		}
+	}
+}
+
+// TestClientUserAllCheckinsProgress verifies that Client.User.AllCheckins
+// reports progress after each page to a ProgressFunc.
+func TestClientUserAllCheckinsProgress(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimitRemaining, "42")
+
+		n := maxCheckinsLimit
+		if r.URL.Query().Get("max_id") != "" {
+			n = 5
+		}
+		w.Write(checkinsPageJSON(100, n))
+	})
+	defer done()
+
+	var reports []Progress
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		reports = append(reports, p)
+	})
+
+	if _, err := c.User.AllCheckins(ctx, "mdlayher"); err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(reports); l != 2 {
+		t.Fatalf("unexpected number of progress reports: %d != %d", l, 2)
+	}
+
+	p := reports[1]
+	if p.Operation != "user/checkins/mdlayher" || p.Pages != 2 || p.Items != 55 || !p.Done {
+		t.Fatalf("unexpected final progress: %+v", p)
+	}
+	if p.RateLimit.Remaining != 42 {
+		t.Fatalf("unexpected rate limit in progress: %+v", p.RateLimit)
+	}
+	if reports[0].Done {
+		t.Fatal("first progress report should not be done")
+	}
+}
+
+// TestProgressETA verifies that Progress estimates the time remaining based
+// on the rate at which items have been fetched.
+func TestProgressETA(t *testing.T) {
+	var tests = []struct {
+		desc string
+		p    Progress
+		eta  time.Duration
+	}{
+		{
+			desc: "unknown total",
+			p:    Progress{Items: 50},
+		},
+		{
+			desc: "half done",
+			p:    Progress{Items: 50, Total: 100},
+			eta:  10 * time.Second,
+		},
+		{
+			desc: "done",
+			p:    Progress{Items: 100, Total: 100, Done: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if eta := tt.p.eta(10 * time.Second); eta != tt.eta {
+				t.Fatalf("unexpected ETA: %v != %v", eta, tt.eta)
+			}
+		})
+	}
+}
+
+// TestClientUserAllCheckinsFromResume verifies that Client.User.AllCheckinsFrom
+// resumes from a persisted Cursor after an error.
+func TestClientUserAllCheckinsFromResume(t *testing.T) {
+	fail := true
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		start := 150
+		if maxID := r.URL.Query().Get("max_id"); maxID != "" {
+			start, _ = strconv.Atoi(maxID)
+
+			// Fail the second page once
+			if fail {
+				fail = false
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write(apiErrJSON)
+				return
+			}
+		}
+
+		n := maxCheckinsLimit
+		if start <= maxCheckinsLimit {
+			n = 10
+		}
+		w.Write(checkinsPageJSON(start, n))
+	})
+	defer done()
+
+	var cur Cursor
+	first, err := c.User.AllCheckinsFrom(context.Background(), "mdlayher", &cur)
+	if err == nil {
+		t.Fatal("expected an error for the second page")
+	}
+	if l := len(first); l != maxCheckinsLimit {
+		t.Fatalf("unexpected number of checkins before error: %d != %d", l, maxCheckinsLimit)
+	}
+
+	// Persist and restore the checkpoint
+	b, err := json.Marshal(cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"highest":150,"lowest":101}`; string(b) != want {
+		t.Fatalf("unexpected checkpoint JSON: %s != %s", b, want)
+	}
+
+	var restored Cursor
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+
+	rest, err := c.User.AllCheckinsFrom(context.Background(), "mdlayher", &restored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(first) + len(rest); l != 110 {
+		t.Fatalf("unexpected total number of checkins: %d != %d", l, 110)
+	}
+	if id := rest[0].ID; id != 100 {
+		t.Fatalf("unexpected first resumed checkin ID: %d != %d", id, 100)
+	}
+
+	// A checkpoint at the oldest checkin requires no further requests
+	if cs, err := c.User.AllCheckinsFrom(context.Background(), "mdlayher", &Cursor{Highest: 150, Lowest: 1}); err != nil || len(cs) != 0 {
+		t.Fatalf("unexpected result for exhausted checkpoint: %v, %v", cs, err)
+	}
+}
@@ -0,0 +1,557 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// Parquet physical types, converted types, field repetitions, and encodings
+// used by the Parquet writers, as defined by the Apache Parquet format.
+const (
+	parquetMagic = "PAR1"
+
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetUTF8            = 0
+	parquetTimestampMicros = 10
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetDataPage     = 0
+	parquetUncompressed = 0
+)
+
+// WriteCheckinsParquet writes the input checkins to w as an Apache Parquet
+// file, so that they may be loaded directly by tools such as DuckDB and
+// pandas.  Each checkin is one row, with the following columns:
+//
+//	checkin_id       INT64, never null
+//	created_at       TIMESTAMP (microseconds, UTC)
+//	comment          STRING
+//	rating           DOUBLE, null if the checkin was not rated
+//	user_name        STRING
+//	beer_id          INT64
+//	beer_name        STRING
+//	beer_style       STRING
+//	beer_abv         DOUBLE
+//	brewery_id       INT64
+//	brewery_name     STRING
+//	brewery_country  STRING
+//	venue_id         INT64, null if the checkin has no venue
+//	venue_name       STRING
+//	venue_city       STRING
+//	venue_state      STRING
+//	venue_country    STRING
+//	total_toasts     INT64
+//	total_comments   INT64
+//
+// Columns which are not marked otherwise are null when the information is
+// not available, such as empty strings.  The schema is stable: columns may
+// be added to the end in the future, but existing columns are never renamed,
+// reordered, or removed.  Nil checkins are skipped.
+func WriteCheckinsParquet(w io.Writer, checkins []*Checkin) error {
+	var (
+		id            = newParquetColumn("checkin_id", parquetInt64, false)
+		created       = newParquetColumn("created_at", parquetInt64, true).convert(parquetTimestampMicros)
+		comment       = newParquetColumn("comment", parquetByteArray, true).convert(parquetUTF8)
+		rating        = newParquetColumn("rating", parquetDouble, true)
+		userName      = newParquetColumn("user_name", parquetByteArray, true).convert(parquetUTF8)
+		beerID        = newParquetColumn("beer_id", parquetInt64, true)
+		beerName      = newParquetColumn("beer_name", parquetByteArray, true).convert(parquetUTF8)
+		beerStyle     = newParquetColumn("beer_style", parquetByteArray, true).convert(parquetUTF8)
+		beerABV       = newParquetColumn("beer_abv", parquetDouble, true)
+		breweryID     = newParquetColumn("brewery_id", parquetInt64, true)
+		breweryName   = newParquetColumn("brewery_name", parquetByteArray, true).convert(parquetUTF8)
+		country       = newParquetColumn("brewery_country", parquetByteArray, true).convert(parquetUTF8)
+		venueID       = newParquetColumn("venue_id", parquetInt64, true)
+		venueName     = newParquetColumn("venue_name", parquetByteArray, true).convert(parquetUTF8)
+		venueCity     = newParquetColumn("venue_city", parquetByteArray, true).convert(parquetUTF8)
+		venueState    = newParquetColumn("venue_state", parquetByteArray, true).convert(parquetUTF8)
+		venueCountry  = newParquetColumn("venue_country", parquetByteArray, true).convert(parquetUTF8)
+		totalToasts   = newParquetColumn("total_toasts", parquetInt64, true)
+		totalComments = newParquetColumn("total_comments", parquetInt64, true)
+	)
+
+	for _, c := range checkins {
+		if c == nil {
+			continue
+		}
+
+		id.int64(c.ID, true)
+		created.timestamp(c.Created)
+		comment.string(c.Comment)
+		rating.double(c.UserRating, c.UserRating > 0)
+
+		var u User
+		if c.User != nil {
+			u = *c.User
+		}
+		userName.string(u.UserName)
+
+		var beer Beer
+		if c.Beer != nil {
+			beer = *c.Beer
+		}
+		beerID.int64(beer.ID, c.Beer != nil)
+		beerName.string(beer.Name)
+		beerStyle.string(beer.Style)
+		beerABV.double(beer.ABV, c.Beer != nil)
+
+		b := c.Brewery
+		if b == nil {
+			b = beer.Brewery
+		}
+		var brewery Brewery
+		if b != nil {
+			brewery = *b
+		}
+		breweryID.int64(brewery.ID, b != nil)
+		breweryName.string(brewery.Name)
+		country.string(brewery.Country)
+
+		var venue Venue
+		if c.Venue != nil {
+			venue = *c.Venue
+		}
+		venueID.int64(venue.ID, c.Venue != nil)
+		venueName.string(venue.Name)
+		venueCity.string(venue.Location.City)
+		venueState.string(venue.Location.State)
+		venueCountry.string(venue.Location.Country)
+
+		totalToasts.int64(int64(c.TotalToasts), true)
+		totalComments.int64(int64(c.TotalComments), true)
+	}
+
+	return writeParquet(w, []*parquetColumn{
+		id, created, comment, rating, userName,
+		beerID, beerName, beerStyle, beerABV,
+		breweryID, breweryName, country,
+		venueID, venueName, venueCity, venueState, venueCountry,
+		totalToasts, totalComments,
+	})
+}
+
+// WriteBeersParquet writes the input beers to w as an Apache Parquet file, as
+// with WriteCheckinsParquet.  Each beer is one row, with the following
+// columns:
+//
+//	beer_id          INT64, never null
+//	beer_name        STRING
+//	beer_style       STRING
+//	beer_abv         DOUBLE
+//	beer_ibu         INT64
+//	brewery_id       INT64
+//	brewery_name     STRING
+//	brewery_country  STRING
+//	rating_score     DOUBLE
+//	rating_count     INT64
+//	user_rating      DOUBLE, null if the beer was not rated
+//	checkin_count    INT64
+//	first_had        TIMESTAMP (microseconds, UTC)
+//	recent_had       TIMESTAMP (microseconds, UTC)
+//	in_production    BOOLEAN
+//	homebrew         BOOLEAN
+//
+// The checkin_count, first_had, and recent_had columns are only available for
+// beers returned by methods such as UserService.AllBeers.  The schema is
+// stable, as with WriteCheckinsParquet.  Nil beers are skipped.
+func WriteBeersParquet(w io.Writer, beers []*Beer) error {
+	var (
+		id           = newParquetColumn("beer_id", parquetInt64, false)
+		name         = newParquetColumn("beer_name", parquetByteArray, true).convert(parquetUTF8)
+		style        = newParquetColumn("beer_style", parquetByteArray, true).convert(parquetUTF8)
+		abv          = newParquetColumn("beer_abv", parquetDouble, true)
+		ibu          = newParquetColumn("beer_ibu", parquetInt64, true)
+		breweryID    = newParquetColumn("brewery_id", parquetInt64, true)
+		breweryName  = newParquetColumn("brewery_name", parquetByteArray, true).convert(parquetUTF8)
+		country      = newParquetColumn("brewery_country", parquetByteArray, true).convert(parquetUTF8)
+		score        = newParquetColumn("rating_score", parquetDouble, true)
+		ratings      = newParquetColumn("rating_count", parquetInt64, true)
+		userRating   = newParquetColumn("user_rating", parquetDouble, true)
+		count        = newParquetColumn("checkin_count", parquetInt64, true)
+		firstHad     = newParquetColumn("first_had", parquetInt64, true).convert(parquetTimestampMicros)
+		recentHad    = newParquetColumn("recent_had", parquetInt64, true).convert(parquetTimestampMicros)
+		inProduction = newParquetColumn("in_production", parquetBoolean, true)
+		homebrew     = newParquetColumn("homebrew", parquetBoolean, true)
+	)
+
+	for _, b := range beers {
+		if b == nil {
+			continue
+		}
+
+		id.int64(b.ID, true)
+		name.string(b.Name)
+		style.string(b.Style)
+		abv.double(b.ABV, true)
+		ibu.int64(int64(b.IBU), true)
+
+		var brewery Brewery
+		if b.Brewery != nil {
+			brewery = *b.Brewery
+		}
+		breweryID.int64(brewery.ID, b.Brewery != nil)
+		breweryName.string(brewery.Name)
+		country.string(brewery.Country)
+
+		score.double(b.Rating.Score, true)
+		ratings.int64(int64(b.Rating.Count), true)
+		userRating.double(b.UserRating, b.UserRating > 0)
+		count.int64(int64(b.Count), true)
+		firstHad.timestamp(b.FirstHad)
+		recentHad.timestamp(b.RecentHad)
+		inProduction.bool(b.InProduction, true)
+		homebrew.bool(b.Homebrew, true)
+	}
+
+	return writeParquet(w, []*parquetColumn{
+		id, name, style, abv, ibu,
+		breweryID, breweryName, country,
+		score, ratings, userRating, count,
+		firstHad, recentHad, inProduction, homebrew,
+	})
+}
+
+// A parquetColumn accumulates the values of a single column of a Parquet
+// file, which are written as a single PLAIN encoded data page.
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32
+	optional  bool
+
+	// Whether each row has a value, the PLAIN encoded values of non-null
+	// rows, and for boolean columns, the values to be bit-packed.
+	valid  []bool
+	values bytes.Buffer
+	bools  []bool
+}
+
+// newParquetColumn creates a parquetColumn with the input name and physical
+// type, which permits null values if optional is true.
+func newParquetColumn(name string, typ int32, optional bool) *parquetColumn {
+	return &parquetColumn{
+		name:      name,
+		typ:       typ,
+		converted: -1,
+		optional:  optional,
+	}
+}
+
+// convert sets the converted type of a parquetColumn, such as UTF8 for
+// strings.
+func (c *parquetColumn) convert(converted int32) *parquetColumn {
+	c.converted = converted
+	return c
+}
+
+// int64 appends an INT64 value, or null if ok is false.
+func (c *parquetColumn) int64(v int64, ok bool) {
+	c.valid = append(c.valid, ok)
+	if ok {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		c.values.Write(b[:])
+	}
+}
+
+// double appends a DOUBLE value, or null if ok is false.
+func (c *parquetColumn) double(v float64, ok bool) {
+	c.valid = append(c.valid, ok)
+	if ok {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		c.values.Write(b[:])
+	}
+}
+
+// string appends a BYTE_ARRAY value, or null if s is empty.
+func (c *parquetColumn) string(s string) {
+	c.valid = append(c.valid, s != "")
+	if s != "" {
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], uint32(len(s)))
+		c.values.Write(b[:])
+		c.values.WriteString(s)
+	}
+}
+
+// timestamp appends a microsecond timestamp, or null if t is the zero time.
+func (c *parquetColumn) timestamp(t time.Time) {
+	c.int64(t.UnixNano()/int64(time.Microsecond), !t.IsZero())
+}
+
+// bool appends a BOOLEAN value, or null if ok is false.
+func (c *parquetColumn) bool(v bool, ok bool) {
+	c.valid = append(c.valid, ok)
+	if ok {
+		c.bools = append(c.bools, v)
+	}
+}
+
+// page returns the contents of a data page containing each of the column's
+// values.
+func (c *parquetColumn) page() []byte {
+	var buf bytes.Buffer
+
+	// Definition levels of optional columns are encoded as runs of the
+	// RLE/bit-packing hybrid encoding, prefixed by their length
+	if c.optional {
+		var levels thriftWriter
+		for i := 0; i < len(c.valid); {
+			j := i
+			for j < len(c.valid) && c.valid[j] == c.valid[i] {
+				j++
+			}
+
+			levels.uvarint(uint64(j-i) << 1)
+			if c.valid[i] {
+				levels.buf.WriteByte(1)
+			} else {
+				levels.buf.WriteByte(0)
+			}
+			i = j
+		}
+
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], uint32(levels.buf.Len()))
+		buf.Write(b[:])
+		buf.Write(levels.buf.Bytes())
+	}
+
+	if c.typ == parquetBoolean {
+		packed := make([]byte, (len(c.bools)+7)/8)
+		for i, v := range c.bools {
+			if v {
+				packed[i/8] |= 1 << uint(i%8)
+			}
+		}
+		buf.Write(packed)
+	}
+
+	buf.Write(c.values.Bytes())
+	return buf.Bytes()
+}
+
+// writeParquet writes a Parquet file to w containing the input columns, which
+// must each have the same number of rows, as a single row group.
+func writeParquet(w io.Writer, cols []*parquetColumn) error {
+	var rows int
+	if len(cols) > 0 {
+		rows = len(cols[0].valid)
+	}
+
+	var (
+		buf     bytes.Buffer
+		offsets = make([]int64, len(cols))
+		sizes   = make([]int64, len(cols))
+	)
+	buf.WriteString(parquetMagic)
+
+	for i, c := range cols {
+		data := c.page()
+
+		var h thriftWriter
+		h.begin()
+		h.i32(1, parquetDataPage)
+		h.i32(2, int32(len(data)))
+		h.i32(3, int32(len(data)))
+		h.beginStruct(5)
+		h.i32(1, int32(rows))
+		h.i32(2, parquetPlain)
+		h.i32(3, parquetRLE)
+		h.i32(4, parquetRLE)
+		h.end()
+		h.end()
+
+		offsets[i] = int64(buf.Len())
+		sizes[i] = int64(h.buf.Len() + len(data))
+		buf.Write(h.buf.Bytes())
+		buf.Write(data)
+	}
+
+	// The file metadata describes the schema and the location of each
+	// column chunk
+	var m thriftWriter
+	m.begin()
+	m.i32(1, 1)
+	m.list(2, thriftStruct, len(cols)+1)
+	m.begin()
+	m.binary(4, "schema")
+	m.i32(5, int32(len(cols)))
+	m.end()
+	for _, c := range cols {
+		rep := int32(parquetRequired)
+		if c.optional {
+			rep = parquetOptional
+		}
+
+		m.begin()
+		m.i32(1, c.typ)
+		m.i32(3, rep)
+		m.binary(4, c.name)
+		if c.converted >= 0 {
+			m.i32(6, c.converted)
+		}
+		m.end()
+	}
+	m.i64(3, int64(rows))
+
+	// Empty files contain no row groups
+	var total int64
+	for _, s := range sizes {
+		total += s
+	}
+	if rows == 0 {
+		m.list(4, thriftStruct, 0)
+	} else {
+		m.list(4, thriftStruct, 1)
+		m.begin()
+		m.list(1, thriftStruct, len(cols))
+		for i, c := range cols {
+			m.begin()
+			m.i64(2, offsets[i])
+			m.beginStruct(3)
+			m.i32(1, c.typ)
+			m.list(2, thriftI32, 2)
+			m.listI32(parquetPlain)
+			m.listI32(parquetRLE)
+			m.list(3, thriftBinary, 1)
+			m.listBinary(c.name)
+			m.i32(4, parquetUncompressed)
+			m.i64(5, int64(rows))
+			m.i64(6, sizes[i])
+			m.i64(7, sizes[i])
+			m.i64(9, offsets[i])
+			m.end()
+			m.end()
+		}
+		m.i64(2, total)
+		m.i64(3, int64(rows))
+		m.end()
+	}
+	m.binary(6, untappdUserAgent)
+	m.end()
+
+	buf.Write(m.buf.Bytes())
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(m.buf.Len()))
+	buf.Write(b[:])
+	buf.WriteString(parquetMagic)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Thrift compact protocol types used by thriftWriter.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// A thriftWriter encodes structs using the Thrift compact protocol, as used
+// by Parquet file metadata.
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	// ID of the last field written in the current struct, and of each
+	// enclosing struct
+	id    int16
+	stack []int16
+}
+
+// begin begins a struct, such as an element of a list.
+func (t *thriftWriter) begin() {
+	t.stack = append(t.stack, t.id)
+	t.id = 0
+}
+
+// beginStruct begins a struct field with the input ID.
+func (t *thriftWriter) beginStruct(id int16) {
+	t.field(id, thriftStruct)
+	t.begin()
+}
+
+// end ends the current struct.
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	t.id = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// field writes the header of a field with the input ID and type.
+func (t *thriftWriter) field(id int16, typ byte) {
+	if d := id - t.id; d > 0 && d <= 15 {
+		t.buf.WriteByte(byte(d)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	t.id = id
+}
+
+// i32 writes an i32 field.
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(int64(v))
+}
+
+// i64 writes an i64 field.
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(v)
+}
+
+// binary writes a binary field.
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.listBinary(s)
+}
+
+// list writes the header of a list field of n elements of the input type.
+func (t *thriftWriter) list(id int16, typ byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | typ)
+		return
+	}
+
+	t.buf.WriteByte(0xf0 | typ)
+	t.uvarint(uint64(n))
+}
+
+// listI32 writes an i32 list element.
+func (t *thriftWriter) listI32(v int32) {
+	t.varint(int64(v))
+}
+
+// listBinary writes a binary list element.
+func (t *thriftWriter) listBinary(s string) {
+	t.uvarint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+// varint writes a zigzag encoded integer.
+func (t *thriftWriter) varint(v int64) {
+	t.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+// uvarint writes an unsigned variable-length integer.
+func (t *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	t.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
@@ -0,0 +1,214 @@
+package untappd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWriteCheckinsParquet verifies that WriteCheckinsParquet produces a
+// Parquet file with the documented schema and one row per checkin.
+func TestWriteCheckinsParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCheckinsParquet(&buf, parquetTestCheckins()); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := parquetMetadata(t, buf.Bytes())
+	if rows := meta[3].(int64); rows != 2 {
+		t.Fatalf("unexpected number of rows: %d != %d", rows, 2)
+	}
+
+	schema := meta[2].([]interface{})
+	want := []string{
+		"schema", "checkin_id", "created_at", "comment", "rating", "user_name",
+		"beer_id", "beer_name", "beer_style", "beer_abv",
+		"brewery_id", "brewery_name", "brewery_country",
+		"venue_id", "venue_name", "venue_city", "venue_state", "venue_country",
+		"total_toasts", "total_comments",
+	}
+	if l := len(schema); l != len(want) {
+		t.Fatalf("unexpected number of schema elements: %d != %d", l, len(want))
+	}
+	for i, name := range want {
+		if n := string(schema[i].(map[int16]interface{})[4].([]byte)); n != name {
+			t.Fatalf("unexpected schema element %d: %q != %q", i, n, name)
+		}
+	}
+
+	for _, s := range []string{"Black Note Stout", "Bell's Brewery", "Kalamazoo"} {
+		if !bytes.Contains(buf.Bytes(), []byte(s)) {
+			t.Fatalf("expected file to contain %q", s)
+		}
+	}
+}
+
+// TestWriteCheckinsParquetGolden verifies that WriteCheckinsParquet produces
+// the same bytes as a golden file, so that changes to the output are caught
+// even when parquetMetadata accepts them.  Whenever the golden file is
+// regenerated, it must be read using a reference Parquet implementation,
+// such as with:
+//
+//	python3 -c 'import pyarrow.parquet as pq; print(pq.read_table("testdata/checkins.parquet"))'
+func TestWriteCheckinsParquetGolden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCheckinsParquet(&buf, parquetTestCheckins()); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ioutil.ReadFile(filepath.Join("testdata", "checkins.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("output does not match golden file: %d bytes != %d bytes", buf.Len(), len(want))
+	}
+}
+
+// parquetTestCheckins returns the checkins written by the tests of
+// WriteCheckinsParquet.
+func parquetTestCheckins() []*Checkin {
+	return []*Checkin{
+		{
+			ID:         2,
+			Created:    time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC),
+			Comment:    "Tasty",
+			UserRating: 4.25,
+			User:       &User{UserName: "mdlayher"},
+			Beer:       &Beer{ID: 10, Name: "Black Note Stout", Style: "Stout - Imperial / Double", ABV: 11.2},
+			Brewery:    &Brewery{ID: 20, Name: "Bell's Brewery", Country: "United States"},
+			Venue:      &Venue{ID: 30, Name: "Eccentric Cafe", Location: VenueLocation{City: "Kalamazoo", State: "MI"}},
+		},
+		nil,
+		{
+			ID:          1,
+			TotalToasts: 3,
+		},
+	}
+}
+
+// TestWriteBeersParquetEmpty verifies that WriteBeersParquet produces a
+// valid Parquet file with no row groups when no beers are written.
+func TestWriteBeersParquetEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBeersParquet(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := parquetMetadata(t, buf.Bytes())
+	if rows := meta[3].(int64); rows != 0 {
+		t.Fatalf("unexpected number of rows: %d != %d", rows, 0)
+	}
+	if l := len(meta[4].([]interface{})); l != 0 {
+		t.Fatalf("unexpected number of row groups: %d != %d", l, 0)
+	}
+	if l := len(meta[2].([]interface{})); l != 17 {
+		t.Fatalf("unexpected number of schema elements: %d != %d", l, 17)
+	}
+}
+
+// parquetMetadata validates the framing of a Parquet file, and decodes its
+// file metadata into a map of field IDs to values.
+func parquetMetadata(t *testing.T, b []byte) map[int16]interface{} {
+	if len(b) < 12 || string(b[:4]) != parquetMagic || string(b[len(b)-4:]) != parquetMagic {
+		t.Fatal("missing Parquet magic bytes")
+	}
+
+	n := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	r := &thriftReader{b: b[len(b)-8-n : len(b)-8]}
+	meta := r.structure()
+	if r.err || len(r.b) != 0 {
+		t.Fatal("malformed Parquet file metadata")
+	}
+
+	return meta
+}
+
+// A thriftReader decodes the Thrift compact protocol structs produced by a
+// thriftWriter, for tests.
+type thriftReader struct {
+	b   []byte
+	err bool
+}
+
+func (r *thriftReader) byte() byte {
+	if len(r.b) == 0 {
+		r.err = true
+		return 0
+	}
+
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.err = true
+		return 0
+	}
+
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		if n > len(r.b) {
+			r.err = true
+			return nil
+		}
+		v := r.b[:n]
+		r.b = r.b[n:]
+		return v
+	case thriftList:
+		h := r.byte()
+		n := int(h >> 4)
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		vs := make([]interface{}, 0, n)
+		for i := 0; i < n && !r.err; i++ {
+			vs = append(vs, r.value(h&0x0f))
+		}
+		return vs
+	case thriftStruct:
+		return r.structure()
+	}
+
+	r.err = true
+	return nil
+}
+
+func (r *thriftReader) structure() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var id int16
+	for !r.err {
+		h := r.byte()
+		if h == 0 {
+			break
+		}
+
+		if d := int16(h >> 4); d != 0 {
+			id += d
+		} else {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(h & 0x0f)
+	}
+
+	return fields
+}
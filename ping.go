@@ -0,0 +1,84 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// pingEndpoint is the API endpoint requested by Client.Ping.  Compact beer
+// information is among the cheapest responses the Untappd APIv4 produces,
+// and is available to both client ID and access token credentials.
+const pingEndpoint = "beer/info/1"
+
+// A PingResult reports the health of the Untappd APIv4, as observed by
+// Client.Ping.
+type PingResult struct {
+	// Reachable reports whether the Untappd APIv4 responded, and was not
+	// failing with a server error.
+	Reachable bool
+
+	// Authenticated reports whether the Untappd APIv4 accepted the Client's
+	// credentials.  It is false if the API is not reachable.
+	Authenticated bool
+
+	// The most recent rate limit information reported for the Client's
+	// credentials, including any reported in response to the ping.
+	RateLimit RateLimit
+
+	// Time taken to receive the response, or to fail.
+	Latency time.Duration
+}
+
+// bypassCacheKey is the context key used to indicate that a request must not
+// be served from a Client's response cache.
+type bypassCacheKey struct{}
+
+// bypassCache reports whether the input context indicates that a request must
+// not be served from a Client's response cache.
+func bypassCache(ctx context.Context) bool {
+	_, ok := ctx.Value(bypassCacheKey{}).(struct{})
+	return ok
+}
+
+// Ping performs the cheapest available request to the Untappd APIv4, and
+// reports whether the API is reachable, whether the Client's credentials are
+// valid, and the current rate limit.  It is intended for use in readiness
+// probes of services which depend on the Untappd APIv4.
+//
+// The request is never served from the Client's response cache.  A
+// PingResult is always returned, and the error is nil only if the API is
+// reachable and the credentials are valid.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	ctx = context.WithValue(ctx, bypassCacheKey{}, struct{}{})
+
+	q := url.Values{"compact": []string{"true"}}
+
+	start := c.clock().Now()
+	_, err := c.requestContext(ctx, "GET", pingEndpoint, nil, q, nil)
+	pr := &PingResult{
+		Latency: c.clock().Now().Sub(start),
+	}
+
+	var apiErr *Error
+	switch {
+	case err == nil:
+		pr.Reachable, pr.Authenticated = true, true
+	case errors.As(err, &apiErr):
+		auth := apiErr.Code != http.StatusUnauthorized && apiErr.Type != "invalid_auth"
+
+		// Authentication failures are reported by the Untappd APIv4 with
+		// server error codes, but the API is nonetheless serving requests
+		pr.Reachable = !auth || apiErr.Code < http.StatusInternalServerError
+		pr.Authenticated = auth && pr.Reachable
+		if pr.Authenticated {
+			// Such as a missing beer, which does not affect health
+			err = nil
+		}
+	}
+
+	pr.RateLimit = c.RateLimit()
+	return pr, err
+}
@@ -0,0 +1,117 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestClientPing verifies that Client.Ping reports the health of the
+// Untappd APIv4 for a variety of responses.
+func TestClientPing(t *testing.T) {
+	var tests = []struct {
+		desc  string
+		code  int
+		body  []byte
+		reach bool
+		auth  bool
+		ok    bool
+	}{
+		{
+			desc:  "OK",
+			code:  http.StatusOK,
+			body:  blackNoteBeerJSON,
+			reach: true,
+			auth:  true,
+			ok:    true,
+		},
+		{
+			desc:  "invalid credentials",
+			code:  http.StatusInternalServerError,
+			body:  apiErrJSON,
+			reach: true,
+		},
+		{
+			desc:  "missing beer",
+			code:  http.StatusNotFound,
+			body:  []byte(`{"meta":{"code":404,"error_detail":"Beer not found","error_type":"invalid_param"}}`),
+			reach: true,
+			auth:  true,
+			ok:    true,
+		},
+		{
+			desc: "server error",
+			code: http.StatusInternalServerError,
+			body: []byte(`{"meta":{"code":500,"error_detail":"Internal error","error_type":"server_error"}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				if p := r.URL.Path; p != "/v4/beer/info/1/" {
+					t.Fatalf("unexpected path: %q", p)
+				}
+				assertParameters(t, r, url.Values{"compact": []string{"true"}})
+
+				w.Header().Set(headerRateLimitLimit, "100")
+				w.Header().Set(headerRateLimitRemaining, "42")
+				w.WriteHeader(tt.code)
+				w.Write(tt.body)
+			})
+			defer done()
+
+			pr, err := c.Ping(context.Background())
+			if ok := err == nil; ok != tt.ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pr.Reachable != tt.reach || pr.Authenticated != tt.auth {
+				t.Fatalf("unexpected result: %+v", pr)
+			}
+			if r := pr.RateLimit.Remaining; r != 42 {
+				t.Fatalf("unexpected remaining requests: %d != %d", r, 42)
+			}
+		})
+	}
+}
+
+// TestClientPingBypassesCache verifies that Client.Ping always performs a
+// request, even if responses are cached.
+func TestClientPingBypassesCache(t *testing.T) {
+	var n int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		n++
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithPolicy(ServicePolicy{CacheTTL: time.Hour}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Ping(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n != 2 {
+		t.Fatalf("unexpected number of requests: %d != %d", n, 2)
+	}
+}
+
+// TestClientPingUnreachable verifies that Client.Ping reports an error when
+// the Untappd APIv4 cannot be reached.
+func TestClientPingUnreachable(t *testing.T) {
+	c, done := testClient(t, nil)
+	done()
+
+	pr, err := c.Ping(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) || pr.Reachable || pr.Authenticated {
+		t.Fatalf("unexpected result: %+v, %v", pr, err)
+	}
+}
@@ -0,0 +1,385 @@
+package untappd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRetryWait is the time waited before the first retry of a
+	// request, if no other wait is specified by a ServicePolicy.
+	defaultRetryWait = 1 * time.Second
+
+	// maxCacheEntries is the maximum number of responses stored in a
+	// Client's response cache.
+	maxCacheEntries = 1024
+)
+
+// A Service identifies a group of related Untappd APIv4 endpoints, so that
+// a ServicePolicy may be applied to them.
+type Service string
+
+// Service constants which correspond to each of the services of a Client.
+const (
+	ServiceAuth    Service = "auth"
+	ServiceBeer    Service = "beer"
+	ServiceBrewery Service = "brewery"
+	ServiceLocal   Service = "local"
+	ServiceUser    Service = "user"
+	ServiceVenue   Service = "venue"
+)
+
+// serviceFor returns the Service which handles the input API endpoint.
+func serviceFor(endpoint string) Service {
+	first := endpoint
+	if i := strings.IndexByte(endpoint, '/'); i != -1 {
+		first = endpoint[:i]
+	}
+
+	switch first {
+	case "checkin", "notifications":
+		return ServiceAuth
+	case "thepub":
+		return ServiceLocal
+	case "search":
+		// Search endpoints belong to the service of the object searched,
+		// e.g. "search/beer"
+		return Service(strings.TrimPrefix(endpoint, "search/"))
+	default:
+		return Service(first)
+	}
+}
+
+// A ServicePolicy specifies how a Client performs requests for a Service.
+// Static metadata, such as beer information, can typically be cached much
+// more aggressively than activity feeds.  The zero value performs each
+// request once, with no timeout or caching.
+type ServicePolicy struct {
+	// Maximum duration of each request, including any retries.  If zero,
+	// no timeout is applied beyond that of the request's context.
+	Timeout time.Duration
+
+	// Number of times a GET request is retried after a network error, an
+	// HTTP 429 response, or an HTTP 502, 503, or 504 response.  RetryWait
+	// is the time waited before the first retry, which doubles after each
+	// retry.  If RetryWait is zero, one second is used.
+	Retries   int
+	RetryWait time.Duration
+
+	// Duration for which successful GET responses are cached in memory and
+	// reused.  If zero, responses are not cached, unless CacheHeaders is
+	// set.
+	CacheTTL time.Duration
+
+	// CacheHeaders enables caching according to the Cache-Control and
+	// Expires headers of each response, as a private cache would under
+	// RFC 9111.  Responses are cached for the freshness lifetime permitted
+	// by the server, less their Age, and responses marked no-store or
+	// no-cache are never cached.  If CacheTTL is also set, it overrides the
+	// lifetime of each response the server permits to be cached.
+	CacheHeaders bool
+}
+
+// WithPolicy sets the ServicePolicy used for all services which do not have
+// a policy set using WithServicePolicy.
+func WithPolicy(p ServicePolicy) ClientOption {
+	return func(c *clientConfig) error {
+		c.defaultPolicy = p
+		return nil
+	}
+}
+
+// WithServicePolicy sets the ServicePolicy used for requests to a single
+// Service, overriding any policy set using WithPolicy.
+func WithServicePolicy(s Service, p ServicePolicy) ClientOption {
+	return func(c *clientConfig) error {
+		// Copy on write, so that clones do not modify the original
+		policies := make(map[Service]ServicePolicy, len(c.policies)+1)
+		for k, v := range c.policies {
+			policies[k] = v
+		}
+		policies[s] = p
+
+		c.policies = policies
+		return nil
+	}
+}
+
+// policy returns the ServicePolicy for the input API endpoint.
+func (cfg *clientConfig) policy(endpoint string) ServicePolicy {
+	if p, ok := cfg.policies[serviceFor(endpoint)]; ok {
+		return p
+	}
+
+	return cfg.defaultPolicy
+}
+
+// do performs an HTTP request using the Client's http.Client, retrying GET
+// requests to the input endpoint according to the input ServicePolicy.
+func (c *Client) do(req *http.Request, endpoint string, p ServicePolicy) (*http.Response, error) {
+	wait := p.RetryWait
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+
+	start := c.clock().Now()
+	for attempt := 0; ; attempt++ {
+		res, err := c.client.Do(req)
+		if attempt >= p.Retries || req.Method != "GET" || !retryable(res, err) {
+			c.observeRequest(req, endpoint, start, attempt, res, err)
+			return res, err
+		}
+
+		// Discard the failed response before trying again
+		if res != nil {
+			res.Body.Close()
+		}
+		c.updateRateLimitFrom(res)
+
+		e := WaitEvent{
+			Reason:   WaitRetry,
+			Endpoint: endpoint,
+			Attempt:  attempt + 1,
+			Err:      err,
+		}
+		if res != nil {
+			e.StatusCode = res.StatusCode
+		}
+		if h := c.cfg.stats; h != nil {
+			h.ObserveRetry(req.Context(), RetryStats{
+				Service:    serviceFor(endpoint),
+				Endpoint:   endpoint,
+				Attempt:    e.Attempt,
+				StatusCode: e.StatusCode,
+				Err:        err,
+				Wait:       wait,
+			})
+		}
+		if err := c.wait(req.Context(), e, c.clock().Now().Add(wait)); err != nil {
+			c.observeRequest(req, endpoint, start, attempt, nil, err)
+			return nil, err
+		}
+		wait *= 2
+	}
+}
+
+// observeRequest reports a request which was sent, along with its final
+// response or error, to the Client's StatsHandler, if any.
+func (c *Client) observeRequest(req *http.Request, endpoint string, start time.Time, retries int, res *http.Response, err error) {
+	h := c.cfg.stats
+	if h == nil {
+		return
+	}
+
+	s := RequestStats{
+		Service:  serviceFor(endpoint),
+		Endpoint: endpoint,
+		Method:   req.Method,
+		Err:      err,
+		Duration: c.clock().Now().Sub(start),
+		Retries:  retries,
+	}
+	if res != nil {
+		s.StatusCode = res.StatusCode
+	}
+
+	h.ObserveRequest(req.Context(), s)
+}
+
+// retryable reports whether a request which produced the input response and
+// error should be retried.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		// Do not retry requests which were canceled by the caller
+		return !isContextError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isContextError reports whether err was caused by context cancelation or
+// deadline expiry.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// updateRateLimitFrom updates rate limit information from a response which
+// may be nil.  Responses to requests made with a token from WithToken are
+// ignored.
+func (c *Client) updateRateLimitFrom(res *http.Response) {
+	if res == nil {
+		return
+	}
+	if res.Request != nil {
+		if _, ok := tokenFromContext(res.Request.Context()); ok {
+			return
+		}
+	}
+
+	c.updateRateLimit(res.Header)
+}
+
+// cacheLifetime returns the duration for which a response with the input
+// headers may be cached, relative to the input current time.  It returns false
+// if the response must not be cached, and zero if the response permits caching
+// but specifies no freshness lifetime.
+func cacheLifetime(h http.Header, now time.Time) (time.Duration, bool) {
+	var (
+		maxAge    time.Duration
+		hasMaxAge bool
+	)
+	for _, line := range h.Values("Cache-Control") {
+		for _, d := range strings.Split(line, ",") {
+			name, value := d, ""
+			if i := strings.IndexByte(d, '='); i != -1 {
+				name, value = d[:i], strings.Trim(strings.TrimSpace(d[i+1:]), `"`)
+			}
+
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "no-store", "no-cache":
+				return 0, false
+			case "max-age":
+				// Shared cache directives such as s-maxage do not apply
+				// to a Client
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || n < 0 {
+					// Invalid freshness information means the response
+					// is stale
+					return 0, false
+				}
+				maxAge, hasMaxAge = time.Duration(n)*time.Second, true
+			}
+		}
+	}
+
+	lifetime := maxAge
+	if !hasMaxAge {
+		v := h.Get("Expires")
+		if v == "" {
+			return 0, true
+		}
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return 0, false
+		}
+
+		// Expires is relative to the server's clock, if it reports one
+		date := now
+		if d, err := http.ParseTime(h.Get("Date")); err == nil {
+			date = d
+		}
+		lifetime = expires.Sub(date)
+	}
+
+	if age, err := strconv.ParseInt(h.Get("Age"), 10, 64); err == nil && age > 0 {
+		lifetime -= time.Duration(age) * time.Second
+	}
+	if lifetime <= 0 {
+		return 0, false
+	}
+
+	return lifetime, true
+}
+
+// cacheTTL returns the duration for which a response with the input headers
+// is cached under a ServicePolicy, or zero if it is not cached.
+func (p ServicePolicy) cacheTTL(h http.Header, now time.Time) time.Duration {
+	if !p.CacheHeaders {
+		return p.CacheTTL
+	}
+
+	lifetime, ok := cacheLifetime(h, now)
+	if !ok {
+		return 0
+	}
+	if p.CacheTTL > 0 {
+		return p.CacheTTL
+	}
+
+	return lifetime
+}
+
+// A responseCache stores successful responses in memory, keyed by request
+// URL.  It is safe for concurrent use, and may be shared by clones.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// A cacheEntry is a response stored in a responseCache.
+type cacheEntry struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// newResponseCache creates an empty responseCache.
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cacheEntry)}
+}
+
+// get returns a fresh response for the input key, if one is cached.
+func (rc *responseCache) get(key string, req *http.Request, now time.Time) (*http.Response, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	e, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}, true
+}
+
+// put stores a response body for the input key until the input expiry time.
+// Expired entries are evicted relative to the input current time.
+func (rc *responseCache) put(key string, header http.Header, body []byte, now time.Time, expires time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if len(rc.entries) >= maxCacheEntries {
+		// Make room by removing expired entries, and give up if the
+		// cache is still full
+		for k, e := range rc.entries {
+			if !now.Before(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+		if len(rc.entries) >= maxCacheEntries {
+			return
+		}
+	}
+
+	rc.entries[key] = cacheEntry{
+		header:  header.Clone(),
+		body:    body,
+		expires: expires,
+	}
+}
@@ -0,0 +1,241 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test_serviceFor verifies that API endpoints are mapped to the correct
+// Service.
+func Test_serviceFor(t *testing.T) {
+	var tests = []struct {
+		endpoint string
+		service  Service
+	}{
+		{"beer/info/1", ServiceBeer},
+		{"search/beer", ServiceBeer},
+		{"search/brewery", ServiceBrewery},
+		{"brewery/checkins/1", ServiceBrewery},
+		{"checkin/recent", ServiceAuth},
+		{"checkin/add", ServiceAuth},
+		{"notifications", ServiceAuth},
+		{"thepub/local", ServiceLocal},
+		{"user/checkins/foo", ServiceUser},
+		{"venue/info/1", ServiceVenue},
+	}
+
+	for _, tt := range tests {
+		if s := serviceFor(tt.endpoint); s != tt.service {
+			t.Fatalf("unexpected service for %q: %q != %q", tt.endpoint, s, tt.service)
+		}
+	}
+}
+
+// TestServicePolicyCache verifies that responses are cached only for
+// services whose policy permits it.
+func TestServicePolicyCache(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	applyTestOptions(t, c,
+		WithPolicy(ServicePolicy{CacheTTL: time.Hour}),
+		WithServicePolicy(ServiceUser, ServicePolicy{}),
+	)
+
+	for i := 0; i < 3; i++ {
+		b, _, err := c.Beer.Info(1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Name != "Black Note Stout" {
+			t.Fatalf("unexpected cached beer name: %q", b.Name)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("unexpected number of requests with caching: %d != %d", requests, 1)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.request("GET", "user/info/foo", nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 3 {
+		t.Fatalf("unexpected number of requests without caching: %d != %d", requests, 3)
+	}
+}
+
+// Test_cacheLifetime verifies that the freshness lifetime of a response is
+// determined from its Cache-Control, Expires, and Age headers.
+func Test_cacheLifetime(t *testing.T) {
+	now := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		desc     string
+		header   http.Header
+		lifetime time.Duration
+		ok       bool
+	}{
+		{
+			desc:   "no headers",
+			header: http.Header{},
+			ok:     true,
+		},
+		{
+			desc:     "max-age",
+			header:   http.Header{"Cache-Control": {"public, max-age=60"}},
+			lifetime: time.Minute,
+			ok:       true,
+		},
+		{
+			desc: "max-age less age",
+			header: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Age":           {"20"},
+			},
+			lifetime: 40 * time.Second,
+			ok:       true,
+		},
+		{
+			desc:   "no-store",
+			header: http.Header{"Cache-Control": {"max-age=60, no-store"}},
+		},
+		{
+			desc:   "no-cache",
+			header: http.Header{"Cache-Control": {"No-Cache"}},
+		},
+		{
+			desc:   "invalid max-age",
+			header: http.Header{"Cache-Control": {"max-age=foo"}},
+		},
+		{
+			desc: "expires relative to date",
+			header: http.Header{
+				"Date":    {"Tue, 01 Mar 2016 11:00:00 GMT"},
+				"Expires": {"Tue, 01 Mar 2016 11:05:00 GMT"},
+			},
+			lifetime: 5 * time.Minute,
+			ok:       true,
+		},
+		{
+			desc: "max-age overrides expires",
+			header: http.Header{
+				"Cache-Control": {"max-age=10"},
+				"Expires":       {"Tue, 01 Mar 2016 13:00:00 GMT"},
+			},
+			lifetime: 10 * time.Second,
+			ok:       true,
+		},
+		{
+			desc:   "expired",
+			header: http.Header{"Expires": {"0"}},
+		},
+	}
+
+	for _, tt := range tests {
+		lifetime, ok := cacheLifetime(tt.header, now)
+		if lifetime != tt.lifetime || ok != tt.ok {
+			t.Fatalf("[%s] unexpected lifetime: %v, %v != %v, %v",
+				tt.desc, lifetime, ok, tt.lifetime, tt.ok)
+		}
+	}
+}
+
+// TestServicePolicyCacheHeaders verifies that responses are cached according
+// to their Cache-Control headers when CacheHeaders is set.
+func TestServicePolicyCacheHeaders(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/v4/beer/info/2/" {
+			w.Header().Set("Cache-Control", "no-store")
+		} else {
+			w.Header().Set("Cache-Control", "max-age=3600")
+		}
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithPolicy(ServicePolicy{CacheHeaders: true}))
+
+	for _, id := range []int64{1, 1, 2, 2} {
+		if _, _, err := c.Beer.Info(id, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 3 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 3)
+	}
+}
+
+// TestServicePolicyRetries verifies that GET requests are retried after
+// transient failures, up to the configured number of retries.
+func TestServicePolicyRetries(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write(apiErrJSON)
+			return
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithServicePolicy(ServiceBeer, ServicePolicy{
+		Retries:   1,
+		RetryWait: time.Millisecond,
+	}))
+
+	var uerr *Error
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); !errors.As(err, &uerr) {
+		t.Fatalf("unexpected error after exhausting retries: %v", err)
+	}
+
+	requests = 1
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Fatalf("unexpected number of requests: %d != %d", requests, 3)
+	}
+}
+
+// TestServicePolicyTimeout verifies that requests are canceled once the
+// configured timeout elapses.
+func TestServicePolicyTimeout(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithServicePolicy(ServiceVenue, ServicePolicy{
+		Timeout: 10 * time.Millisecond,
+	}))
+
+	if _, err := c.request("GET", "venue/info/1", nil, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// applyTestOptions applies ClientOptions to a Client created by testClient.
+func applyTestOptions(t *testing.T, c *Client, opts ...ClientOption) {
+	var cfg clientConfig
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cfg.apply(c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package untappd
+
+import (
+	"context"
+	"time"
+)
+
+// Progress reports the progress of a multi-page operation, such as
+// UserService.AllCheckins.
+type Progress struct {
+	// Operation identifies the operation, such as "user/checkins/mdlayher".
+	Operation string
+
+	// Number of pages and items fetched so far.
+	Pages int
+	Items int
+
+	// Expected total number of items, or zero if unknown.
+	Total int
+
+	// Done reports whether the last page has been fetched.
+	Done bool
+
+	// Rate limit information following the most recent page.
+	RateLimit RateLimit
+
+	// Estimated time remaining until the operation completes, based on the
+	// rate at which items have been fetched so far.  ETA is zero if Total
+	// is unknown.
+	ETA time.Duration
+}
+
+// eta estimates the time remaining for an operation which has been running
+// for the input duration.
+func (p Progress) eta(elapsed time.Duration) time.Duration {
+	if p.Done || p.Total <= 0 || p.Items <= 0 || p.Items >= p.Total {
+		return 0
+	}
+
+	return time.Duration(float64(elapsed) / float64(p.Items) * float64(p.Total-p.Items))
+}
+
+// A ProgressFunc receives progress reports for multi-page operations.  A
+// ProgressFunc may be invoked concurrently when operations run in parallel,
+// such as by UserService.DownloadLibrary.
+type ProgressFunc func(p Progress)
+
+// progressKey is the context key used to store a ProgressFunc.
+type progressKey struct{}
+
+// WithProgress returns a copy of the input context which carries a
+// ProgressFunc.  Multi-page operations performed using the returned context,
+// such as UserService.AllCheckins and UserService.DownloadLibrary, invoke fn
+// after each page is fetched.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// progressFromContext returns the ProgressFunc stored in a context by
+// WithProgress, if any.
+func progressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
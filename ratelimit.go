@@ -0,0 +1,110 @@
+package untappd
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimit is matched by errors.Is when the Untappd APIv4 refuses a
+	// request because the Client's rate limit is exhausted.  The returned
+	// error is an *Error.
+	ErrRateLimit = errors.New("rate limit exceeded")
+)
+
+const (
+	// rateLimitErrorType is the Error type returned by the APIv4 when the
+	// Client's rate limit is exhausted.
+	rateLimitErrorType = "invalid_limit"
+
+	// headerRateLimitLimit and headerRateLimitRemaining are the HTTP headers
+	// used by the Untappd APIv4 to report rate limit information.
+	headerRateLimitLimit     = "X-Ratelimit-Limit"
+	headerRateLimitRemaining = "X-Ratelimit-Remaining"
+)
+
+// RateLimit contains rate limit information reported by the Untappd APIv4.
+//
+// The Untappd APIv4 permits a fixed number of requests per hour for each
+// client ID or access token.
+type RateLimit struct {
+	// Maximum number of requests permitted per hour, and the number of
+	// requests remaining in the current hour.
+	Limit     int
+	Remaining int
+
+	// Time when this rate limit information was reported by the Untappd
+	// APIv4.  The zero value indicates that no information is available.
+	Updated time.Time
+}
+
+// RateLimit returns the most recent rate limit information reported by the
+// Untappd APIv4.  If no request has been made yet, the zero RateLimit is
+// returned.
+//
+// RateLimit is safe for concurrent use.
+func (c *Client) RateLimit() RateLimit {
+	c.limits.mu.Lock()
+	defer c.limits.mu.Unlock()
+
+	return c.limits.rl
+}
+
+// updateRateLimit updates the Client's rate limit information using headers
+// from an HTTP response.  Responses without rate limit headers are ignored.
+func (c *Client) updateRateLimit(h http.Header) {
+	rl, ok := parseRateLimit(h, c.clock().Now())
+	if !ok {
+		return
+	}
+
+	c.limits.mu.Lock()
+	c.limits.rl = rl
+	store := c.limits.store
+	save := store != nil && !c.limits.saving
+	if save {
+		c.limits.saving = true
+	}
+	c.limits.mu.Unlock()
+
+	// If a save is already in progress, it saves this information once it
+	// completes
+	if save {
+		c.saveRateLimit(store, rl)
+	}
+}
+
+// rateLimitState stores the most recent rate limit information for a set of
+// credentials, which may be shared by several Clients, and the
+// RateLimitStore used to persist it, if any.
+type rateLimitState struct {
+	mu    sync.Mutex
+	rl    RateLimit
+	store RateLimitStore
+
+	// Whether rate limit information is being saved to store, and the
+	// error returned by the most recent save.
+	saving  bool
+	saveErr error
+}
+
+// parseRateLimit parses rate limit information from HTTP headers, reporting
+// whether any rate limit headers were present.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(h.Get(headerRateLimitRemaining))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	// Limit is optional, and zero if not present
+	limit, _ := strconv.Atoi(h.Get(headerRateLimitLimit))
+
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Updated:   now,
+	}, true
+}
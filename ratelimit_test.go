@@ -0,0 +1,267 @@
+package untappd
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestClientRateLimit verifies that Client.RateLimit reports the rate limit
+// information from the most recent response, and that it is safe to use
+// concurrently with requests.
+func TestClientRateLimit(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimitLimit, "100")
+		w.Header().Set(headerRateLimitRemaining, "99")
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	if rl := c.RateLimit(); !rl.Updated.IsZero() {
+		t.Fatalf("unexpected rate limit before first request: %+v", rl)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+				t.Error(err)
+			}
+			_ = c.RateLimit()
+		}()
+	}
+	wg.Wait()
+
+	rl := c.RateLimit()
+	if rl.Limit != 100 || rl.Remaining != 99 || rl.Updated.IsZero() {
+		t.Fatalf("unexpected rate limit: %+v", rl)
+	}
+}
+
+// Test_parseRateLimit verifies that parseRateLimit ignores responses which
+// do not contain rate limit headers.
+func Test_parseRateLimit(t *testing.T) {
+	if _, ok := parseRateLimit(http.Header{}, time.Time{}); ok {
+		t.Fatal("expected no rate limit for empty headers")
+	}
+
+	rl, ok := parseRateLimit(http.Header{
+		headerRateLimitRemaining: []string{"5"},
+	}, time.Time{})
+	if !ok {
+		t.Fatal("expected rate limit")
+	}
+	if rl.Remaining != 5 || rl.Limit != 0 {
+		t.Fatalf("unexpected rate limit: %+v", rl)
+	}
+}
+
+// TestClientRateLimitStore verifies that rate limit information is restored
+// from and saved to a RateLimitStore.
+func TestClientRateLimitStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-ratelimit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &FileRateLimitStore{Path: filepath.Join(dir, "ratelimit.json")}
+
+	if rl, err := store.LoadRateLimit(); err != nil || !rl.Updated.IsZero() {
+		t.Fatalf("unexpected rate limit from missing file: %+v, %v", rl, err)
+	}
+
+	saved := RateLimit{
+		Limit:     100,
+		Remaining: 7,
+		Updated:   time.Now().Add(-10 * time.Minute).Round(0),
+	}
+	if err := store.SaveRateLimit(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimitLimit, "100")
+		w.Header().Set(headerRateLimitRemaining, "6")
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	applyTestOptions(t, c, WithRateLimitStore(store))
+
+	if rl := c.RateLimit(); rl.Remaining != 7 || !rl.Updated.Equal(saved.Updated) {
+		t.Fatalf("unexpected restored rate limit: %+v", rl)
+	}
+
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	rl, err := store.LoadRateLimit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rl.Remaining != 6 || !rl.Updated.After(saved.Updated) {
+		t.Fatalf("unexpected saved rate limit: %+v", rl)
+	}
+
+	// Information from a window which has reset is ignored
+	saved.Updated = time.Now().Add(-2 * time.Hour)
+	if err := store.SaveRateLimit(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	c2, done2 := testClient(t, nil)
+	defer done2()
+
+	applyTestOptions(t, c2, WithRateLimitStore(store))
+
+	if rl := c2.RateLimit(); !rl.Updated.IsZero() {
+		t.Fatalf("unexpected rate limit restored from expired window: %+v", rl)
+	}
+}
+
+// TestClientRateLimitStoreConcurrent verifies that saving rate limit
+// information does not block other requests, that only the most recent
+// information is saved after a slow save, and that save errors are reported.
+func TestClientRateLimitStoreConcurrent(t *testing.T) {
+	remaining := int32(10)
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimitRemaining, strconv.Itoa(int(atomic.AddInt32(&remaining, -1))))
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	errSave := errors.New("disk full")
+	store := &blockingRateLimitStore{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		err:     errSave,
+	}
+	applyTestOptions(t, c, WithRateLimitStore(store))
+
+	errC := make(chan error)
+	go func() {
+		_, err := c.request("GET", "foo", nil, nil, nil)
+		errC <- err
+	}()
+	<-store.started
+
+	// Requests complete while the first save is blocked
+	for i := 0; i < 2; i++ {
+		if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rl := c.RateLimit(); rl.Remaining != 7 {
+		t.Fatalf("unexpected rate limit while saving: %+v", rl)
+	}
+
+	close(store.release)
+	if err := <-errC; err != nil {
+		t.Fatal(err)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if len(store.saved) != 2 || store.saved[0].Remaining != 9 || store.saved[1].Remaining != 7 {
+		t.Fatalf("unexpected saved rate limits: %+v", store.saved)
+	}
+	if err := c.RateLimitStoreError(); err != errSave {
+		t.Fatalf("unexpected rate limit store error: %v", err)
+	}
+}
+
+// blockingRateLimitStore is a RateLimitStore which blocks each save until
+// release is closed, and then fails it with err.
+type blockingRateLimitStore struct {
+	started chan struct{}
+	release chan struct{}
+	err     error
+
+	mu    sync.Mutex
+	saved []RateLimit
+}
+
+func (s *blockingRateLimitStore) LoadRateLimit() (RateLimit, error) {
+	return RateLimit{}, nil
+}
+
+func (s *blockingRateLimitStore) SaveRateLimit(rl RateLimit) error {
+	select {
+	case s.started <- struct{}{}:
+	default:
+	}
+	<-s.release
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.saved = append(s.saved, rl)
+	return s.err
+}
+
+// TestRateLimitForecast verifies the times by which a number of requests are
+// expected to be permitted.
+func TestRateLimitForecast(t *testing.T) {
+	now := time.Date(2016, time.May, 21, 0, 0, 0, 0, time.UTC)
+	reset := now.Add(rateLimitWindow)
+
+	var tests = []struct {
+		desc string
+		rl   RateLimit
+		n    int
+		at   time.Time
+	}{
+		{
+			desc: "no rate limit information",
+			n:    10,
+		},
+		{
+			desc: "requests remaining",
+			rl:   RateLimit{Limit: 100, Remaining: 10, Updated: now},
+			n:    10,
+			at:   now,
+		},
+		{
+			desc: "exhausted",
+			rl:   RateLimit{Limit: 100, Remaining: 0, Updated: now},
+			n:    1,
+			at:   reset,
+		},
+		{
+			desc: "next window",
+			rl:   RateLimit{Limit: 100, Remaining: 10, Updated: now},
+			n:    110,
+			at:   reset,
+		},
+		{
+			desc: "several windows",
+			rl:   RateLimit{Limit: 100, Remaining: 10, Updated: now},
+			n:    250,
+			at:   reset.Add(2 * rateLimitWindow),
+		},
+		{
+			desc: "default limit",
+			rl:   RateLimit{Remaining: 0, Updated: now},
+			n:    DefaultHourlyBudget + 1,
+			at:   reset.Add(rateLimitWindow),
+		},
+	}
+
+	for _, tt := range tests {
+		if at := tt.rl.Forecast(tt.n); !at.Equal(tt.at) {
+			t.Fatalf("[%s] unexpected forecast: %v != %v", tt.desc, at, tt.at)
+		}
+	}
+}
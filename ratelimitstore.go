@@ -0,0 +1,193 @@
+package untappd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+const (
+	// rateLimitWindow is the period over which the Untappd APIv4 enforces
+	// its rate limit.
+	rateLimitWindow = 1 * time.Hour
+)
+
+// Reset returns the time by which the rate limit window reported by the
+// Untappd APIv4 is expected to have reset, with the full number of requests
+// available again.  The zero time is returned if no information is available.
+func (rl RateLimit) Reset() time.Time {
+	if rl.Updated.IsZero() {
+		return time.Time{}
+	}
+
+	return rl.Updated.Add(rateLimitWindow)
+}
+
+// Forecast returns the time by which n more requests are expected to be
+// permitted, given the number of requests remaining and the reset of the
+// rate limit window.  Requests beyond those remaining are permitted once the
+// window resets, up to Limit per window, or DefaultHourlyBudget if Limit is
+// not reported.  If n requests remain, the time this rate limit information
+// was reported is returned, so the requests are permitted immediately.  The
+// zero time is returned if no information is available.
+func (rl RateLimit) Forecast(n int) time.Time {
+	if rl.Updated.IsZero() || n <= rl.Remaining {
+		return rl.Updated
+	}
+
+	limit := rl.Limit
+	if limit <= 0 {
+		limit = DefaultHourlyBudget
+	}
+
+	// Each window after the current one permits up to limit requests
+	windows := (n - rl.Remaining + limit - 1) / limit
+	return rl.Reset().Add(time.Duration(windows-1) * rateLimitWindow)
+}
+
+// A RateLimitStore persists rate limit information, so that it survives
+// restarts of a program.  A program which is invoked repeatedly, such as
+// from cron, would otherwise assume a fresh rate limit budget on each run.
+type RateLimitStore interface {
+	// LoadRateLimit returns the stored rate limit information, or the zero
+	// RateLimit if none is stored.
+	LoadRateLimit() (RateLimit, error)
+
+	// SaveRateLimit stores rate limit information, replacing any
+	// previously stored information.
+	SaveRateLimit(rl RateLimit) error
+}
+
+// WithRateLimitStore sets a RateLimitStore used to restore rate limit
+// information when a Client is created, and to save it after each response
+// which reports rate limit information.  Stored information is ignored once
+// its rate limit window has reset.
+//
+// Information is saved without blocking other requests, and if responses
+// arrive while a save is in progress, only the most recent information is
+// saved once it completes.  Errors which occur while saving do not cause
+// otherwise successful requests to fail, and are reported by
+// Client.RateLimitStoreError instead.  Clones share the store only if their
+// credentials are not changed.
+func WithRateLimitStore(s RateLimitStore) ClientOption {
+	return func(c *clientConfig) error {
+		c.rateLimitStore = s
+		return nil
+	}
+}
+
+// RateLimitStoreError returns the error which occurred while saving rate
+// limit information to the Client's RateLimitStore, if the most recent save
+// failed.  It returns nil if the most recent save succeeded, or if no
+// RateLimitStore is in use.
+//
+// RateLimitStoreError is safe for concurrent use.
+func (c *Client) RateLimitStoreError() error {
+	c.limits.mu.Lock()
+	defer c.limits.mu.Unlock()
+
+	return c.limits.saveErr
+}
+
+// saveRateLimit saves rate limit information to the input RateLimitStore
+// without holding the rate limit lock, and then saves any newer information
+// which arrived in the meantime.  The caller must have set the saving flag.
+func (c *Client) saveRateLimit(s RateLimitStore, rl RateLimit) {
+	for {
+		err := s.SaveRateLimit(rl)
+
+		c.limits.mu.Lock()
+		c.limits.saveErr = err
+		if c.limits.rl == rl {
+			c.limits.saving = false
+			c.limits.mu.Unlock()
+			return
+		}
+		rl = c.limits.rl
+		c.limits.mu.Unlock()
+	}
+}
+
+// restoreRateLimit loads rate limit information from a RateLimitStore into
+// the Client's rate limit state, and uses the store for future updates.
+func (c *Client) restoreRateLimit(s RateLimitStore) error {
+	c.limits.mu.Lock()
+	defer c.limits.mu.Unlock()
+
+	// Clones with the same credentials share a store, which has already
+	// been loaded
+	if c.limits.store != nil {
+		return nil
+	}
+
+	rl, err := s.LoadRateLimit()
+	if err != nil {
+		return err
+	}
+
+	// Do not restore information from a rate limit window which has reset,
+	// or which is older than information already available
+	if c.clock().Now().Before(rl.Reset()) && rl.Updated.After(c.limits.rl.Updated) {
+		c.limits.rl = rl
+	}
+	c.limits.store = s
+
+	return nil
+}
+
+// A FileRateLimitStore is a RateLimitStore which stores rate limit
+// information as JSON in a file.
+type FileRateLimitStore struct {
+	// Path to the file.  The file is created if it does not exist.
+	Path string
+}
+
+var _ RateLimitStore = &FileRateLimitStore{}
+
+// fileRateLimit is the JSON representation of a RateLimit stored by a
+// FileRateLimitStore.
+type fileRateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Updated   time.Time `json:"updated"`
+}
+
+// LoadRateLimit implements RateLimitStore.  If the file does not exist, the
+// zero RateLimit is returned.
+func (s *FileRateLimitStore) LoadRateLimit() (RateLimit, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return RateLimit{}, nil
+		}
+
+		return RateLimit{}, err
+	}
+
+	var v fileRateLimit
+	if err := json.Unmarshal(b, &v); err != nil {
+		return RateLimit{}, err
+	}
+
+	return RateLimit{
+		Limit:     v.Limit,
+		Remaining: v.Remaining,
+		Updated:   v.Updated,
+	}, nil
+}
+
+// SaveRateLimit implements RateLimitStore.  The file is replaced atomically,
+// so that concurrently running programs never observe a partial write.
+func (s *FileRateLimitStore) SaveRateLimit(rl RateLimit) error {
+	b, err := json.Marshal(fileRateLimit{
+		Limit:     rl.Limit,
+		Remaining: rl.Remaining,
+		Updated:   rl.Updated,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.Path, b)
+}
@@ -0,0 +1,52 @@
+package untappd
+
+// Rating represents an Untappd rating, and contains a rating score along
+// with the number of ratings used to calculate it.
+type Rating struct {
+	// Global Untappd rating score, and the number of ratings used to
+	// calculate it.  Score is an average between 0 and 5, and is not
+	// rounded.
+	Score float64
+	Count int
+
+	// If applicable, the authenticated user's rating.  Zero if the
+	// authenticated user has not rated this item.
+	AuthRating float64
+
+	// If applicable, whether this item is on the authenticated user's wish
+	// list.
+	WishList bool
+}
+
+// Weighted returns a weighted rating score, which blends this rating's score
+// with a prior score, as if the prior had been submitted weight times.
+// Ratings calculated from few ratings are pulled towards the prior, which
+// makes weighted scores suitable for ranking items with differing numbers of
+// ratings.
+//
+// If both Count and weight are zero, the prior is returned.
+func (r Rating) Weighted(prior float64, weight int) float64 {
+	n := r.Count + weight
+	if n == 0 {
+		return prior
+	}
+
+	return (r.Score*float64(r.Count) + prior*float64(weight)) / float64(n)
+}
+
+// rawRating is the raw JSON representation of an Untappd rating block, as
+// used by breweries.  Its data is unmarshaled from JSON and then exported to
+// a Rating struct.
+type rawRating struct {
+	Count int     `json:"count"`
+	Score float64 `json:"rating_score"`
+}
+
+// export creates an exported Rating from a rawRating struct, allowing for
+// more useful structures to be created for client consumption.
+func (r *rawRating) export() Rating {
+	return Rating{
+		Score: r.Score,
+		Count: r.Count,
+	}
+}
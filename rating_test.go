@@ -0,0 +1,27 @@
+package untappd
+
+import "testing"
+
+// TestRatingWeighted verifies that Rating.Weighted blends rating scores with
+// a prior score according to the number of ratings.
+func TestRatingWeighted(t *testing.T) {
+	var tests = []struct {
+		description string
+		rating      Rating
+		prior       float64
+		weight      int
+		score       float64
+	}{
+		{"no ratings", Rating{}, 3.5, 10, 3.5},
+		{"no ratings or weight", Rating{}, 3.5, 0, 3.5},
+		{"no weight", Rating{Score: 4, Count: 2}, 3, 0, 4},
+		{"equal weight", Rating{Score: 4, Count: 10}, 3, 10, 3.5},
+		{"many ratings", Rating{Score: 5, Count: 90}, 3, 10, 4.8},
+	}
+
+	for _, tt := range tests {
+		if s := tt.rating.Weighted(tt.prior, tt.weight); s != tt.score {
+			t.Fatalf("unexpected score for test %q: %f != %f", tt.description, s, tt.score)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package untappd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRatingInterval is the interval at which a RatingTracker
+	// records snapshots, if no other interval is specified.
+	DefaultRatingInterval = 24 * time.Hour
+)
+
+// A RatingSnapshot records the rating and checkin totals of a Beer at a
+// point in time.
+type RatingSnapshot struct {
+	BeerID int64     `json:"beer_id"`
+	Time   time.Time `json:"time"`
+
+	// Rating score and number of ratings of the Beer.
+	Score float64 `json:"score"`
+	Count int     `json:"count"`
+
+	// Total number of checkins of the Beer, the number in the past month,
+	// and the number of distinct users who have checked it in.
+	TotalCheckins   int `json:"total_checkins"`
+	MonthlyCheckins int `json:"monthly_checkins"`
+	TotalUsers      int `json:"total_users"`
+}
+
+// newRatingSnapshot creates a RatingSnapshot of a Beer at the input time.
+func newRatingSnapshot(b *Beer, now time.Time) RatingSnapshot {
+	return RatingSnapshot{
+		BeerID:          b.ID,
+		Time:            now,
+		Score:           b.Rating.Score,
+		Count:           b.Rating.Count,
+		TotalCheckins:   b.Stats.TotalCount,
+		MonthlyCheckins: b.Stats.MonthlyCount,
+		TotalUsers:      b.Stats.TotalUserCount,
+	}
+}
+
+// A RatingStore persists RatingSnapshots recorded by a RatingTracker, so that
+// rating trends can be charted over long periods.
+type RatingStore interface {
+	// SaveRatingSnapshots stores RatingSnapshots, in addition to those
+	// previously stored.
+	SaveRatingSnapshots(snapshots []RatingSnapshot) error
+
+	// RatingSnapshots returns the stored RatingSnapshots for a Beer, oldest
+	// first.
+	RatingSnapshots(beerID int64) ([]RatingSnapshot, error)
+}
+
+// A RatingTracker periodically records RatingSnapshots of a set of beers in
+// a RatingStore, such as to chart the rating trends of a brewery's releases
+// over months.
+type RatingTracker struct {
+	// Client used to retrieve beer information.
+	Client *Client
+
+	// Store in which snapshots are recorded.
+	Store RatingStore
+
+	// IDs of the beers to track.
+	BeerIDs []int64
+
+	// Interval at which Run records snapshots.  If zero,
+	// DefaultRatingInterval is used.
+	Interval time.Duration
+}
+
+// Record retrieves information about each of the RatingTracker's beers using
+// BeerService.InfoBatch, and records a RatingSnapshot of each in the
+// RatingTracker's store.  If some beers cannot be retrieved, snapshots of the
+// others are recorded, and a *BatchError is returned.
+func (t *RatingTracker) Record(ctx context.Context) error {
+	beers, err := t.Client.Beer.InfoBatch(ctx, t.BeerIDs, false)
+	var berr *BatchError
+	if err != nil && !errors.As(err, &berr) {
+		return err
+	}
+
+	now := t.Client.clock().Now()
+	snapshots := make([]RatingSnapshot, 0, len(beers))
+	for _, id := range t.BeerIDs {
+		if b, ok := beers[id]; ok {
+			snapshots = append(snapshots, newRatingSnapshot(b, now))
+			// IDs may be repeated
+			delete(beers, id)
+		}
+	}
+
+	if len(snapshots) > 0 {
+		if serr := t.Store.SaveRatingSnapshots(snapshots); serr != nil {
+			return serr
+		}
+	}
+
+	return err
+}
+
+// Run records snapshots using Record immediately, and then once per
+// Interval, until the context is canceled.  Errors returned by Record are
+// passed to onError, if it is not nil, and do not stop Run.  If Record fails
+// because the Untappd APIv4 is unavailable, Run records again once the
+// outage is expected to end.  Run returns the context's error once it is
+// canceled.
+func (t *RatingTracker) Run(ctx context.Context, onError func(err error)) error {
+	interval := t.Interval
+	if interval <= 0 {
+		interval = DefaultRatingInterval
+	}
+
+	c := t.Client
+	for {
+		next := c.clock().Now().Add(interval)
+		err := t.Record(ctx)
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		// Record again once an outage ends, rather than after a full
+		// interval
+		var uerr *ServiceUnavailableError
+		if errors.As(err, &uerr) && uerr.RetryAt.Before(next) {
+			next = uerr.RetryAt
+		}
+
+		if err := c.wait(ctx, WaitEvent{Reason: WaitSchedule}, next); err != nil {
+			return err
+		}
+	}
+}
+
+// Series returns the RatingSnapshots recorded for a Beer, oldest first.
+func (t *RatingTracker) Series(beerID int64) ([]RatingSnapshot, error) {
+	return t.Store.RatingSnapshots(beerID)
+}
+
+// A FileRatingStore is a RatingStore which appends RatingSnapshots to a file,
+// as one JSON object per line.
+//
+// A FileRatingStore is safe for concurrent use by multiple goroutines, but
+// not by multiple programs.
+type FileRatingStore struct {
+	// Path to the file.  The file is created if it does not exist.
+	Path string
+
+	mu sync.Mutex
+}
+
+var _ RatingStore = &FileRatingStore{}
+
+// SaveRatingSnapshots implements RatingStore.
+func (s *FileRatingStore) SaveRatingSnapshots(snapshots []RatingSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(f)
+	enc := json.NewEncoder(bw)
+	for _, rs := range snapshots {
+		if err := enc.Encode(rs); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// RatingSnapshots implements RatingStore.  If the file does not exist, no
+// snapshots are returned.
+func (s *FileRatingStore) RatingSnapshots(beerID int64) ([]RatingSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []RatingSnapshot
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var rs RatingSnapshot
+		if err := dec.Decode(&rs); err != nil {
+			return nil, err
+		}
+		if rs.BeerID == beerID {
+			out = append(out, rs)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Time.Before(out[j].Time)
+	})
+
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ratingTrackerClient creates a test Client which reports beers with ratings
+// which increase with each request.  Requests for beer 3 fail.
+func ratingTrackerClient(t *testing.T) (*Client, *int64, func()) {
+	var n int64
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/v4/beer/info/%d/", &id)
+		if id == 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(apiErrJSON)
+			return
+		}
+
+		i := atomic.AddInt64(&n, 1)
+		fmt.Fprintf(w, `{"meta": {"code": 200}, "response": {"beer": {
+  "bid": %d,
+  "rating_score": %d,
+  "rating_count": %d,
+  "stats": {"total_count": %d, "monthly_count": 1, "total_user_count": 2}
+}}}`, id, i, i*10, i*100)
+	})
+
+	return c, &n, done
+}
+
+// TestRatingTracker verifies that a RatingTracker records snapshots of each
+// beer in a FileRatingStore, and reports them oldest first.
+func TestRatingTracker(t *testing.T) {
+	c, _, done := ratingTrackerClient(t)
+	defer done()
+
+	rt := &RatingTracker{
+		Client:  c,
+		Store:   &FileRatingStore{Path: filepath.Join(t.TempDir(), "ratings.jsonl")},
+		BeerIDs: []int64{1, 2, 3, 1},
+	}
+
+	series, err := rt.Series(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(series); l != 0 {
+		t.Fatalf("unexpected number of snapshots before recording: %d", l)
+	}
+
+	for i := 0; i < 2; i++ {
+		var berr *BatchError
+		if err := rt.Record(context.Background()); !errors.As(err, &berr) || len(berr.Errors) != 1 {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, id := range []int64{1, 2} {
+		series, err := rt.Series(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if l := len(series); l != 2 {
+			t.Fatalf("unexpected number of snapshots for beer %d: %d != %d", id, l, 2)
+		}
+		if a, b := series[0], series[1]; a.BeerID != id || b.Score <= a.Score || b.Count <= a.Count ||
+			b.TotalCheckins <= a.TotalCheckins || b.TotalUsers != 2 || b.Time.Before(a.Time) {
+			t.Fatalf("unexpected snapshots for beer %d:\n%+v\n%+v", id, a, b)
+		}
+	}
+
+	series, err = rt.Series(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(series); l != 0 {
+		t.Fatalf("unexpected number of snapshots for failing beer: %d", l)
+	}
+}
+
+// TestRatingTrackerRun verifies that RatingTracker.Run records snapshots
+// periodically until its context is canceled.
+func TestRatingTrackerRun(t *testing.T) {
+	c, n, done := ratingTrackerClient(t)
+	defer done()
+
+	rt := &RatingTracker{
+		Client:   c,
+		Store:    &FileRatingStore{Path: filepath.Join(t.TempDir(), "ratings.jsonl")},
+		BeerIDs:  []int64{1, 3},
+		Interval: time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var failures int
+	err := rt.Run(ctx, func(err error) {
+		failures++
+		if atomic.LoadInt64(n) >= 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	series, err := rt.Series(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(series); l != 3 || failures != 3 {
+		t.Fatalf("unexpected number of snapshots and failures: %d, %d", l, failures)
+	}
+}
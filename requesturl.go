@@ -0,0 +1,42 @@
+package untappd
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// redactedParams are the query parameters which carry credentials, and are
+// redacted by RequestURL.
+var redactedParams = []string{"access_token", "client_secret"}
+
+// RequestURL returns the URL of the request which produced the input HTTP
+// response, exactly as it was sent to the Untappd APIv4, including all query
+// parameters.  The access token and client secret, if present, are replaced
+// with "REDACTED", so the result is safe to log.  If res is nil or carries no
+// request, it returns the empty string.
+//
+// Combined with WithResponseFunc, RequestURL can be used to inspect each
+// request made by a multi-page method such as UserService.AllCheckins.
+func RequestURL(res *http.Response) string {
+	if res == nil || res.Request == nil || res.Request.URL == nil {
+		return ""
+	}
+
+	return redactURL(res.Request.URL).String()
+}
+
+// redactURL returns a copy of u with credentials redacted from its query
+// string.
+func redactURL(u *url.URL) *url.URL {
+	out := *u
+
+	q := out.Query()
+	for _, p := range redactedParams {
+		if _, ok := q[p]; ok {
+			q.Set(p, "REDACTED")
+		}
+	}
+	out.RawQuery = q.Encode()
+
+	return &out
+}
@@ -0,0 +1,44 @@
+package untappd
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestRequestURL verifies that RequestURL reports the query parameters sent
+// with a request, with credentials redacted.
+func TestRequestURL(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(blackNoteBeerJSON)
+	})
+	defer done()
+
+	_, res, err := c.Beer.Info(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(RequestURL(res))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := u.Query()
+	for k, want := range map[string]string{
+		"client_id":     "foo",
+		"client_secret": "REDACTED",
+		"compact":       "true",
+	} {
+		if got := q.Get(k); got != want {
+			t.Fatalf("unexpected %q parameter: %q != %q", k, got, want)
+		}
+	}
+	if want := "/v4/beer/info/1/"; u.Path != want {
+		t.Fatalf("unexpected path: %q != %q", u.Path, want)
+	}
+
+	if s := RequestURL(nil); s != "" {
+		t.Fatalf("unexpected URL for nil response: %q", s)
+	}
+}
@@ -0,0 +1,250 @@
+package untappd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minResolveScore is the minimum score of a candidate which is
+	// returned by a Resolve method without an exact match.
+	minResolveScore = 0.8
+
+	// minResolveMargin is the minimum difference between the scores of
+	// the best and second best candidates for the best candidate to be
+	// returned by a Resolve method without an exact match.
+	minResolveMargin = 0.2
+)
+
+var (
+	// ErrNoMatch is returned by Resolve methods, such as BeerService.Resolve,
+	// when no search results resemble the input names.
+	ErrNoMatch = errors.New("no matching results")
+)
+
+// A Match is a candidate result considered by a Resolve method, along with
+// its score.  Scores range from zero to one, where one is an exact match of
+// the normalized names.
+type Match struct {
+	// The candidate beer, if resolving a beer, and its brewery.
+	Beer    *Beer
+	Brewery *Brewery
+
+	Score float64
+}
+
+// An AmbiguousMatchError is returned by Resolve methods, such as
+// BeerService.Resolve, when no search result matches the input names well
+// enough to be chosen over the others.  Candidates contains the results
+// which resemble the input names, in descending order of score, so that
+// callers may choose among them.
+type AmbiguousMatchError struct {
+	Query      string
+	Candidates []Match
+}
+
+// Error returns the string representation of an AmbiguousMatchError.
+func (e *AmbiguousMatchError) Error() string {
+	return fmt.Sprintf("ambiguous match for %q: %d candidates", e.Query, len(e.Candidates))
+}
+
+// Resolve searches for the beer with the specified name, produced by the
+// brewery with the specified name, such as Resolve("Dogfish Head", "60
+// Minute IPA").  Names are compared ignoring case, punctuation, and common
+// words such as "Brewing" and "Company".  If brewery is empty, only beer
+// names are compared.
+//
+// If exactly one search result matches both names, or one result matches
+// them much more closely than any other, it is returned.  Otherwise, an
+// *AmbiguousMatchError is returned which lists the candidates, or ErrNoMatch
+// if no search result resembles the input names.
+func (b *BeerService) Resolve(brewery string, beer string) (*Beer, *http.Response, error) {
+	query := strings.TrimSpace(brewery + " " + beer)
+	p, res, err := b.SearchPage(query, 0, 25, SortCheckin)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var matches []Match
+	for _, c := range p.Beers {
+		if c == nil {
+			continue
+		}
+
+		score := nameScore(beer, c.Name)
+		if brewery != "" {
+			var name string
+			if c.Brewery != nil {
+				name = c.Brewery.Name
+			}
+			score = (score + nameScore(brewery, name)) / 2
+		}
+
+		matches = append(matches, Match{
+			Beer:    c,
+			Brewery: c.Brewery,
+			Score:   score,
+		})
+	}
+
+	// Retired beers often share a name with their replacements, so prefer
+	// beers which are still in production when choosing among exact matches
+	m, err := bestMatch(query, matches, func(m Match) bool {
+		return m.Beer.InProduction
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	return m.Beer, res, nil
+}
+
+// Resolve searches for the brewery with the specified name.  Names are
+// compared as with BeerService.Resolve.
+//
+// If exactly one search result matches the name, or one result matches it
+// much more closely than any other, it is returned.  Otherwise, an
+// *AmbiguousMatchError is returned which lists the candidates, or ErrNoMatch
+// if no search result resembles the input name.
+func (b *BreweryService) Resolve(name string) (*Brewery, *http.Response, error) {
+	p, res, err := b.SearchPage(name, 0, 25)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var matches []Match
+	for _, c := range p.Breweries {
+		if c == nil {
+			continue
+		}
+
+		matches = append(matches, Match{
+			Brewery: c,
+			Score:   nameScore(name, c.Name),
+		})
+	}
+
+	// Prefer active breweries when choosing among exact matches
+	m, err := bestMatch(name, matches, func(m Match) bool {
+		return m.Brewery.Active
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	return m.Brewery, res, nil
+}
+
+// bestMatch chooses a single confident Match from the input candidates for
+// query.  If several candidates match exactly, the only one which satisfies
+// prefer is chosen, if any.
+func bestMatch(query string, matches []Match, prefer func(m Match) bool) (Match, error) {
+	// Discard candidates which bear no resemblance to the query
+	candidates := matches[:0]
+	for _, m := range matches {
+		if m.Score > 0 {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return Match{}, ErrNoMatch
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	var exact, preferred []Match
+	for _, m := range candidates {
+		if m.Score < 1 {
+			break
+		}
+
+		exact = append(exact, m)
+		if prefer(m) {
+			preferred = append(preferred, m)
+		}
+	}
+
+	switch {
+	case len(exact) == 1:
+		return exact[0], nil
+	case len(exact) > 1 && len(preferred) == 1:
+		return preferred[0], nil
+	case len(exact) == 0 && candidates[0].Score >= minResolveScore &&
+		(len(candidates) == 1 || candidates[0].Score-candidates[1].Score >= minResolveMargin):
+		return candidates[0], nil
+	}
+
+	return Match{}, &AmbiguousMatchError{
+		Query:      query,
+		Candidates: candidates,
+	}
+}
+
+// nameStopWords are common words in beer and brewery names which are ignored
+// when comparing names.
+var nameStopWords = map[string]struct{}{
+	"and":     {},
+	"beer":    {},
+	"brewers": {},
+	"brewery": {},
+	"brewing": {},
+	"co":      {},
+	"company": {},
+	"the":     {},
+}
+
+// nameTokens splits a beer or brewery name into lowercase words, ignoring
+// punctuation and nameStopWords.
+func nameTokens(s string) []string {
+	// Apostrophes do not separate words, as in "Bell's"
+	s = strings.NewReplacer("'", "", "’", "").Replace(s)
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	tokens := words[:0]
+	for _, w := range words {
+		if _, ok := nameStopWords[w]; !ok {
+			tokens = append(tokens, w)
+		}
+	}
+
+	return tokens
+}
+
+// nameScore scores the similarity of two beer or brewery names, as the
+// fraction of their distinct words which are shared by both.  Names which
+// consist only of nameStopWords are compared in full.
+func nameScore(a string, b string) float64 {
+	ta, tb := nameTokens(a), nameTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		if strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b)) && a != "" {
+			return 1
+		}
+
+		return 0
+	}
+
+	words := make(map[string]int)
+	for _, w := range ta {
+		words[w] |= 1
+	}
+	for _, w := range tb {
+		words[w] |= 2
+	}
+
+	var shared int
+	for _, v := range words {
+		if v == 3 {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(words))
+}
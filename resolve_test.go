@@ -0,0 +1,103 @@
+package untappd
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// TestClientBeerResolve verifies that Client.Beer.Resolve chooses a single
+// confident search result, or reports the candidates when it cannot.
+func TestClientBeerResolve(t *testing.T) {
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"q":      []string{"Russian River Pliny the Elder"},
+			"offset": []string{"0"},
+			"limit":  []string{"25"},
+			"sort":   []string{"checkin"},
+		})
+
+		w.Write(beerSearchJSON)
+	})
+	defer done()
+
+	beer, _, err := c.Beer.Resolve("Russian River", "Pliny the Elder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := beer.ID; id != 1 {
+		t.Fatalf("unexpected beer ID: %d != %d", id, 1)
+	}
+}
+
+// TestClientBeerResolveAmbiguous verifies that Client.Beer.Resolve returns
+// an *AmbiguousMatchError when several beers match equally well, and
+// ErrNoMatch when none resemble the input names.
+func TestClientBeerResolveAmbiguous(t *testing.T) {
+	c, done := beerSearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{"found":3,"beers":{"count":3,"items":[
+			{"beer":{"bid":1,"beer_name":"Hopslam Ale","is_in_production":0},"brewery":{"brewery_name":"Bell's Brewery"}},
+			{"beer":{"bid":2,"beer_name":"Hopslam Ale","is_in_production":0},"brewery":{"brewery_name":"Bell's Brewery, Inc."}},
+			{"beer":{"bid":3,"beer_name":"Two Hearted Ale"},"brewery":{"brewery_name":"Bell's Brewery"}}
+		]}}}`))
+	})
+	defer done()
+
+	_, _, err := c.Beer.Resolve("", "Hopslam")
+	var aerr *AmbiguousMatchError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("expected *AmbiguousMatchError, got: %v", err)
+	}
+	if l := len(aerr.Candidates); l != 2 {
+		t.Fatalf("unexpected number of candidates: %d != %d", l, 2)
+	}
+	for _, m := range aerr.Candidates {
+		if m.Beer.ID == 3 {
+			t.Fatalf("unexpected unrelated candidate: %+v", m)
+		}
+	}
+
+	if _, _, err := c.Beer.Resolve("", "Oberon"); err != ErrNoMatch {
+		t.Fatalf("expected ErrNoMatch, got: %v", err)
+	}
+}
+
+// TestClientBreweryResolve verifies that Client.Brewery.Resolve returns a
+// single brewery which matches the input name, ignoring common words.
+func TestClientBreweryResolve(t *testing.T) {
+	c, done := brewerySearchTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		w.Write(brewerySearchJSON)
+	})
+	defer done()
+
+	brewery, _, err := c.Brewery.Resolve("russian river brewing co.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := brewery.ID; id != 1 {
+		t.Fatalf("unexpected brewery ID: %d != %d", id, 1)
+	}
+}
+
+// Test_nameScore verifies that nameScore compares names ignoring case,
+// punctuation, and common words.
+func Test_nameScore(t *testing.T) {
+	var tests = []struct {
+		a, b  string
+		score float64
+	}{
+		{a: "Bell's Brewery", b: "bells brewery", score: 1},
+		{a: "Bell's Brewery", b: "Bell's Brewing Company", score: 1},
+		{a: "60 Minute IPA", b: "60 Minute IPA", score: 1},
+		{a: "60 Minute IPA", b: "90 Minute IPA", score: 0.5},
+		{a: "The Brewery", b: "the brewery", score: 1},
+		{a: "Oberon", b: "Hopslam Ale", score: 0},
+	}
+
+	for _, tt := range tests {
+		if s := nameScore(tt.a, tt.b); s != tt.score {
+			t.Fatalf("[%q, %q] unexpected score: %v != %v", tt.a, tt.b, s, tt.score)
+		}
+	}
+}
@@ -4,9 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"math"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -20,12 +19,25 @@ var (
 	errInvalidTimeUnit = errors.New("invalid time unit")
 )
 
-// responseDuration implements json.Unmarshaler, so that duration responses
-// in the Untappd APIv4 can be decoded directly into Go time.Duration structs.
-type responseDuration time.Duration
+// Duration is a time.Duration which is encoded as in the Untappd APIv4, as an
+// object with a time and a unit of measure, such as
+// {"time":0.5,"measure":"seconds"}.  Units of milliseconds, seconds, and
+// minutes are accepted when decoding, and Durations are encoded in seconds.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (r Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Time    float64 `json:"time"`
+		Measure string  `json:"measure"`
+	}{
+		Time:    time.Duration(r).Seconds(),
+		Measure: "seconds",
+	})
+}
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (r *responseDuration) UnmarshalJSON(data []byte) error {
+func (r *Duration) UnmarshalJSON(data []byte) error {
 	var v struct {
 		Time    float64 `json:"time"`
 		Measure string  `json:"measure"`
@@ -35,31 +47,37 @@ func (r *responseDuration) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	// Known measure strings mapped to Go parse-able equivalents
-	timeUnits := map[string]string{
-		"milliseconds": "ms",
-		"seconds":      "s",
-		"minutes":      "m",
-	}
-
-	// Parse a Go time.Duration from string
-	d, err := time.ParseDuration(fmt.Sprintf("%f%s", v.Time, timeUnits[v.Measure]))
-	if err != nil && strings.Contains(err.Error(), "time: missing unit in duration") {
+	// Known measure strings mapped to their Go time.Duration units
+	var unit time.Duration
+	switch v.Measure {
+	case "milliseconds":
+		unit = time.Millisecond
+	case "seconds":
+		unit = time.Second
+	case "minutes":
+		unit = time.Minute
+	default:
 		return errInvalidTimeUnit
 	}
 
-	*r = responseDuration(d)
-	return err
+	*r = Duration(math.Round(v.Time * float64(unit)))
+	return nil
 }
 
-// responseTime implements json.Unmarshaler, so that timestamp responses
-// in the Untappd APIv4 can be decoded directly into Go time.Time structs.
-type responseTime time.Time
+// Time is a time.Time which is encoded as in the Untappd APIv4, as a string
+// in RFC 1123 format with a numeric zone, such as
+// "Sat, 21 May 2016 00:15:40 +0000".
+type Time time.Time
+
+// MarshalJSON implements json.Marshaler.
+func (r Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(r).Format(time.RFC1123Z))
+}
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (r *responseTime) UnmarshalJSON(data []byte) error {
-	var v string
-	if err := json.Unmarshal(data, &v); err != nil {
+func (r *Time) UnmarshalJSON(data []byte) error {
+	v, err := unquote(data)
+	if err != nil {
 		return err
 	}
 
@@ -69,36 +87,69 @@ func (r *responseTime) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*r = responseTime(t)
+	*r = Time(t)
 	return nil
 }
 
-// responseURL implements json.Unmarshaler, so that URL string responses
-// in the Untappd APIv4 can be decoded directly into Go *url.URL structs.
-type responseURL url.URL
+// URL is a url.URL which is encoded as in the Untappd APIv4, as a string which
+// is empty for an empty URL.
+type URL url.URL
+
+// MarshalJSON implements json.Marshaler.
+func (r URL) MarshalJSON() ([]byte, error) {
+	u := url.URL(r)
+	return json.Marshal(u.String())
+}
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (r *responseURL) UnmarshalJSON(data []byte) error {
-	var v string
-	if err := json.Unmarshal(data, &v); err != nil {
+func (r *URL) UnmarshalJSON(data []byte) error {
+	v, err := unquote(data)
+	if err != nil {
 		return err
 	}
 
+	// Avoid parsing empty URLs, which are common in API responses
+	if v == "" {
+		*r = URL{}
+		return nil
+	}
+
 	u, err := url.Parse(v)
 	if err != nil {
 		return err
 	}
 
-	*r = responseURL(*u)
+	*r = URL(*u)
 	return nil
 }
 
-// responseBool implements json.Unmarshaler, so that integer 0 or 1 responses
-// in the Untappd APIv4 can be decoded directly into Go boolean values.
-type responseBool bool
+// Bool is a boolean value which is encoded as in the Untappd APIv4, as the
+// integer 0 or 1.  Other integers are rejected when decoding.  Some fields,
+// such as the claimed status of a brewery, are reported as JSON booleans
+// instead, which are also accepted when decoding.
+type Bool bool
+
+// MarshalJSON implements json.Marshaler.
+func (r Bool) MarshalJSON() ([]byte, error) {
+	if r {
+		return []byte("1"), nil
+	}
+
+	return []byte("0"), nil
+}
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (r *responseBool) UnmarshalJSON(data []byte) error {
+func (r *Bool) UnmarshalJSON(data []byte) error {
+	// Fast path for the only valid values
+	switch string(data) {
+	case "0", "false":
+		*r = false
+		return nil
+	case "1", "true":
+		*r = true
+		return nil
+	}
+
 	var v int
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
@@ -116,6 +167,20 @@ func (r *responseBool) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// unquote returns the contents of a JSON string.  Strings without escape
+// sequences are sliced directly from data, avoiding the cost of a full
+// json.Unmarshal.
+func unquote(data []byte) (string, error) {
+	n := len(data)
+	if n >= 2 && data[0] == '"' && data[n-1] == '"' && bytes.IndexByte(data[1:n-1], '\\') == -1 {
+		return string(data[1 : n-1]), nil
+	}
+
+	var v string
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
 // responseBadgeLevels implements json.Unmarshaler, so that an empty array on
 // a badge with no levels can be appropriately handled.
 type responseBadgeLevels struct {
@@ -166,3 +231,16 @@ func (r *responseVenue) UnmarshalJSON(data []byte) error {
 	*r = responseVenue(v)
 	return nil
 }
+
+// unmarshalItems unmarshals the items of a list in an Untappd APIv4 response
+// into the slice pointed to by v.  A list containing a single item may be
+// reported as a bare object instead of an array, in which case v receives
+// one element.
+func unmarshalItems(data []byte, v interface{}) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		data = append(append([]byte("["), data...), ']')
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,35 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+)
+
+// A ResponseFunc receives each HTTP response received by a Client.
+type ResponseFunc func(res *http.Response)
+
+// responseFuncKey is the context key used to store a ResponseFunc.
+type responseFuncKey struct{}
+
+// WithResponseFunc returns a copy of the input context which carries a
+// ResponseFunc.  Each HTTP response received by a Client using the returned
+// context, including error responses and responses served from the cache,
+// is passed to fn before it is decoded.  fn may inspect the response's status
+// code and headers, but must not read or close its body.
+//
+// Most methods return the *http.Response of their request directly.
+// WithResponseFunc provides access to the responses of methods which perform
+// many requests, such as UserService.AllCheckins and BeerService.InfoBatch.
+// fn may be invoked concurrently by methods which perform requests in
+// parallel, such as UserService.DownloadLibrary.
+func WithResponseFunc(ctx context.Context, fn ResponseFunc) context.Context {
+	return context.WithValue(ctx, responseFuncKey{}, fn)
+}
+
+// observeResponse passes an HTTP response to the ResponseFunc of its
+// request's context, if any.
+func observeResponse(ctx context.Context, res *http.Response) {
+	if fn, ok := ctx.Value(responseFuncKey{}).(ResponseFunc); ok && fn != nil {
+		fn(res)
+	}
+}
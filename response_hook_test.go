@@ -0,0 +1,38 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// TestWithResponseFunc verifies that a ResponseFunc receives the HTTP
+// response for each page of a multi-page operation.
+func TestWithResponseFunc(t *testing.T) {
+	var page int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		page++
+		w.Header().Set("X-Page", strconv.Itoa(page))
+
+		n := maxCheckinsLimit
+		if page > 1 {
+			n = 1
+		}
+		w.Write(checkinsPageJSON(100, n))
+	})
+	defer done()
+
+	var pages []string
+	ctx := WithResponseFunc(context.Background(), func(res *http.Response) {
+		pages = append(pages, res.Header.Get("X-Page"))
+	})
+
+	if _, err := c.User.AllCheckins(ctx, "mdlayher"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pages) != 2 || pages[0] != "1" || pages[1] != "2" {
+		t.Fatalf("unexpected responses observed: %v", pages)
+	}
+}
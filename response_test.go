@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"encoding/json"
 	"errors"
 	"net/url"
 	"reflect"
@@ -12,10 +13,10 @@ var (
 	errBadJSON = errors.New("invalid character '}' looking for beginning of value")
 )
 
-// Test_responseDurationUnmarshalJSON verifies that responseDuration.UnmarshalJSON
-// provides proper time.Duration for a variety of responseDuration JSON values
+// TestDurationUnmarshalJSON verifies that Duration.UnmarshalJSON
+// provides proper time.Duration for a variety of Duration JSON values
 // from the Untappd APIv4.
-func Test_responseDurationUnmarshalJSON(t *testing.T) {
+func TestDurationUnmarshalJSON(t *testing.T) {
 	var tests = []struct {
 		description string
 		body        []byte
@@ -90,7 +91,7 @@ func Test_responseDurationUnmarshalJSON(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		r := new(responseDuration)
+		r := new(Duration)
 		err := r.UnmarshalJSON(tt.body)
 		if tt.err == nil && err != nil {
 			t.Fatal(err)
@@ -99,16 +100,16 @@ func Test_responseDurationUnmarshalJSON(t *testing.T) {
 			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
 		}
 
-		if *r != responseDuration(tt.result) {
+		if *r != Duration(tt.result) {
 			t.Fatalf("unexpected duration for test %q: %v != %v", tt.description, r, tt.result)
 		}
 	}
 }
 
-// Test_responseTimeUnmarshalJSON verifies that responseTime.UnmarshalJSON
-// provides proper time.Time for a variety of responseTime JSON values
+// TestTimeUnmarshalJSON verifies that Time.UnmarshalJSON
+// provides proper time.Time for a variety of Time JSON values
 // from the Untappd APIv4.
-func Test_responseTimeUnmarshalJSON(t *testing.T) {
+func TestTimeUnmarshalJSON(t *testing.T) {
 	mst, err := time.LoadLocation("America/Denver")
 	if err != nil {
 		t.Fatal(err)
@@ -138,7 +139,7 @@ func Test_responseTimeUnmarshalJSON(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		r := new(responseTime)
+		r := new(Time)
 		err := r.UnmarshalJSON(tt.body)
 		if tt.err == nil && err != nil {
 			t.Fatal(err)
@@ -173,10 +174,10 @@ func Test_responseTimeUnmarshalJSON(t *testing.T) {
 	}
 }
 
-// Test_responseURLUnmarshalJSON verifies that responseURL.UnmarshalJSON
-// provides proper url.URL value for a variety of responseURL JSON values
+// TestURLUnmarshalJSON verifies that URL.UnmarshalJSON
+// provides proper url.URL value for a variety of URL JSON values
 // from the Untappd APIv4.
-func Test_responseURLUnmarshalJSON(t *testing.T) {
+func TestURLUnmarshalJSON(t *testing.T) {
 	// Bad URL used to validate URL parsing
 	badURL := "http://www.%20.com/foo"
 
@@ -224,7 +225,7 @@ func Test_responseURLUnmarshalJSON(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		r := new(responseURL)
+		r := new(URL)
 		err := r.UnmarshalJSON(tt.body)
 		if tt.err == nil && err != nil {
 			t.Fatal(err)
@@ -245,16 +246,16 @@ func Test_responseURLUnmarshalJSON(t *testing.T) {
 			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
 		}
 
-		if *r != responseURL(tt.result) {
+		if *r != URL(tt.result) {
 			t.Fatalf("unexpected url.URL for test %q: %#v != %#v", tt.description, r, tt.result)
 		}
 	}
 }
 
-// Test_responseBoolUnmarshalJSON verifies that responseBool.UnmarshalJSON
-// provides proper bool value for a variety of responseBool JSON values
+// TestBoolUnmarshalJSON verifies that Bool.UnmarshalJSON
+// provides proper bool value for a variety of Bool JSON values
 // from the Untappd APIv4.
-func Test_responseBoolUnmarshalJSON(t *testing.T) {
+func TestBoolUnmarshalJSON(t *testing.T) {
 	var tests = []struct {
 		description string
 		body        []byte
@@ -271,6 +272,16 @@ func Test_responseBoolUnmarshalJSON(t *testing.T) {
 			body:        []byte(`1`),
 			result:      true,
 		},
+		{
+			description: "JSON true",
+			body:        []byte(`true`),
+			result:      true,
+		},
+		{
+			description: "JSON false",
+			body:        []byte(`false`),
+			result:      false,
+		},
 		{
 			description: "2 (invalid)",
 			body:        []byte(`2`),
@@ -284,7 +295,7 @@ func Test_responseBoolUnmarshalJSON(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		r := new(responseBool)
+		r := new(Bool)
 		err := r.UnmarshalJSON(tt.body)
 		if tt.err == nil && err != nil {
 			t.Fatal(err)
@@ -293,7 +304,7 @@ func Test_responseBoolUnmarshalJSON(t *testing.T) {
 			t.Fatalf("unexpected error for test %q: %v != %v", tt.description, err, tt.err)
 		}
 
-		if *r != responseBool(tt.result) {
+		if *r != Bool(tt.result) {
 			t.Fatalf("unexpected bool for test %q: %v != %v", tt.description, r, tt.result)
 		}
 	}
@@ -422,3 +433,75 @@ func Test_responseVenueUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+// Benchmarks for the custom unmarshalers which are invoked for nearly every
+// object in an Untappd APIv4 response.
+
+func Benchmark_responseDurationUnmarshalJSON(b *testing.B) {
+	benchmarkUnmarshalJSON(b, new(Duration), []byte(`{"time":0.841,"measure":"seconds"}`))
+}
+
+func Benchmark_responseTimeUnmarshalJSON(b *testing.B) {
+	benchmarkUnmarshalJSON(b, new(Time), []byte(`"Sat, 13 Dec 2014 19:15:38 +0000"`))
+}
+
+func Benchmark_responseURLUnmarshalJSON(b *testing.B) {
+	benchmarkUnmarshalJSON(b, new(URL), []byte(`"https://d1c8v1qci5en44.cloudfront.net/photo/2014_12_13/abc_320x320.jpg"`))
+}
+
+func Benchmark_responseBoolUnmarshalJSON(b *testing.B) {
+	benchmarkUnmarshalJSON(b, new(Bool), []byte(`1`))
+}
+
+func benchmarkUnmarshalJSON(b *testing.B, u json.Unmarshaler, data []byte) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := u.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestResponseTypesRoundTrip verifies that Duration, Time, URL, and Bool
+// values are encoded in the same form they are decoded from.
+func TestResponseTypesRoundTrip(t *testing.T) {
+	type value struct {
+		Duration Duration `json:"duration"`
+		Time     Time     `json:"time"`
+		URL      URL      `json:"url"`
+		Empty    URL      `json:"empty"`
+		Bool     Bool     `json:"bool"`
+	}
+
+	u, err := url.Parse("https://untappd.com/user/mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := value{
+		Duration: Duration(1500 * time.Millisecond),
+		Time:     Time(time.Date(2016, time.May, 21, 0, 15, 40, 0, time.FixedZone("", 0))),
+		URL:      URL(*u),
+		Bool:     true,
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"duration":{"time":1.5,"measure":"seconds"},"time":"Sat, 21 May 2016 00:15:40 +0000","url":"https://untappd.com/user/mdlayher","empty":"","bool":1}`
+	if string(b) != want {
+		t.Fatalf("unexpected JSON:\n- want: %s\n-  got: %s", want, b)
+	}
+
+	var out value
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !time.Time(out.Time).Equal(time.Time(in.Time)) {
+		t.Fatalf("unexpected decoded time: %v != %v", time.Time(out.Time), time.Time(in.Time))
+	}
+	in.Time, out.Time = Time{}, Time{}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("unexpected decoded value:\n- want: %+v\n-  got: %+v", in, out)
+	}
+}
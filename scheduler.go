@@ -0,0 +1,302 @@
+package untappd
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHourlyBudget is the number of requests per hour a Scheduler
+	// performs with each Client, if no other budget is specified and no
+	// rate limit information has been reported by the Untappd APIv4.  It
+	// matches the default Untappd APIv4 rate limit.
+	DefaultHourlyBudget = 100
+)
+
+var (
+	// ErrSchedulerStarted is returned when a Scheduler is started more than
+	// once.
+	ErrSchedulerStarted = errors.New("scheduler already started")
+
+	// ErrNoSchedulerClients is returned when a Scheduler is started without
+	// any Clients.
+	ErrNoSchedulerClients = errors.New("scheduler has no clients")
+)
+
+// A Task is a unit of work performed by a Scheduler, using one of the
+// Scheduler's Clients.
+type Task struct {
+	// Name identifies this Task in progress reports.
+	Name string
+
+	// Tasks with a higher priority are performed first.  Tasks with equal
+	// priority are performed in the order they were added.
+	Priority int
+
+	// Number of API requests this Task is expected to perform.  If zero,
+	// one request is assumed.
+	Cost int
+
+	// Run performs this Task using the input Client.
+	Run func(ctx context.Context, c *Client) error
+}
+
+// SchedulerProgress reports the progress of a Scheduler.
+type SchedulerProgress struct {
+	// Total number of Tasks added to the Scheduler, and the number which
+	// have completed successfully or failed.
+	Total     int
+	Completed int
+	Failed    int
+}
+
+// Remaining returns the number of Tasks which have not yet finished.
+func (p SchedulerProgress) Remaining() int {
+	return p.Total - p.Completed - p.Failed
+}
+
+// A Scheduler performs a queue of Tasks in the background, spreading them
+// evenly across the hourly request budget of one or more Clients.  Using
+// several Clients, each with its own access token, multiplies the number of
+// Tasks which may be performed each hour.
+//
+// Tasks may be added before or after the Scheduler is started.  A Scheduler
+// is safe for concurrent use.
+type Scheduler struct {
+	// Budget is the number of requests per hour to perform with each
+	// Client.  If zero, the rate limit most recently reported for the
+	// Client is used, or DefaultHourlyBudget if none has been reported.
+	Budget int
+
+	// Progress, if not nil, is invoked after each Task finishes, with the
+	// Task, its error, and the Scheduler's progress.  Progress may be
+	// invoked concurrently by multiple goroutines.
+	Progress func(t *Task, err error, p SchedulerProgress)
+
+	clients []*Client
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    taskQueue
+	seq      int
+	progress SchedulerProgress
+	started  bool
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// NewScheduler creates a Scheduler which performs Tasks using the input
+// Clients.
+func NewScheduler(clients ...*Client) *Scheduler {
+	s := &Scheduler{clients: clients}
+	s.cond = sync.NewCond(&s.mu)
+
+	return s
+}
+
+// Add adds a Task to the Scheduler's queue.
+func (s *Scheduler) Add(t Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	heap.Push(&s.queue, &queuedTask{task: t, seq: s.seq})
+	s.seq++
+	s.progress.Total++
+
+	s.cond.Signal()
+}
+
+// Status returns the current progress of the Scheduler.
+func (s *Scheduler) Status() SchedulerProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.progress
+}
+
+// Start begins performing queued Tasks in the background, using one worker
+// per Client.  Workers stop once Close is called and the queue is empty, or
+// when the context is canceled.  When the context is canceled, Tasks which
+// remain queued are not run, and fail with the context's error.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrSchedulerStarted
+	}
+	if len(s.clients) == 0 {
+		return ErrNoSchedulerClients
+	}
+	s.started = true
+
+	for _, c := range s.clients {
+		s.wg.Add(1)
+		go func(c *Client) {
+			defer s.wg.Done()
+			s.work(ctx, c)
+		}(c)
+	}
+
+	// Wake idle workers when the context is canceled, until all workers
+	// have stopped
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.cond.Broadcast()
+			s.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	return nil
+}
+
+// Close indicates that no more Tasks will be added.  Workers finish all
+// queued Tasks, and then stop.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+}
+
+// Wait waits for all workers to stop, and returns the final progress of the
+// Scheduler.  Wait blocks until Close is called and all queued Tasks have
+// finished, or until the context passed to Start is canceled.
+func (s *Scheduler) Wait() SchedulerProgress {
+	s.wg.Wait()
+	return s.Status()
+}
+
+// work performs Tasks using a single Client, pacing them according to the
+// Client's hourly budget.
+func (s *Scheduler) work(ctx context.Context, c *Client) {
+	var next time.Time
+	for {
+		t, ok := s.dequeue(ctx)
+		if !ok {
+			return
+		}
+
+		// Drain the queue once the context is canceled
+		if err := ctx.Err(); err != nil {
+			s.finish(&t, err)
+			continue
+		}
+
+		// Wait for this Client's budget to permit another Task
+		if err := c.wait(ctx, WaitEvent{Reason: WaitSchedule}, next); err != nil {
+			s.finish(&t, err)
+			continue
+		}
+
+		// Hold Tasks during an outage, rather than failing each of them
+		if err := c.waitOutage(ctx); err != nil {
+			s.finish(&t, err)
+			continue
+		}
+
+		cost := t.Cost
+		if cost <= 0 {
+			cost = 1
+		}
+		now := c.clock().Now()
+		if next.Before(now) {
+			next = now
+		}
+		next = next.Add(time.Duration(cost) * time.Hour / time.Duration(s.budget(c)))
+
+		s.finish(&t, t.Run(ctx, c))
+	}
+}
+
+// dequeue waits for and removes the highest priority Task from the queue.
+// It returns false when the worker should stop, because the queue is empty
+// and the Scheduler is closed or the context is canceled.
+func (s *Scheduler) dequeue(ctx context.Context) (Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.queue.Len() == 0 && !s.closed && ctx.Err() == nil {
+		s.cond.Wait()
+	}
+	if s.queue.Len() == 0 {
+		return Task{}, false
+	}
+
+	return heap.Pop(&s.queue).(*queuedTask).task, true
+}
+
+// finish records the result of a Task and reports progress.
+func (s *Scheduler) finish(t *Task, err error) {
+	s.mu.Lock()
+	if err != nil {
+		s.progress.Failed++
+	} else {
+		s.progress.Completed++
+	}
+	p := s.progress
+	s.mu.Unlock()
+
+	if s.Progress != nil {
+		s.Progress(t, err, p)
+	}
+}
+
+// budget returns the number of requests per hour to perform with the input
+// Client.
+func (s *Scheduler) budget(c *Client) int {
+	if s.Budget > 0 {
+		return s.Budget
+	}
+	if rl := c.RateLimit(); rl.Limit > 0 {
+		return rl.Limit
+	}
+
+	return DefaultHourlyBudget
+}
+
+// A queuedTask is a Task in a taskQueue.
+type queuedTask struct {
+	task Task
+	seq  int
+}
+
+// taskQueue implements heap.Interface, ordering Tasks by priority and then
+// by insertion order.
+type taskQueue []*queuedTask
+
+func (q taskQueue) Len() int { return len(q) }
+
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].task.Priority != q[j].task.Priority {
+		return q[i].task.Priority > q[j].task.Priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q taskQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *taskQueue) Push(x interface{}) { *q = append(*q, x.(*queuedTask)) }
+
+func (q *taskQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return t
+}
@@ -0,0 +1,122 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSchedulerPriority verifies that a Scheduler performs Tasks in priority
+// order, and reports progress for each Task.
+func TestSchedulerPriority(t *testing.T) {
+	c, done := testClient(t, nil)
+	defer done()
+
+	s := NewScheduler(c)
+	s.Budget = 3600 * 1000
+
+	var mu sync.Mutex
+	var order []string
+	var last SchedulerProgress
+	s.Progress = func(task *Task, err error, p SchedulerProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		order = append(order, task.Name)
+		last = p
+	}
+
+	errTest := errors.New("test task")
+	run := func(err error) func(ctx context.Context, c *Client) error {
+		return func(ctx context.Context, c *Client) error { return err }
+	}
+
+	s.Add(Task{Name: "low", Priority: 0, Run: run(nil)})
+	s.Add(Task{Name: "high", Priority: 10, Run: run(nil)})
+	s.Add(Task{Name: "medium", Priority: 5, Run: run(errTest)})
+	s.Add(Task{Name: "high2", Priority: 10, Run: run(nil)})
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(context.Background()); err != ErrSchedulerStarted {
+		t.Fatalf("unexpected error for second Start: %v", err)
+	}
+	s.Close()
+
+	p := s.Wait()
+	if p.Total != 4 || p.Completed != 3 || p.Failed != 1 || p.Remaining() != 0 {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+	if last != p {
+		t.Fatalf("unexpected last reported progress: %+v != %+v", last, p)
+	}
+
+	want := []string{"high", "high2", "medium", "low"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("unexpected task order: %v != %v", order, want)
+		}
+	}
+}
+
+// TestSchedulerBudget verifies that a Scheduler spreads Tasks across each
+// Client's hourly budget, and stops when its context is canceled, failing
+// the Tasks which did not run.
+func TestSchedulerBudget(t *testing.T) {
+	c, done := testClient(t, nil)
+	defer done()
+
+	// One task per 50ms
+	s := NewScheduler(c)
+	s.Budget = 3600 * 20
+
+	var mu sync.Mutex
+	var times []time.Time
+	var canceled int
+	s.Progress = func(task *Task, err error, p SchedulerProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			canceled++
+		}
+	}
+	for i := 0; i < 10; i++ {
+		s.Add(Task{Run: func(ctx context.Context, c *Client) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			times = append(times, time.Now())
+			return nil
+		}})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+
+	if err := s.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	p := s.Wait()
+	if p.Completed < 2 || p.Completed > 4 {
+		t.Fatalf("unexpected number of completed tasks: %d", p.Completed)
+	}
+	if d := times[1].Sub(times[0]); d < 40*time.Millisecond {
+		t.Fatalf("tasks were not paced: %v", d)
+	}
+	if p.Remaining() != 0 || p.Failed != canceled || p.Completed+canceled != 10 {
+		t.Fatalf("unexpected progress after cancelation: %+v, %d canceled", p, canceled)
+	}
+}
+
+// TestSchedulerNoClients verifies that a Scheduler cannot be started without
+// any Clients.
+func TestSchedulerNoClients(t *testing.T) {
+	if err := NewScheduler().Start(context.Background()); err != ErrNoSchedulerClients {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package untappd
+
+// SearchQuery describes how the Untappd APIv4 interpreted a search query.
+// It is reported along with each page of search results.
+type SearchQuery struct {
+	// Search query as it was received by the API, and the term which was
+	// actually searched, such as "pliny*" for a wildcard search.
+	Term       string `json:"term"`
+	ParsedTerm string `json:"parsed_term"`
+
+	// Type of search performed, such as "wildcard" or "brewery", along
+	// with its numeric ID.
+	Type   string `json:"search_type"`
+	TypeID int    `json:"type_id"`
+}
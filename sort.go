@@ -1,5 +1,18 @@
 package untappd
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidSort is returned when a Sort is not accepted by an API
+	// endpoint.  Errors of type *InvalidSortError match ErrInvalidSort when
+	// using errors.Is.
+	ErrInvalidSort = errors.New("invalid sort")
+)
+
 // Sort is a sorting method accepted by the Untappd APIv4.
 // A set of Sort constants are provided for ease of use.
 type Sort string
@@ -32,8 +45,48 @@ const (
 
 	// SortLowestABV sorts a list of beers by lowest alcohol by volume on Untappd.
 	SortLowestABV Sort = "lowest_abv"
+
+	// SortName sorts a list of beers alphabetically by name.
+	SortName Sort = "name"
 )
 
+// WishListSorts returns a slice of the Sort constants which may be used to
+// sort a User's wish list.
+func WishListSorts() []Sort {
+	return []Sort{
+		SortDate,
+		SortCheckin,
+		SortHighestRated,
+		SortLowestRated,
+		SortHighestABV,
+		SortLowestABV,
+	}
+}
+
+// UserBeerSorts returns a slice of the Sort constants which may be used to
+// sort a User's checked-in beers.
+func UserBeerSorts() []Sort {
+	return []Sort{
+		SortDate,
+		SortCheckin,
+		SortHighestRated,
+		SortLowestRated,
+		SortUserHighestRated,
+		SortUserLowestRated,
+		SortHighestABV,
+		SortLowestABV,
+	}
+}
+
+// SearchSorts returns a slice of the Sort constants which may be used to sort
+// beer search results.
+func SearchSorts() []Sort {
+	return []Sort{
+		SortCheckin,
+		SortName,
+	}
+}
+
 // Sorts returns a slice of all available Sort constants.
 func Sorts() []Sort {
 	return []Sort{
@@ -45,5 +98,49 @@ func Sorts() []Sort {
 		SortUserLowestRated,
 		SortHighestABV,
 		SortLowestABV,
+		SortName,
+	}
+}
+
+// An InvalidSortError is returned when a Sort is not accepted by an API
+// endpoint.  The Untappd APIv4 silently ignores invalid sorts and returns
+// results in its default order, so sorts are validated before each request.
+type InvalidSortError struct {
+	// The invalid Sort, and the Sorts accepted by the endpoint.
+	Sort  Sort
+	Valid []Sort
+}
+
+// Error returns the string representation of an InvalidSortError.
+func (e *InvalidSortError) Error() string {
+	valid := make([]string, 0, len(e.Valid))
+	for _, s := range e.Valid {
+		valid = append(valid, string(s))
+	}
+
+	return fmt.Sprintf("invalid sort %q, must be one of: %s", e.Sort, strings.Join(valid, ", "))
+}
+
+// Is reports whether target is ErrInvalidSort or ErrInvalidParam.
+func (e *InvalidSortError) Is(target error) bool {
+	return target == ErrInvalidSort || target == ErrInvalidParam
+}
+
+// checkSort returns an *InvalidSortError if s is not one of the valid Sorts.
+// The empty Sort is always valid, and selects the API's default order.
+func checkSort(s Sort, valid []Sort) error {
+	if s == "" {
+		return nil
+	}
+
+	for _, v := range valid {
+		if s == v {
+			return nil
+		}
+	}
+
+	return &InvalidSortError{
+		Sort:  s,
+		Valid: valid,
 	}
 }
@@ -1,6 +1,10 @@
 package untappd
 
-import "testing"
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
 
 // TestSorts verifies that every Sort type is present in the output of Sorts.
 func TestSorts(t *testing.T) {
@@ -13,6 +17,7 @@ func TestSorts(t *testing.T) {
 		SortUserLowestRated,
 		SortHighestABV,
 		SortLowestABV,
+		SortName,
 	} {
 		var found bool
 		for _, ss := range Sorts() {
@@ -28,3 +33,72 @@ func TestSorts(t *testing.T) {
 		t.Fatalf("unknown Sort type: %q", s)
 	}
 }
+
+// Test_checkSort verifies that checkSort accepts only valid and empty sorts.
+func Test_checkSort(t *testing.T) {
+	var tests = []struct {
+		desc  string
+		sort  Sort
+		valid []Sort
+		ok    bool
+	}{
+		{
+			desc:  "empty",
+			valid: SearchSorts(),
+			ok:    true,
+		},
+		{
+			desc:  "valid",
+			sort:  SortName,
+			valid: SearchSorts(),
+			ok:    true,
+		},
+		{
+			desc:  "not valid for endpoint",
+			sort:  SortUserHighestRated,
+			valid: WishListSorts(),
+		},
+		{
+			desc:  "name not valid for user beers",
+			sort:  SortName,
+			valid: UserBeerSorts(),
+		},
+		{
+			desc:  "unknown",
+			sort:  Sort("foo"),
+			valid: Sorts(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := checkSort(tt.sort, tt.valid)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidSort) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestClientBeerSearchInvalidSort verifies that an invalid Sort is rejected
+// before a request is sent.
+func TestClientBeerSearchInvalidSort(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not be sent")
+	})
+	defer done()
+
+	_, _, err := c.Beer.SearchOffsetLimitSort("pliny", 0, 25, SortHighestABV)
+
+	var serr *InvalidSortError
+	if !errors.As(err, &serr) || serr.Sort != SortHighestABV {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
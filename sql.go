@@ -0,0 +1,150 @@
+package untappd
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Duration, Time, URL, and Bool implement sql.Scanner and driver.Valuer, so
+// that they may be stored in SQL databases directly.  IDs are int64 values,
+// and string types such as Style and BreweryType are converted by
+// database/sql automatically, so they require no conversion.
+
+// Value implements driver.Valuer.  A Duration is stored as an integer number
+// of nanoseconds.
+func (r Duration) Value() (driver.Value, error) {
+	return int64(r), nil
+}
+
+// Scan implements sql.Scanner.
+func (r *Duration) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = 0
+	case int64:
+		*r = Duration(v)
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into Duration: %v", v, err)
+		}
+		*r = Duration(n)
+	default:
+		return fmt.Errorf("cannot scan %T into Duration", src)
+	}
+
+	return nil
+}
+
+// Value implements driver.Valuer.  A Time is stored as a timestamp, or NULL if
+// it is the zero time.
+func (r Time) Value() (driver.Value, error) {
+	t := time.Time(r)
+	if t.IsZero() {
+		return nil, nil
+	}
+
+	return t, nil
+}
+
+// Scan implements sql.Scanner.  In addition to timestamps, strings are
+// accepted in RFC 3339 format, as stored by drivers such as SQLite's, or in
+// the format used by the Untappd APIv4.
+func (r *Time) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Time{}
+	case time.Time:
+		*r = Time(v)
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, time.RFC1123Z, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				*r = Time(t)
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot scan %q into Time", v)
+	default:
+		return fmt.Errorf("cannot scan %T into Time", src)
+	}
+
+	return nil
+}
+
+// Value implements driver.Valuer.  A URL is stored as a string, which is empty
+// for an empty URL.
+func (r URL) Value() (driver.Value, error) {
+	u := url.URL(r)
+	return u.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (r *URL) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into URL", src)
+	}
+
+	if s == "" {
+		*r = URL{}
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*r = URL(*u)
+	return nil
+}
+
+// Value implements driver.Valuer.  A Bool is stored as a boolean.
+func (r Bool) Value() (driver.Value, error) {
+	return bool(r), nil
+}
+
+// Scan implements sql.Scanner.  In addition to booleans, the integers 0 and 1
+// are accepted, as stored by drivers such as SQLite's.
+func (r *Bool) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = false
+	case bool:
+		*r = Bool(v)
+	case int64:
+		switch v {
+		case 0:
+			*r = false
+		case 1:
+			*r = true
+		default:
+			return errInvalidBool
+		}
+	case []byte:
+		return r.Scan(string(v))
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into Bool: %v", v, err)
+		}
+		*r = Bool(b)
+	default:
+		return fmt.Errorf("cannot scan %T into Bool", src)
+	}
+
+	return nil
+}
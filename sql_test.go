@@ -0,0 +1,75 @@
+package untappd
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSQLRoundTrip verifies that Duration, Time, URL, and Bool values can be
+// scanned from the values they produce.
+func TestSQLRoundTrip(t *testing.T) {
+	u, err := url.Parse("https://untappd.com/user/mdlayher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC)
+
+	var tests = []struct {
+		desc string
+		in   driver.Valuer
+		out  sql.Scanner
+	}{
+		{desc: "Duration", in: Duration(1500 * time.Millisecond), out: new(Duration)},
+		{desc: "Time", in: Time(now), out: new(Time)},
+		{desc: "zero Time", in: Time{}, out: new(Time)},
+		{desc: "URL", in: URL(*u), out: new(URL)},
+		{desc: "empty URL", in: URL{}, out: new(URL)},
+		{desc: "Bool", in: Bool(true), out: new(Bool)},
+	}
+
+	for _, tt := range tests {
+		v, err := tt.in.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tt.out.Scan(v); err != nil {
+			t.Fatalf("[%s] unexpected error: %v", tt.desc, err)
+		}
+
+		if got := reflect.ValueOf(tt.out).Elem().Interface(); !reflect.DeepEqual(got, tt.in) {
+			t.Fatalf("[%s] unexpected scanned value: %+v != %+v", tt.desc, got, tt.in)
+		}
+	}
+}
+
+// TestSQLScanDriverValues verifies that values stored by drivers which lack
+// native timestamp or boolean types can be scanned.
+func TestSQLScanDriverValues(t *testing.T) {
+	var tm Time
+	if err := tm.Scan([]byte("2016-05-21T00:15:40Z")); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC); !time.Time(tm).Equal(want) {
+		t.Fatalf("unexpected Time: %v != %v", time.Time(tm), want)
+	}
+	if err := tm.Scan("foo"); err == nil {
+		t.Fatal("expected an error for an invalid timestamp")
+	}
+
+	var b Bool
+	if err := b.Scan(int64(1)); err != nil || !b {
+		t.Fatalf("unexpected Bool for 1: %v, %v", b, err)
+	}
+	if err := b.Scan(int64(2)); err != errInvalidBool {
+		t.Fatalf("unexpected error for 2: %v", err)
+	}
+
+	var d Duration
+	if err := d.Scan(1.5); err == nil {
+		t.Fatal("expected an error for a float Duration")
+	}
+}
@@ -0,0 +1,261 @@
+package untappd
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A StatsHandler receives metrics about the requests performed by a Client,
+// so that they may be exported to any metrics system.  Adapters are provided
+// for expvar, using NewExpvarStats, and StatsD, using NewStatsDStats.  Other
+// systems, such as OpenCensus, may be wired using StatsFuncs.
+//
+// Methods may be invoked concurrently by multiple goroutines, and should
+// return quickly, because they are invoked while a request is in progress.
+type StatsHandler interface {
+	// ObserveRequest is invoked once for each request sent to the Untappd
+	// APIv4, after its response is received, including any retries.
+	ObserveRequest(ctx context.Context, s RequestStats)
+
+	// ObserveRetry is invoked before a failed request is retried.
+	ObserveRetry(ctx context.Context, s RetryStats)
+
+	// ObserveCacheHit is invoked when a request is served from a Client's
+	// response cache, and is not sent.
+	ObserveCacheHit(ctx context.Context, s CacheStats)
+}
+
+// RequestStats describes a single request sent to the Untappd APIv4.
+type RequestStats struct {
+	// Service and API endpoint of the request, such as ServiceBeer and
+	// "beer/info/1".  Endpoints include IDs and usernames, so Service is
+	// better suited to labeling metrics.
+	Service  Service
+	Endpoint string
+	Method   string
+
+	// HTTP status code of the final response, or zero if the request
+	// failed with a network error, and the error.
+	StatusCode int
+	Err        error
+
+	// Total duration of the request, including retries and the waits
+	// between them, and the number of retries performed.
+	Duration time.Duration
+	Retries  int
+}
+
+// RetryStats describes a retry of a failed request.
+type RetryStats struct {
+	// Service and API endpoint of the request, as in RequestStats.
+	Service  Service
+	Endpoint string
+
+	// Number of the retry, starting at 1, the HTTP status code or network
+	// error which caused the previous attempt to fail, and the backoff
+	// delay before the retry.
+	Attempt    int
+	StatusCode int
+	Err        error
+	Wait       time.Duration
+}
+
+// CacheStats describes a request served from a Client's response cache.
+type CacheStats struct {
+	// Service and API endpoint of the request, as in RequestStats.
+	Service  Service
+	Endpoint string
+}
+
+// WithStatsHandler sets a StatsHandler which receives metrics about each
+// request performed by a Client.
+func WithStatsHandler(h StatsHandler) ClientOption {
+	return func(c *clientConfig) error {
+		c.stats = h
+		return nil
+	}
+}
+
+// StatsFuncs is a StatsHandler which invokes a function for each kind of
+// observation.  Nil functions are ignored.
+type StatsFuncs struct {
+	Request  func(ctx context.Context, s RequestStats)
+	Retry    func(ctx context.Context, s RetryStats)
+	CacheHit func(ctx context.Context, s CacheStats)
+}
+
+var _ StatsHandler = StatsFuncs{}
+
+// ObserveRequest implements StatsHandler.
+func (f StatsFuncs) ObserveRequest(ctx context.Context, s RequestStats) {
+	if f.Request != nil {
+		f.Request(ctx, s)
+	}
+}
+
+// ObserveRetry implements StatsHandler.
+func (f StatsFuncs) ObserveRetry(ctx context.Context, s RetryStats) {
+	if f.Retry != nil {
+		f.Retry(ctx, s)
+	}
+}
+
+// ObserveCacheHit implements StatsHandler.
+func (f StatsFuncs) ObserveCacheHit(ctx context.Context, s CacheStats) {
+	if f.CacheHit != nil {
+		f.CacheHit(ctx, s)
+	}
+}
+
+// MultiStats returns a StatsHandler which passes each observation to all of
+// the input StatsHandlers, in order.
+func MultiStats(handlers ...StatsHandler) StatsHandler {
+	return multiStats(append([]StatsHandler(nil), handlers...))
+}
+
+// multiStats is the StatsHandler returned by MultiStats.
+type multiStats []StatsHandler
+
+// ObserveRequest implements StatsHandler.
+func (m multiStats) ObserveRequest(ctx context.Context, s RequestStats) {
+	for _, h := range m {
+		h.ObserveRequest(ctx, s)
+	}
+}
+
+// ObserveRetry implements StatsHandler.
+func (m multiStats) ObserveRetry(ctx context.Context, s RetryStats) {
+	for _, h := range m {
+		h.ObserveRetry(ctx, s)
+	}
+}
+
+// ObserveCacheHit implements StatsHandler.
+func (m multiStats) ObserveCacheHit(ctx context.Context, s CacheStats) {
+	for _, h := range m {
+		h.ObserveCacheHit(ctx, s)
+	}
+}
+
+// NewExpvarStats returns a StatsHandler which records counters in the input
+// expvar.Map, typically created using expvar.NewMap.  The map contains:
+//
+//   - requests, errors, retries, and cache_hits: totals of each
+//   - request_seconds: the total duration of all requests
+//   - requests_by_service and requests_by_status: maps of request totals,
+//     keyed by Service and by HTTP status code
+//
+// A request is counted as an error if it failed with a network error or
+// received a status code other than 2xx.
+func NewExpvarStats(m *expvar.Map) StatsHandler {
+	byService, byStatus := new(expvar.Map).Init(), new(expvar.Map).Init()
+	seconds := new(expvar.Float)
+
+	m.Set("requests_by_service", byService)
+	m.Set("requests_by_status", byStatus)
+	m.Set("request_seconds", seconds)
+
+	return &expvarStats{
+		m:         m,
+		byService: byService,
+		byStatus:  byStatus,
+		seconds:   seconds,
+	}
+}
+
+// expvarStats is the StatsHandler returned by NewExpvarStats.
+type expvarStats struct {
+	m, byService, byStatus *expvar.Map
+	seconds                *expvar.Float
+}
+
+// ObserveRequest implements StatsHandler.
+func (e *expvarStats) ObserveRequest(_ context.Context, s RequestStats) {
+	e.m.Add("requests", 1)
+	if statsFailed(s) {
+		e.m.Add("errors", 1)
+	}
+
+	e.byService.Add(string(s.Service), 1)
+	e.byStatus.Add(fmt.Sprint(s.StatusCode), 1)
+	e.seconds.Add(s.Duration.Seconds())
+}
+
+// ObserveRetry implements StatsHandler.
+func (e *expvarStats) ObserveRetry(_ context.Context, _ RetryStats) {
+	e.m.Add("retries", 1)
+}
+
+// ObserveCacheHit implements StatsHandler.
+func (e *expvarStats) ObserveCacheHit(_ context.Context, _ CacheStats) {
+	e.m.Add("cache_hits", 1)
+}
+
+// NewStatsDStats returns a StatsHandler which writes metrics to w in the
+// StatsD line protocol, with each metric name beginning with prefix, such
+// as "untappd".  w is typically a UDP connection to a StatsD server, created
+// using net.Dial.  Each metric is written using a single call to Write, so
+// that each is sent as its own datagram.  Write errors are ignored.
+//
+// For each request, the counter PREFIX.requests.SERVICE, the counter
+// PREFIX.errors.SERVICE if the request failed, and the timer
+// PREFIX.request_duration.SERVICE are written.  The counters
+// PREFIX.retries.SERVICE and PREFIX.cache_hits.SERVICE are written for each
+// retry and cache hit.
+func NewStatsDStats(w io.Writer, prefix string) StatsHandler {
+	return &statsDStats{
+		w:      w,
+		prefix: strings.TrimSuffix(prefix, "."),
+	}
+}
+
+// statsDStats is the StatsHandler returned by NewStatsDStats.
+type statsDStats struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+}
+
+// ObserveRequest implements StatsHandler.
+func (s *statsDStats) ObserveRequest(_ context.Context, rs RequestStats) {
+	s.write("requests", rs.Service, "1|c")
+	if statsFailed(rs) {
+		s.write("errors", rs.Service, "1|c")
+	}
+	s.write("request_duration", rs.Service, fmt.Sprintf("%d|ms", rs.Duration/time.Millisecond))
+}
+
+// ObserveRetry implements StatsHandler.
+func (s *statsDStats) ObserveRetry(_ context.Context, rs RetryStats) {
+	s.write("retries", rs.Service, "1|c")
+}
+
+// ObserveCacheHit implements StatsHandler.
+func (s *statsDStats) ObserveCacheHit(_ context.Context, cs CacheStats) {
+	s.write("cache_hits", cs.Service, "1|c")
+}
+
+// write writes a single StatsD metric for the input Service.
+func (s *statsDStats) write(name string, service Service, value string) {
+	line := fmt.Sprintf("%s.%s.%s:%s\n", s.prefix, name, service, value)
+	if s.prefix == "" {
+		line = line[1:]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _ = io.WriteString(s.w, line)
+}
+
+// statsFailed reports whether a request failed with a network error or a
+// status code other than 2xx.
+func statsFailed(s RequestStats) bool {
+	return s.Err != nil || s.StatusCode < http.StatusOK || s.StatusCode > 299
+}
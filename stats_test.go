@@ -0,0 +1,102 @@
+package untappd
+
+import (
+	"bytes"
+	"context"
+	"expvar"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClientStatsHandler verifies that a StatsHandler observes each request,
+// retry, and cache hit, and that the expvar and StatsD adapters record them.
+func TestClientStatsHandler(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write(apiErrJSON)
+			return
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	var (
+		reqs    []RequestStats
+		retries []RetryStats
+		hits    []CacheStats
+	)
+	funcs := StatsFuncs{
+		Request:  func(_ context.Context, s RequestStats) { reqs = append(reqs, s) },
+		Retry:    func(_ context.Context, s RetryStats) { retries = append(retries, s) },
+		CacheHit: func(_ context.Context, s CacheStats) { hits = append(hits, s) },
+	}
+
+	m := new(expvar.Map).Init()
+	var statsd bytes.Buffer
+
+	applyTestOptions(t, c,
+		WithServicePolicy(ServiceBeer, ServicePolicy{
+			Retries:   1,
+			RetryWait: time.Millisecond,
+			CacheTTL:  time.Hour,
+		}),
+		WithStatsHandler(MultiStats(funcs, NewExpvarStats(m), NewStatsDStats(&statsd, "untappd."))),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.request("GET", "beer/info/1", nil, nil, &struct{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if l := len(reqs); l != 1 {
+		t.Fatalf("unexpected number of observed requests: %d != %d", l, 1)
+	}
+	if s := reqs[0]; s.Service != ServiceBeer || s.Endpoint != "beer/info/1" || s.Method != "GET" ||
+		s.StatusCode != http.StatusOK || s.Err != nil || s.Retries != 1 || s.Duration <= 0 {
+		t.Fatalf("unexpected request stats: %+v", s)
+	}
+	if l := len(retries); l != 1 {
+		t.Fatalf("unexpected number of observed retries: %d != %d", l, 1)
+	}
+	if s := retries[0]; s.Service != ServiceBeer || s.Attempt != 1 || s.StatusCode != http.StatusServiceUnavailable || s.Wait != time.Millisecond {
+		t.Fatalf("unexpected retry stats: %+v", s)
+	}
+	if l := len(hits); l != 1 || hits[0].Service != ServiceBeer || hits[0].Endpoint != "beer/info/1" {
+		t.Fatalf("unexpected cache hits: %+v", hits)
+	}
+
+	for _, k := range []string{"requests", "retries", "cache_hits"} {
+		if v := m.Get(k); v == nil || v.String() != "1" {
+			t.Fatalf("unexpected expvar %q: %v", k, v)
+		}
+	}
+	if v := m.Get("errors"); v != nil {
+		t.Fatalf("unexpected expvar errors: %v", v)
+	}
+	if v := m.Get("requests_by_status").(*expvar.Map).Get("200"); v == nil || v.String() != "1" {
+		t.Fatalf("unexpected expvar requests by status: %v", m.Get("requests_by_status"))
+	}
+
+	want := []string{
+		"untappd.retries.beer:1|c",
+		"untappd.requests.beer:1|c",
+		"untappd.request_duration.beer:",
+		"untappd.cache_hits.beer:1|c",
+	}
+	lines := strings.Split(strings.TrimSpace(statsd.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected StatsD metrics:\n%s", statsd.String())
+	}
+	for i := range want {
+		if !strings.HasPrefix(lines[i], want[i]) {
+			t.Fatalf("unexpected StatsD metric %d: %q != %q", i, lines[i], want[i])
+		}
+	}
+}
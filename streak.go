@@ -0,0 +1,142 @@
+package untappd
+
+import (
+	"sort"
+	"time"
+)
+
+// A Streak is a run of consecutive days or weeks, each with at least one
+// checkin.
+type Streak struct {
+	// Number of consecutive days or weeks, and the number of checkins
+	// within them.  Length is zero if there is no streak.
+	Length   int
+	Checkins int
+
+	// First and last day of the streak, or the Mondays which begin its first
+	// and last weeks, at midnight.
+	Start time.Time
+	End   time.Time
+}
+
+// Streaks contains the current and longest daily and weekly checkin streaks
+// of a set of checkins, as returned by NewStreaks.
+type Streaks struct {
+	// Current streaks, which include the current day or week, or the
+	// previous one, and so may still be extended.  If the most recent
+	// checkin is older, the current streak is the zero Streak.
+	CurrentDaily  Streak
+	CurrentWeekly Streak
+
+	// Longest streaks.  If streaks are tied, the most recent is reported.
+	LongestDaily  Streak
+	LongestWeekly Streak
+}
+
+// NewStreaks computes the daily and weekly checkin streaks of the input
+// checkins, relative to the current time now.  Weeks begin on Monday.
+//
+// Days are computed using the time of each checkin in loc, and now is
+// converted to loc first.  If loc is nil, the time of each checkin is used as
+// is.  The Untappd APIv4 reports all checkin times in UTC, so with a nil loc,
+// days are UTC days, rather than days in the User's local time.  Returned
+// days are at midnight in loc, or in the location of now if loc is nil.  Nil
+// checkins are ignored.
+func NewStreaks(checkins []*Checkin, now time.Time, loc *time.Location) *Streaks {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	out := now.Location()
+
+	days := make(map[int64]int)
+	weeks := make(map[int64]int)
+	for _, c := range checkins {
+		if c == nil {
+			continue
+		}
+
+		t := c.Created
+		if loc != nil {
+			t = t.In(loc)
+		}
+
+		d := dayNumber(t)
+		days[d]++
+		weeks[weekNumber(d)]++
+	}
+
+	// Report each day at midnight in the output location
+	date := func(day int64) time.Time {
+		t := time.Unix(day*secondsPerDay, 0).UTC()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, out)
+	}
+	monday := func(week int64) time.Time {
+		return date(week*7 + 4)
+	}
+
+	today := dayNumber(now)
+
+	var s Streaks
+	s.CurrentDaily, s.LongestDaily = streaks(days, today, date)
+	s.CurrentWeekly, s.LongestWeekly = streaks(weeks, weekNumber(today), monday)
+	return &s
+}
+
+// streaks computes the current and longest streaks from counts of checkins
+// in each period, numbered consecutively, where the current period is now,
+// and date returns the first day of a period.
+func streaks(counts map[int64]int, now int64, date func(p int64) time.Time) (current, longest Streak) {
+	periods := make([]int64, 0, len(counts))
+	for p := range counts {
+		periods = append(periods, p)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i] < periods[j] })
+
+	var run Streak
+	for i, p := range periods {
+		if i == 0 || p != periods[i-1]+1 {
+			run = Streak{Start: date(p)}
+		}
+		run.Length++
+		run.Checkins += counts[p]
+		run.End = date(p)
+
+		if run.Length >= longest.Length {
+			longest = run
+		}
+	}
+
+	// The final run is current if it reaches the current or previous period
+	if n := len(periods); n > 0 && periods[n-1] >= now-1 {
+		current = run
+	}
+
+	return current, longest
+}
+
+// secondsPerDay is the number of seconds in a day, ignoring leap seconds and
+// daylight saving time, as used to number days.
+const secondsPerDay = 24 * 60 * 60
+
+// dayNumber returns the number of days since January 1, 1970, of the date of
+// t in its own location.
+func dayNumber(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+}
+
+// weekNumber returns the number of the week containing the input day number,
+// where weeks begin on Monday.
+func weekNumber(day int64) int64 {
+	// January 1, 1970, was a Thursday, so day 4 was a Monday
+	return floorDiv(day-4, 7)
+}
+
+// floorDiv returns a divided by b, rounded down.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
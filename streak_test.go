@@ -0,0 +1,89 @@
+package untappd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestNewStreaks verifies that NewStreaks computes current and longest daily
+// and weekly streaks.
+func TestNewStreaks(t *testing.T) {
+	at := func(month time.Month, day int, hour int) *Checkin {
+		return &Checkin{Created: time.Date(2016, month, day, hour, 0, 0, 0, time.UTC)}
+	}
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2016, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	checkins := []*Checkin{
+		// Three days, in the week of Monday, May 2
+		at(time.May, 2, 12),
+		at(time.May, 3, 12),
+		at(time.May, 3, 20),
+		at(time.May, 4, 12),
+		// Two days, in the weeks of May 9 and May 16
+		at(time.May, 15, 12),
+		at(time.May, 16, 12),
+		nil,
+		// One day, in the week of May 23
+		at(time.May, 27, 12),
+	}
+
+	var tests = []struct {
+		desc string
+		now  time.Time
+		s    *Streaks
+	}{
+		{
+			desc: "current",
+			now:  time.Date(2016, time.May, 28, 9, 0, 0, 0, time.UTC),
+			s: &Streaks{
+				CurrentDaily:  Streak{Length: 1, Checkins: 1, Start: date(time.May, 27), End: date(time.May, 27)},
+				CurrentWeekly: Streak{Length: 4, Checkins: 7, Start: date(time.May, 2), End: date(time.May, 23)},
+				LongestDaily:  Streak{Length: 3, Checkins: 4, Start: date(time.May, 2), End: date(time.May, 4)},
+				LongestWeekly: Streak{Length: 4, Checkins: 7, Start: date(time.May, 2), End: date(time.May, 23)},
+			},
+		},
+		{
+			desc: "lapsed",
+			now:  time.Date(2016, time.June, 7, 9, 0, 0, 0, time.UTC),
+			s: &Streaks{
+				LongestDaily:  Streak{Length: 3, Checkins: 4, Start: date(time.May, 2), End: date(time.May, 4)},
+				LongestWeekly: Streak{Length: 4, Checkins: 7, Start: date(time.May, 2), End: date(time.May, 23)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if s := NewStreaks(checkins, tt.now, nil); !reflect.DeepEqual(tt.s, s) {
+			t.Fatalf("[%s] unexpected Streaks:\n- want: %+v\n-  got: %+v", tt.desc, tt.s, s)
+		}
+	}
+}
+
+// TestNewStreaksLocation verifies that NewStreaks computes days in the
+// input location, if one is specified.
+func TestNewStreaksLocation(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+
+	// Late evening checkins on consecutive days in EST are reported on
+	// the following days in UTC
+	checkins := []*Checkin{
+		{Created: time.Date(2016, time.May, 3, 2, 0, 0, 0, time.UTC)},
+		{Created: time.Date(2016, time.May, 3, 23, 0, 0, 0, time.UTC)},
+	}
+	now := time.Date(2016, time.May, 4, 0, 0, 0, 0, time.UTC)
+
+	if l := NewStreaks(checkins, now, nil).LongestDaily.Length; l != 1 {
+		t.Fatalf("unexpected UTC streak length: %d != %d", l, 1)
+	}
+
+	s := NewStreaks(checkins, now, est)
+	if l := s.CurrentDaily.Length; l != 2 {
+		t.Fatalf("unexpected EST streak length: %d != %d", l, 2)
+	}
+	if start := time.Date(2016, time.May, 2, 0, 0, 0, 0, est); !s.CurrentDaily.Start.Equal(start) {
+		t.Fatalf("unexpected EST streak start: %v != %v", s.CurrentDaily.Start, start)
+	}
+}
@@ -0,0 +1,116 @@
+package untappd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns a human-readable description of a Beer, with its brewery,
+// name, style, and ABV, such as:
+//
+//	Bell's Brewery — Two Hearted Ale (IPA - American, 7%)
+func (b *Beer) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+
+	var sb strings.Builder
+	if b.Brewery != nil && b.Brewery.Name != "" {
+		sb.WriteString(b.Brewery.Name)
+		sb.WriteString(" — ")
+	}
+	sb.WriteString(b.Name)
+
+	sb.WriteString(" (")
+	if b.Style != "" {
+		sb.WriteString(b.Style)
+		sb.WriteString(", ")
+	}
+	sb.WriteString(strconv.FormatFloat(b.ABV, 'f', -1, 64))
+	sb.WriteString("%)")
+
+	return sb.String()
+}
+
+// String returns a human-readable description of a Checkin, with its user,
+// beer, venue, and rating, such as:
+//
+//	mdlayher: Bell's Brewery — Two Hearted Ale (IPA - American, 7%) at Eccentric Cafe, rated 4.5
+func (c *Checkin) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+
+	var sb strings.Builder
+	if c.User != nil && c.User.UserName != "" {
+		sb.WriteString(c.User.UserName)
+		sb.WriteString(": ")
+	}
+
+	switch {
+	case c.Beer != nil:
+		b := c.Beer
+		if b.Brewery == nil && c.Brewery != nil {
+			// Checkins report the brewery separately from the beer
+			bb := *b
+			bb.Brewery = c.Brewery
+			b = &bb
+		}
+		sb.WriteString(b.String())
+	default:
+		fmt.Fprintf(&sb, "checkin %d", c.ID)
+	}
+
+	if c.Venue != nil && c.Venue.Name != "" {
+		sb.WriteString(" at ")
+		sb.WriteString(c.Venue.Name)
+	}
+	if c.UserRating > 0 {
+		sb.WriteString(", rated ")
+		sb.WriteString(strconv.FormatFloat(c.UserRating, 'f', -1, 64))
+	}
+
+	return sb.String()
+}
+
+// String returns a human-readable description of a Venue, with its name and
+// location, such as:
+//
+//	Eccentric Cafe (Kalamazoo, MI)
+func (v *Venue) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var place []string
+	for _, s := range []string{v.Location.City, v.Location.State} {
+		if s != "" {
+			place = append(place, s)
+		}
+	}
+	if len(place) == 0 {
+		return v.Name
+	}
+
+	return fmt.Sprintf("%s (%s)", v.Name, strings.Join(place, ", "))
+}
+
+// String returns a human-readable description of a Badge, with its name and
+// level, if applicable, such as:
+//
+//	Untappd Veteran (level 5 of 50)
+func (b *Badge) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+
+	switch {
+	case !b.IsLevel || b.Level == 0:
+		return b.Name
+	case b.TotalLevels == 0:
+		return fmt.Sprintf("%s (level %d)", b.Name, b.Level)
+	default:
+		return fmt.Sprintf("%s (level %d of %d)", b.Name, b.Level, b.TotalLevels)
+	}
+}
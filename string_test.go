@@ -0,0 +1,91 @@
+package untappd
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestString verifies the human-readable descriptions of response structs.
+func TestString(t *testing.T) {
+	beer := &Beer{
+		Name:    "Two Hearted Ale",
+		Style:   "IPA - American",
+		ABV:     7,
+		Brewery: &Brewery{Name: "Bell's Brewery"},
+	}
+	venue := &Venue{
+		Name:     "Eccentric Cafe",
+		Location: VenueLocation{City: "Kalamazoo", State: "MI"},
+	}
+
+	var tests = []struct {
+		desc string
+		v    fmt.Stringer
+		s    string
+	}{
+		{
+			desc: "beer",
+			v:    beer,
+			s:    "Bell's Brewery — Two Hearted Ale (IPA - American, 7%)",
+		},
+		{
+			desc: "beer without brewery or style",
+			v:    &Beer{Name: "Oberon", ABV: 5.8},
+			s:    "Oberon (5.8%)",
+		},
+		{
+			desc: "checkin",
+			v: &Checkin{
+				User:       &User{UserName: "mdlayher"},
+				Beer:       beer,
+				Venue:      venue,
+				UserRating: 4.5,
+			},
+			s: "mdlayher: Bell's Brewery — Two Hearted Ale (IPA - American, 7%) at Eccentric Cafe, rated 4.5",
+		},
+		{
+			desc: "checkin with separate brewery",
+			v: &Checkin{
+				Beer:    &Beer{Name: "Oberon", ABV: 5.8},
+				Brewery: &Brewery{Name: "Bell's Brewery"},
+			},
+			s: "Bell's Brewery — Oberon (5.8%)",
+		},
+		{
+			desc: "checkin without beer",
+			v:    &Checkin{ID: 1},
+			s:    "checkin 1",
+		},
+		{
+			desc: "venue",
+			v:    venue,
+			s:    "Eccentric Cafe (Kalamazoo, MI)",
+		},
+		{
+			desc: "venue without location",
+			v:    &Venue{Name: "Eccentric Cafe"},
+			s:    "Eccentric Cafe",
+		},
+		{
+			desc: "badge",
+			v:    &Badge{Name: "Newbie"},
+			s:    "Newbie",
+		},
+		{
+			desc: "level badge",
+			v:    &Badge{Name: "Untappd Veteran", IsLevel: true, Level: 5, TotalLevels: 50},
+			s:    "Untappd Veteran (level 5 of 50)",
+		},
+		{
+			desc: "nil beer",
+			v:    (*Beer)(nil),
+			s:    "<nil>",
+		},
+	}
+
+	for _, tt := range tests {
+		if s := tt.v.String(); s != tt.s {
+			t.Fatalf("[%s] unexpected string:\n- want: %q\n-  got: %q", tt.desc, tt.s, s)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package untappd
+
+import (
+	"strings"
+)
+
+// Style is a normalized family of beer styles, parsed from the style names
+// reported by the Untappd APIv4.  Untappd occasionally renames its styles,
+// such as "American IPA" becoming "IPA - American", so a Style constant
+// should be used to compare styles rather than a style name.
+type Style string
+
+// Style constants for common families of beer styles.  The zero value
+// indicates that no style was reported.
+const (
+	StyleIPA          Style = "ipa"
+	StylePaleAle      Style = "pale_ale"
+	StyleStout        Style = "stout"
+	StylePorter       Style = "porter"
+	StyleSour         Style = "sour"
+	StyleWheat        Style = "wheat"
+	StylePilsner      Style = "pilsner"
+	StyleLager        Style = "lager"
+	StyleSaison       Style = "saison"
+	StyleBelgian      Style = "belgian"
+	StyleBarleywine   Style = "barleywine"
+	StyleBrownAle     Style = "brown_ale"
+	StyleRedAle       Style = "red_ale"
+	StyleCider        Style = "cider"
+	StyleMead         Style = "mead"
+	StyleHardSeltzer  Style = "hard_seltzer"
+	StyleNonAlcoholic Style = "non_alcoholic"
+
+	// StyleOther is used for style names which do not belong to any of the
+	// other families.
+	StyleOther Style = "other"
+)
+
+// styleKeywords maps keywords found in Untappd style names to their Style.
+// Keywords are checked in order, so that more specific families take
+// precedence, e.g. "Non-Alcoholic Beer - IPA" and "Wheat Beer - Dunkelweizen".
+var styleKeywords = []struct {
+	keyword string
+	style   Style
+}{
+	{"non-alcoholic", StyleNonAlcoholic},
+	{"cider", StyleCider},
+	{"mead", StyleMead},
+	{"seltzer", StyleHardSeltzer},
+	{"ipa", StyleIPA},
+	{"stout", StyleStout},
+	{"porter", StylePorter},
+	{"sour", StyleSour},
+	{"gose", StyleSour},
+	{"lambic", StyleSour},
+	{"berliner", StyleSour},
+	{"flanders", StyleSour},
+	{"wild ale", StyleSour},
+	{"wheat", StyleWheat},
+	{"weizen", StyleWheat},
+	{"witbier", StyleWheat},
+	{"pilsner", StylePilsner},
+	{"pilsener", StylePilsner},
+	{"lager", StyleLager},
+	{"bock", StyleLager},
+	{"helles", StyleLager},
+	{"märzen", StyleLager},
+	{"marzen", StyleLager},
+	{"saison", StyleSaison},
+	{"farmhouse", StyleSaison},
+	{"barleywine", StyleBarleywine},
+	{"barley wine", StyleBarleywine},
+	{"pale ale", StylePaleAle},
+	{"brown ale", StyleBrownAle},
+	{"red ale", StyleRedAle},
+	{"amber", StyleRedAle},
+	{"belgian", StyleBelgian},
+	{"tripel", StyleBelgian},
+	{"dubbel", StyleBelgian},
+	{"quadrupel", StyleBelgian},
+}
+
+// ParseStyle returns the Style family of the input Untappd style name, such
+// as StyleIPA for "IPA - American".  If name is empty, the zero value is
+// returned.  If name does not belong to any known family, StyleOther is
+// returned.
+func ParseStyle(name string) Style {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+
+	for _, k := range styleKeywords {
+		if strings.Contains(name, k.keyword) {
+			return k.style
+		}
+	}
+
+	return StyleOther
+}
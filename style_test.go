@@ -0,0 +1,36 @@
+package untappd
+
+import "testing"
+
+// TestParseStyle verifies that both current and legacy Untappd style names
+// are normalized to the same Style.
+func TestParseStyle(t *testing.T) {
+	var tests = []struct {
+		name  string
+		style Style
+	}{
+		{"", ""},
+		{"American IPA", StyleIPA},
+		{"IPA - American", StyleIPA},
+		{"Imperial / Double IPA", StyleIPA},
+		{"American Imperial / Double Stout", StyleStout},
+		{"Stout - Imperial / Double", StyleStout},
+		{"American Pale Wheat Ale", StyleWheat},
+		{"Wheat Beer - Dunkelweizen", StyleWheat},
+		{"Pale Ale - American", StylePaleAle},
+		{"Sour - Gose", StyleSour},
+		{"Lager - Helles", StyleLager},
+		{"Pilsner - German", StylePilsner},
+		{"Farmhouse Ale - Saison", StyleSaison},
+		{"Belgian Tripel", StyleBelgian},
+		{"Non-Alcoholic Beer - IPA", StyleNonAlcoholic},
+		{"Cider - Dry", StyleCider},
+		{"Kvass", StyleOther},
+	}
+
+	for _, tt := range tests {
+		if s := ParseStyle(tt.name); s != tt.style {
+			t.Fatalf("unexpected Style for %q: %q != %q", tt.name, s, tt.style)
+		}
+	}
+}
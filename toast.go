@@ -8,32 +8,43 @@ import (
 // regarding the toast, and the User who performed the toast.
 type Toast struct {
 	// Metadata from Untappd.
-	ID     int
-	UserID int
+	ID     int64
+	UserID int64
 
 	// Time when this toast was submitted to Untappd.
 	Created time.Time
 
-	// The user who performed the Toast.
+	// If applicable, was this toast performed by the authenticated user?
+	Owner bool
+
+	// The user who performed the Toast.  May be nil if no user information
+	// was returned.
 	User *User
 }
 
 // rawToast is the raw JSON representation of an Untappd toast.  Its data is
 // unmarshaled from JSON and then exported to a Toast struct.
 type rawToast struct {
-	ID      int          `json:"like_id"`
-	UserID  int          `json:"uid"`
-	Created responseTime `json:"created_at"`
-	User    *rawUser     `json:"user"`
+	ID      int64    `json:"like_id"`
+	UserID  int64    `json:"uid"`
+	Created Time     `json:"created_at"`
+	Owner   bool     `json:"like_owner"`
+	User    *rawUser `json:"user"`
 }
 
 // export creates an exported Toast from a rawToast struct, allowing for more
 // useful structures to be created for client consumption.
 func (r *rawToast) export() *Toast {
-	return &Toast{
+	t := &Toast{
 		ID:      r.ID,
 		UserID:  r.UserID,
 		Created: time.Time(r.Created),
-		User:    r.User.export(),
+		Owner:   r.Owner,
+	}
+
+	if r.User != nil {
+		t.User = r.User.export()
 	}
+
+	return t
 }
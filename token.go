@@ -0,0 +1,31 @@
+package untappd
+
+import (
+	"context"
+)
+
+// tokenKey is the context key used to store an access token which overrides
+// a Client's credentials.
+type tokenKey struct{}
+
+// WithToken returns a copy of the input context which carries an Untappd
+// APIv4 access token.  Requests made by a Client using the returned context
+// are authenticated with the token, in place of the Client's credentials.
+// This enables a single Client to be shared by many users, such as in a web
+// server which performs requests on behalf of each of its users.
+//
+// WithToken applies to methods which accept a context, such as
+// UserService.AllCheckins, and to Tasks performed by a Scheduler.  Rate
+// limit information reported for requests made with the token is not
+// recorded by the Client, because it belongs to a different set of
+// credentials.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// tokenFromContext returns the access token stored in a context by
+// WithToken, if any.
+func tokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok && token != ""
+}
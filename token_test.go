@@ -0,0 +1,46 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestWithToken verifies that an access token from WithToken overrides a
+// Client's credentials, and that rate limit information for the token is
+// not recorded.
+func TestWithToken(t *testing.T) {
+	var queries []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		w.Header().Set(headerRateLimitRemaining, "10")
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	ctx := WithToken(context.Background(), "baz")
+	if _, err := c.requestContext(ctx, "GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rl := c.RateLimit(); !rl.Updated.IsZero() {
+		t.Fatalf("unexpected rate limit recorded for context token: %+v", rl)
+	}
+
+	if _, err := c.request("GET", "foo", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rl := c.RateLimit(); rl.Remaining != 10 {
+		t.Fatalf("unexpected rate limit for client credentials: %+v", rl)
+	}
+
+	want := []string{
+		"access_token=baz",
+		"client_id=foo&client_secret=bar",
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Fatalf("unexpected query for request %d: %q != %q", i, queries[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package untappd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	// ErrNoPassphrase is returned when an EncryptedFileTokenStore is used
+	// without a passphrase.
+	ErrNoPassphrase = errors.New("no passphrase for encrypted token store")
+
+	// ErrTokenDecrypt is returned when a token cannot be decrypted by an
+	// EncryptedFileTokenStore, due to an incorrect passphrase or a corrupted
+	// file.
+	ErrTokenDecrypt = errors.New("cannot decrypt token: incorrect passphrase or corrupted file")
+)
+
+const (
+	// DefaultTokenStoreIterations is the number of PBKDF2 iterations used
+	// by an EncryptedFileTokenStore to derive a key from its passphrase,
+	// if no other number is specified.
+	DefaultTokenStoreIterations = 600000
+
+	// MaxTokenStoreIterations is the maximum number of PBKDF2 iterations
+	// accepted by an EncryptedFileTokenStore, so that a tampered file cannot
+	// make LoadToken consume the CPU indefinitely.
+	MaxTokenStoreIterations = 10 * DefaultTokenStoreIterations
+
+	// tokenStoreKDF identifies the key derivation function of an encrypted
+	// token file.
+	tokenStoreKDF = "pbkdf2-sha256"
+)
+
+// A TokenStore persists an access token, such as one obtained using an
+// AuthHandler, so that it can be reused by later runs of a program.
+type TokenStore interface {
+	// LoadToken returns the stored access token.  If no token has been
+	// stored, it returns the empty string and a nil error.
+	LoadToken() (string, error)
+
+	// SaveToken stores an access token, replacing any existing token.
+	SaveToken(token string) error
+}
+
+// An EncryptedFileTokenStore is a TokenStore which stores an access token in a
+// file, encrypted using a key derived from a passphrase.  It is intended for
+// environments without an operating system keyring, such as containers and
+// headless servers.
+//
+// The key is derived using PBKDF2 with SHA-256 and a random salt, and the
+// token is encrypted using AES-256-GCM.  The file contains no secrets in
+// plain text, and is created with permissions which allow only its owner to
+// read it.
+//
+// Only passphrases are supported.  Keys for age, or other public key
+// encryption tools, cannot be used to encrypt the token.
+type EncryptedFileTokenStore struct {
+	// Path to the file.  The file is created if it does not exist.
+	Path string
+
+	// Passphrase from which the encryption key is derived.
+	Passphrase string
+
+	// Number of PBKDF2 iterations used when saving a token.  If zero,
+	// DefaultTokenStoreIterations is used, and it may not exceed
+	// MaxTokenStoreIterations.  Tokens are loaded using the number of
+	// iterations recorded in the file.
+	Iterations int
+}
+
+var _ TokenStore = &EncryptedFileTokenStore{}
+
+// encryptedToken is the JSON representation of a token stored by an
+// EncryptedFileTokenStore.
+type encryptedToken struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadToken implements TokenStore.  If the file does not exist, the empty
+// string is returned.
+func (s *EncryptedFileTokenStore) LoadToken() (string, error) {
+	if s.Passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	var v encryptedToken
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", ErrTokenDecrypt
+	}
+	if v.KDF != tokenStoreKDF || v.Iterations <= 0 || v.Iterations > MaxTokenStoreIterations {
+		return "", ErrTokenDecrypt
+	}
+
+	aead, err := s.aead(v.Salt, v.Iterations)
+	if err != nil {
+		return "", err
+	}
+	if len(v.Nonce) != aead.NonceSize() {
+		return "", ErrTokenDecrypt
+	}
+
+	token, err := aead.Open(nil, v.Nonce, v.Ciphertext, []byte(tokenStoreKDF))
+	if err != nil {
+		return "", ErrTokenDecrypt
+	}
+
+	return string(token), nil
+}
+
+// SaveToken implements TokenStore.  A new salt and nonce are generated for
+// each token, and the file is replaced atomically.
+func (s *EncryptedFileTokenStore) SaveToken(token string) error {
+	if s.Passphrase == "" {
+		return ErrNoPassphrase
+	}
+
+	iterations := s.Iterations
+	switch {
+	case iterations <= 0:
+		iterations = DefaultTokenStoreIterations
+	case iterations > MaxTokenStoreIterations:
+		return paramErrorf("iterations", "%d exceeds maximum of %d", iterations, MaxTokenStoreIterations)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	aead, err := s.aead(salt, iterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(encryptedToken{
+		KDF:        tokenStoreKDF,
+		Iterations: iterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, []byte(token), []byte(tokenStoreKDF)),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Temporary files are created readable only by their owner, which is
+	// preserved by the rename
+	return writeFileAtomic(s.Path, b)
+}
+
+// aead derives an AES-256-GCM cipher from the store's passphrase.
+func (s *EncryptedFileTokenStore) aead(salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, s.Passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,90 @@
+package untappd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestEncryptedFileTokenStore verifies that an EncryptedFileTokenStore stores
+// tokens encrypted, and only decrypts them using the correct passphrase.
+func TestEncryptedFileTokenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token.json")
+	s := &EncryptedFileTokenStore{
+		Path:       path,
+		Passphrase: "correct horse battery staple",
+		Iterations: 1000,
+	}
+
+	token, err := s.LoadToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		t.Fatalf("unexpected token before save: %q", token)
+	}
+
+	const want = "ABCDEF123456"
+	if err := s.SaveToken(want); err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := fi.Mode().Perm(); m != 0600 {
+		t.Fatalf("unexpected file mode: %o != %o", m, 0600)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), want) {
+		t.Fatal("token stored in plain text")
+	}
+
+	token, err = s.LoadToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != want {
+		t.Fatalf("unexpected token: %q != %q", token, want)
+	}
+
+	wrong := &EncryptedFileTokenStore{Path: path, Passphrase: "wrong"}
+	if _, err := wrong.LoadToken(); !errors.Is(err, ErrTokenDecrypt) {
+		t.Fatalf("unexpected error for incorrect passphrase: %v", err)
+	}
+
+	if err := (&EncryptedFileTokenStore{Path: path}).SaveToken(want); !errors.Is(err, ErrNoPassphrase) {
+		t.Fatalf("unexpected error without passphrase: %v", err)
+	}
+
+	// A tampered iteration count is rejected without deriving a key
+	tampered := strings.Replace(string(b), `"iterations":1000`, `"iterations":2147483647`, 1)
+	if tampered == string(b) {
+		t.Fatal("iterations not found in token file")
+	}
+	if err := ioutil.WriteFile(path, []byte(tampered), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.LoadToken(); !errors.Is(err, ErrTokenDecrypt) {
+		t.Fatalf("unexpected error for tampered iterations: %v", err)
+	}
+
+	s.Iterations = MaxTokenStoreIterations + 1
+	if err := s.SaveToken(want); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("unexpected error for too many iterations: %v", err)
+	}
+}
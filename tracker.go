@@ -0,0 +1,166 @@
+package untappd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+const (
+	// DefaultTrackerSize is the number of checkin IDs remembered by a
+	// Tracker, if no other size is specified.
+	DefaultTrackerSize = 10000
+)
+
+// A Tracker remembers recently observed checkin IDs, so that duplicate
+// checkins can be filtered from overlapping pages of a checkin feed.  Paging
+// using a minimum ID, combined with edits to existing checkins, occasionally
+// causes the Untappd APIv4 to deliver the same checkin more than once.
+//
+// A Tracker may also be used to remember which notifications have been
+// handled, using AuthService.PollNotifications.
+//
+// A Tracker remembers a bounded number of IDs.  Once full, the oldest IDs
+// are forgotten first.  A Tracker may be persisted using WriteTo and
+// restored using ReadFrom.
+//
+// A Tracker must be created using NewTracker; the zero value is not usable.
+// A Tracker is safe for concurrent use.
+type Tracker struct {
+	mu   sync.Mutex
+	size int
+	ids  map[int64]struct{}
+
+	// ring holds IDs in the order they were first observed, beginning at
+	// next once the ring is full.
+	ring []int64
+	next int
+}
+
+// NewTracker creates a Tracker which remembers up to size checkin IDs.  If
+// size is zero or negative, DefaultTrackerSize is used.
+func NewTracker(size int) *Tracker {
+	if size <= 0 {
+		size = DefaultTrackerSize
+	}
+
+	return &Tracker{
+		size: size,
+		ids:  make(map[int64]struct{}, size),
+		ring: make([]int64, 0, size),
+	}
+}
+
+// Seen reports whether the input checkin ID has already been observed by
+// this Tracker, and records it if not.
+func (t *Tracker) Seen(id int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.seen(id)
+}
+
+// Has reports whether the input ID has already been observed by this
+// Tracker, without recording it.
+func (t *Tracker) Has(id int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.ids[id]
+	return ok
+}
+
+// seen implements Seen.  The caller must hold t.mu.
+func (t *Tracker) seen(id int64) bool {
+	if _, ok := t.ids[id]; ok {
+		return true
+	}
+
+	if len(t.ring) < t.size {
+		t.ring = append(t.ring, id)
+	} else {
+		// Forget the oldest ID to make room
+		delete(t.ids, t.ring[t.next])
+		t.ring[t.next] = id
+		t.next = (t.next + 1) % t.size
+	}
+	t.ids[id] = struct{}{}
+
+	return false
+}
+
+// Filter returns the checkins from the input slice which have not already
+// been observed by this Tracker, and records them.  Nil checkins are removed.
+func (t *Tracker) Filter(checkins []*Checkin) []*Checkin {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]*Checkin, 0, len(checkins))
+	for _, c := range checkins {
+		if c == nil || t.seen(c.ID) {
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// Len returns the number of checkin IDs currently remembered by this Tracker.
+func (t *Tracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.ring)
+}
+
+// WriteTo implements io.WriterTo.  Remembered checkin IDs are written one per
+// line, from oldest to newest.
+func (t *Tracker) WriteTo(w io.Writer) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	var n int64
+	for i := range t.ring {
+		id := t.ring[(t.next+i)%len(t.ring)]
+
+		nn, err := fmt.Fprintln(bw, id)
+		n += int64(nn)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// ReadFrom implements io.ReaderFrom.  Checkin IDs written by WriteTo are
+// recorded as observed, in addition to any IDs already remembered.
+func (t *Tracker) ReadFrom(r io.Reader) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := bufio.NewScanner(r)
+	var n int64
+	for s.Scan() {
+		n += int64(len(s.Bytes())) + 1
+
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return n, fmt.Errorf("invalid checkin ID %q: %v", line, err)
+		}
+
+		t.seen(id)
+	}
+
+	return n, s.Err()
+}
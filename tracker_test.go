@@ -0,0 +1,82 @@
+package untappd
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestTrackerFilter verifies that Tracker.Filter removes checkins which were
+// observed in previous, overlapping pages.
+func TestTrackerFilter(t *testing.T) {
+	tr := NewTracker(0)
+
+	page := func(ids ...int64) []*Checkin {
+		checkins := make([]*Checkin, 0, len(ids))
+		for _, id := range ids {
+			checkins = append(checkins, &Checkin{ID: id})
+		}
+		return checkins
+	}
+
+	if l := len(tr.Filter(page(1, 2, 3))); l != 3 {
+		t.Fatalf("unexpected number of checkins in first page: %d != %d", l, 3)
+	}
+
+	out := tr.Filter(page(3, 4, 2, 5, 5))
+	if l := len(out); l != 2 {
+		t.Fatalf("unexpected number of checkins in second page: %d != %d", l, 2)
+	}
+	if out[0].ID != 4 || out[1].ID != 5 {
+		t.Fatalf("unexpected checkin IDs: %d, %d", out[0].ID, out[1].ID)
+	}
+}
+
+// TestTrackerBounded verifies that a Tracker forgets its oldest IDs once it
+// is full.
+func TestTrackerBounded(t *testing.T) {
+	tr := NewTracker(3)
+	for id := int64(1); id <= 5; id++ {
+		tr.Seen(id)
+	}
+
+	if l := tr.Len(); l != 3 {
+		t.Fatalf("unexpected Tracker length: %d != %d", l, 3)
+	}
+	if tr.Seen(1) {
+		t.Fatal("expected oldest ID to be forgotten")
+	}
+	if !tr.Seen(5) {
+		t.Fatal("expected newest ID to be remembered")
+	}
+}
+
+// TestTrackerPersist verifies that a Tracker can be persisted and restored,
+// preserving the order in which IDs were observed.
+func TestTrackerPersist(t *testing.T) {
+	tr := NewTracker(3)
+	for id := int64(1); id <= 4; id++ {
+		tr.Seen(id)
+	}
+
+	var buf bytes.Buffer
+	if _, err := tr.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := "2\n3\n4\n"; buf.String() != want {
+		t.Fatalf("unexpected persisted Tracker: %q != %q", buf.String(), want)
+	}
+
+	restored := NewTracker(3)
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []int64{2, 3, 4} {
+		if !restored.Seen(id) {
+			t.Fatalf("expected restored Tracker to remember ID %d", id)
+		}
+	}
+
+	if _, err := NewTracker(0).ReadFrom(bytes.NewBufferString("foo\n")); err == nil {
+		t.Fatal("expected an error for an invalid checkin ID")
+	}
+}
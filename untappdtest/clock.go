@@ -0,0 +1,99 @@
+package untappdtest
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock is a fake untappd.Clock, whose time only changes when Advance or
+// Set is called.  A Client which uses a Clock can be created by passing
+// untappd.WithClock to Server.Client.  Its methods are safe for concurrent
+// use.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+// A waiter is a channel waiting for a Clock to reach a deadline.
+type waiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewClock creates a Clock set to the input time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the Clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel which receives the Clock's current time once the
+// Clock has been advanced by at least d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.waiters = append(c.waiters, waiter{
+		deadline: c.now.Add(d),
+		c:        ch,
+	})
+
+	return ch
+}
+
+// Advance moves the Clock forward by d, waking any waiters whose deadlines
+// have passed.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+// Set sets the Clock to the input time, waking any waiters whose deadlines
+// have passed.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(now)
+}
+
+// Waiters returns the number of channels returned by After which have not
+// yet received a time.  It may be used to wait until code under test is
+// blocked on the Clock before advancing it.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
+
+// set implements Advance and Set.  The caller must hold c.mu.
+func (c *Clock) set(now time.Time) {
+	c.now = now
+
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if now.Before(w.deadline) {
+			waiters = append(waiters, w)
+			continue
+		}
+
+		w.c <- now
+	}
+	c.waiters = waiters
+}
@@ -0,0 +1,81 @@
+package untappdtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+// TestClockCacheExpiry verifies that a Client's response cache expires
+// entries according to its Clock.
+func TestClockCacheExpiry(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.AddBeer(&untappd.Beer{ID: 1, Name: "Black Note Stout"})
+	s.SetRateLimit(100)
+
+	start := time.Date(2015, time.October, 24, 22, 0, 0, 0, time.UTC)
+	clk := NewClock(start)
+
+	c, err := s.Client(
+		untappd.WithClock(clk),
+		untappd.WithPolicy(untappd.ServicePolicy{CacheTTL: time.Minute}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := func() {
+		if _, _, err := c.Beer.Info(1, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info()
+	clk.Advance(30 * time.Second)
+	info()
+
+	rl := c.RateLimit()
+	if rl.Remaining != 99 {
+		t.Fatalf("unexpected remaining requests with cached response: %d != %d", rl.Remaining, 99)
+	}
+	if !rl.Updated.Equal(start) {
+		t.Fatalf("unexpected rate limit update time: %v != %v", rl.Updated, start)
+	}
+
+	clk.Advance(time.Minute)
+	info()
+
+	if rl := c.RateLimit(); rl.Remaining != 98 {
+		t.Fatalf("unexpected remaining requests with expired response: %d != %d", rl.Remaining, 98)
+	}
+}
+
+// TestClockAfter verifies that channels returned by Clock.After receive only
+// once the Clock has advanced far enough.
+func TestClockAfter(t *testing.T) {
+	clk := NewClock(time.Unix(0, 0))
+	ch := clk.After(time.Minute)
+
+	clk.Advance(59 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("channel received before deadline")
+	default:
+	}
+	if n := clk.Waiters(); n != 1 {
+		t.Fatalf("unexpected number of waiters: %d != %d", n, 1)
+	}
+
+	clk.Advance(time.Second)
+	select {
+	case now := <-ch:
+		if want := time.Unix(60, 0); !now.Equal(want) {
+			t.Fatalf("unexpected time: %v != %v", now, want)
+		}
+	default:
+		t.Fatal("channel did not receive after deadline")
+	}
+}
@@ -0,0 +1,121 @@
+package untappdtest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fixtures contains canned Untappd APIv4 responses, largely taken from the
+// Untappd APIv4 documentation.  They were copied from the tests of package
+// untappd, but are maintained separately, so the two may differ.
+//
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// UpdateGoldenEnv is the environment variable which, when set to "1", causes
+// Golden to write golden files instead of comparing against them.
+const UpdateGoldenEnv = "UNTAPPDTEST_UPDATE_GOLDEN"
+
+// Fixtures returns the names of all available fixtures, in sorted order.
+func Fixtures() []string {
+	entries, _ := fixtures.ReadDir("fixtures")
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Fixture returns the canned Untappd APIv4 response with the input name,
+// such as "user_checkins".  Use Fixtures to list the available names.
+func Fixture(name string) ([]byte, error) {
+	b, err := fixtures.ReadFile(path.Join("fixtures", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("untappdtest: no fixture %q", name)
+	}
+
+	return b, nil
+}
+
+// ServeFixture causes the Server to respond to requests for the input
+// endpoint, such as "user/checkins/gregavola", with the named fixture.  The
+// HTTP status code is taken from the fixture's metadata, if present.
+// Fixtures take precedence over the Server's seeded data.
+func (s *Server) ServeFixture(endpoint string, name string) error {
+	b, err := Fixture(name)
+	if err != nil {
+		return err
+	}
+
+	var v struct {
+		Meta struct {
+			Code int `json:"code"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	code := v.Meta.Code
+	if code == 0 {
+		code = http.StatusOK
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures[strings.Trim(endpoint, "/")] = fixture{code: code, body: b}
+	return nil
+}
+
+// A fixture is a canned response served by a Server.
+type fixture struct {
+	code int
+	body []byte
+}
+
+// Golden compares the indented JSON encoding of v with the contents of the
+// golden file at path, failing the test if they differ.  Decoding a fixture
+// and comparing the result against a golden file detects changes in the
+// shape of package untappd's structures between versions.
+//
+// If the UNTAPPDTEST_UPDATE_GOLDEN environment variable is set to "1", the
+// golden file is written instead.
+func Golden(tb testing.TB, path string, v interface{}) {
+	tb.Helper()
+
+	got, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		tb.Fatalf("untappdtest: failed to encode golden value: %v", err)
+	}
+	got = append(got, '\n')
+
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			tb.Fatalf("untappdtest: failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("untappdtest: failed to read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		tb.Fatalf("untappdtest: value does not match golden file %s (set %s=1 to update it):\n%s",
+			path, UpdateGoldenEnv, got)
+	}
+}
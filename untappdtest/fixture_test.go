@@ -0,0 +1,67 @@
+package untappdtest
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/mdlayher/untappd"
+)
+
+// TestFixtures verifies that all fixtures can be loaded, and that unknown
+// fixtures return an error.
+func TestFixtures(t *testing.T) {
+	names := Fixtures()
+	if len(names) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, n := range names {
+		if _, err := Fixture(n); err != nil {
+			t.Fatalf("failed to load fixture %q: %v", n, err)
+		}
+	}
+
+	if _, err := Fixture("missing"); err == nil {
+		t.Fatal("expected an error for a missing fixture")
+	}
+}
+
+// TestServeFixtureGolden verifies that fixtures served by a Server decode to
+// the structures recorded in golden files.
+func TestServeFixtureGolden(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	if err := s.ServeFixture("user/checkins/gregavola", "user_checkins"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ServeFixture("beer/info/1", "beer_info"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ServeFixture("user/info/nobody", "error_invalid_user"); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkins, _, err := c.User.Checkins("gregavola")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Golden(t, filepath.Join("testdata", "user_checkins.golden"), checkins)
+
+	beer, _, err := c.Beer.Info(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Golden(t, filepath.Join("testdata", "beer_info.golden"), beer)
+
+	var uerr *untappd.Error
+	if _, _, err := c.User.Info("nobody", false); !errors.As(err, &uerr) || uerr.Type != "invalid_auth" {
+		t.Fatalf("unexpected error for error fixture: %v", err)
+	}
+}
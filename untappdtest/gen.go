@@ -0,0 +1,269 @@
+package untappdtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+// Word lists used to generate realistic names.
+var (
+	genAdjectives = []string{
+		"Hazy", "Golden", "Dark", "Wild", "Old", "Imperial", "Rusty",
+		"Midnight", "Crimson", "Lazy", "Bitter", "Smoky", "Northern", "Tiny",
+	}
+
+	genNouns = []string{
+		"Fox", "Harbor", "Anchor", "Owl", "River", "Lantern", "Summit",
+		"Orchard", "Meadow", "Raven", "Barrel", "Bridge", "Forest", "Comet",
+	}
+
+	genStyles = []string{
+		"American IPA", "IPA - New England", "American Pale Ale", "Stout - Imperial",
+		"Porter - American", "Pilsner - Czech", "Lager - Helles", "Saison",
+		"Sour - Gose", "Hefeweizen", "Belgian Tripel", "Red Ale - American Amber",
+	}
+
+	genBreweryKinds = []string{"Brewing Co.", "Brewery", "Beer Works", "Ales", "Brewhouse"}
+
+	genFirstNames = []string{
+		"Alex", "Sam", "Jordan", "Taylor", "Morgan", "Casey", "Riley",
+		"Jamie", "Robin", "Avery", "Quinn", "Charlie",
+	}
+
+	genLastNames = []string{
+		"Smith", "Garcia", "Kowalski", "Nguyen", "Okafor", "Jensen",
+		"Rossi", "Dubois", "Tanaka", "Murphy", "Silva", "Novak",
+	}
+
+	genCities = []struct {
+		City, State, Country string
+		Latitude, Longitude  float64
+	}{
+		{"Portland", "OR", "United States", 45.5152, -122.6784},
+		{"Denver", "CO", "United States", 39.7392, -104.9903},
+		{"Asheville", "NC", "United States", 35.5951, -82.5515},
+		{"Brooklyn", "NY", "United States", 40.6782, -73.9442},
+		{"San Diego", "CA", "United States", 32.7157, -117.1611},
+		{"Brussels", "", "Belgium", 50.8503, 4.3517},
+		{"Munich", "Bavaria", "Germany", 48.1351, 11.5820},
+	}
+
+	genVenueKinds = []string{"Taproom", "Pub", "Bottle Shop", "Beer Garden", "Tavern"}
+
+	genComments = []string{
+		"", "", "Great on tap!", "A little too sweet for me.", "Solid.",
+		"Would have again.", "Perfect for a hot day.", "Wow.", "Smooth and roasty.",
+	}
+)
+
+// genEpoch is the earliest time used for generated timestamps.
+var genEpoch = time.Date(2012, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// A generator produces deterministic fake data from a seed.
+type generator struct {
+	r *rand.Rand
+}
+
+// newGenerator creates a generator using the input seed.
+func newGenerator(seed int64) *generator {
+	return &generator{r: rand.New(rand.NewSource(seed))}
+}
+
+// pick returns a random element of s.
+func (g *generator) pick(s []string) string {
+	return s[g.r.Intn(len(s))]
+}
+
+// id returns a positive ID.
+func (g *generator) id() int64 {
+	return int64(1 + g.r.Intn(math.MaxInt32-1))
+}
+
+// rating returns a rating between 0.25 and 5, in increments of 0.25, like
+// ratings submitted to Untappd.
+func (g *generator) rating() float64 {
+	return float64(1+g.r.Intn(20)) / 4
+}
+
+// time returns a timestamp between genEpoch and roughly ten years later.
+func (g *generator) time() time.Time {
+	return genEpoch.Add(time.Duration(g.r.Int63n(int64(10 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// slug converts a name into an Untappd-style slug.
+func slug(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "-", ".", "", "'", "").Replace(name))
+}
+
+// GenBrewery generates a realistic Brewery.  The same seed always produces
+// the same Brewery.
+func GenBrewery(seed int64) *untappd.Brewery {
+	return newGenerator(seed).brewery()
+}
+
+func (g *generator) brewery() *untappd.Brewery {
+	name := g.pick(genAdjectives) + " " + g.pick(genNouns) + " " + g.pick(genBreweryKinds)
+	city := genCities[g.r.Intn(len(genCities))]
+
+	return &untappd.Brewery{
+		ID:      g.id(),
+		Name:    name,
+		Slug:    slug(name),
+		Country: city.Country,
+		Active:  true,
+		Type:    untappd.BreweryTypeMicro,
+		Location: untappd.BreweryLocation{
+			City:      city.City,
+			State:     city.State,
+			Latitude:  city.Latitude,
+			Longitude: city.Longitude,
+		},
+		Rating: untappd.Rating{
+			Score: 3 + math.Round(g.r.Float64()*150)/100,
+			Count: g.r.Intn(100000),
+		},
+	}
+}
+
+// GenBeer generates a realistic Beer, including its Brewery.  The same seed
+// always produces the same Beer.
+func GenBeer(seed int64) *untappd.Beer {
+	return newGenerator(seed).beer()
+}
+
+func (g *generator) beer() *untappd.Beer {
+	name := g.pick(genAdjectives) + " " + g.pick(genNouns)
+	style := g.pick(genStyles)
+	if i := strings.Index(style, " - "); i != -1 {
+		name += " " + style[i+3:]
+	} else {
+		name += " " + style
+	}
+
+	ratings := g.r.Intn(50000)
+	return &untappd.Beer{
+		ID:      g.id(),
+		Name:    name,
+		Slug:    slug(name),
+		Style:   style,
+		ABV:     math.Round((3.5+g.r.Float64()*9)*10) / 10,
+		IBU:     5 + g.r.Intn(95),
+		Created: g.time(),
+		Rating: untappd.Rating{
+			Score: 3 + math.Round(g.r.Float64()*150)/100,
+			Count: ratings,
+		},
+		OverallCount: ratings,
+		Stats: untappd.BeerStats{
+			TotalCount:     ratings + g.r.Intn(10000),
+			MonthlyCount:   g.r.Intn(1000),
+			TotalUserCount: ratings,
+		},
+		Brewery: g.brewery(),
+	}
+}
+
+// GenUser generates a realistic User.  The same seed always produces the
+// same User.
+func GenUser(seed int64) *untappd.User {
+	return newGenerator(seed).user()
+}
+
+func (g *generator) user() *untappd.User {
+	first, last := g.pick(genFirstNames), g.pick(genLastNames)
+	username := strings.ToLower(first+last) + fmt.Sprint(g.r.Intn(1000))
+	city := genCities[g.r.Intn(len(genCities))]
+
+	id := g.id()
+	return &untappd.User{
+		UID:       id,
+		ID:        id,
+		UserName:  username,
+		FirstName: first,
+		LastName:  last,
+		Location:  city.City + ", " + city.Country,
+		UntappdURL: url.URL{
+			Scheme: "https",
+			Host:   "untappd.com",
+			Path:   "/user/" + username,
+		},
+		Stats: untappd.UserStats{
+			TotalBadges:   g.r.Intn(500),
+			TotalFriends:  g.r.Intn(300),
+			TotalCheckins: g.r.Intn(5000),
+			TotalBeers:    g.r.Intn(3000),
+		},
+	}
+}
+
+// GenVenue generates a realistic Venue.  The same seed always produces the
+// same Venue.
+func GenVenue(seed int64) *untappd.Venue {
+	return newGenerator(seed).venue()
+}
+
+func (g *generator) venue() *untappd.Venue {
+	return &untappd.Venue{
+		ID:       g.id(),
+		Name:     "The " + g.pick(genAdjectives) + " " + g.pick(genNouns) + " " + g.pick(genVenueKinds),
+		Category: "Nightlife Spot",
+	}
+}
+
+// GenCheckin generates a realistic Checkin, including its User, Beer, and
+// Brewery, and sometimes a Venue.  The same seed always produces the same
+// Checkin.
+func GenCheckin(seed int64) *untappd.Checkin {
+	g := newGenerator(seed)
+	return g.checkin(g.id(), g.user(), g.time())
+}
+
+func (g *generator) checkin(id int64, u *untappd.User, created time.Time) *untappd.Checkin {
+	b := g.beer()
+	c := &untappd.Checkin{
+		ID:         id,
+		Created:    created,
+		Comment:    g.pick(genComments),
+		UserRating: g.rating(),
+		User:       u,
+		Beer:       b,
+		Brewery:    b.Brewery,
+		Source: untappd.CheckinSource{
+			Name: "Untappd for iPhone - (V2)",
+		},
+	}
+
+	// Roughly half of checkins occur at a venue
+	if g.r.Intn(2) == 0 {
+		c.Venue = g.venue()
+	}
+
+	return c
+}
+
+// GenCheckins generates a realistic history of n checkins by a single User,
+// ordered from oldest to newest with increasing IDs.  The same seed and n
+// always produce the same checkins.
+func GenCheckins(seed int64, n int) []*untappd.Checkin {
+	g := newGenerator(seed)
+	u := g.user()
+
+	id := int64(g.r.Intn(100000000))
+	created := g.time()
+
+	checkins := make([]*untappd.Checkin, 0, n)
+	for i := 0; i < n; i++ {
+		id += int64(1 + g.r.Intn(1000))
+		created = created.Add(time.Duration(1+g.r.Intn(72*60)) * time.Minute)
+
+		checkins = append(checkins, g.checkin(id, u, created))
+	}
+
+	return checkins
+}
@@ -0,0 +1,59 @@
+package untappdtest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestGenDeterministic verifies that generators produce identical values for
+// the same seed, and different values for different seeds.
+func TestGenDeterministic(t *testing.T) {
+	if a, b := GenCheckin(1), GenCheckin(1); !reflect.DeepEqual(a, b) {
+		t.Fatalf("checkins differ for the same seed:\n- %+v\n- %+v", a, b)
+	}
+	if a, b := GenBeer(1), GenBeer(2); reflect.DeepEqual(a, b) {
+		t.Fatal("beers are equal for different seeds")
+	}
+
+	b := GenBeer(42)
+	if b.Name == "" || b.Style == "" || b.Brewery == nil || b.Brewery.Name == "" {
+		t.Fatalf("incomplete generated beer: %+v", b)
+	}
+	if b.ABV < 3.5 || b.ABV > 12.5 {
+		t.Fatalf("unrealistic generated beer ABV: %v", b.ABV)
+	}
+}
+
+// TestGenCheckinsServer verifies that a generated checkin history can be
+// served by a Server, and is returned newest first.
+func TestGenCheckinsServer(t *testing.T) {
+	checkins := GenCheckins(7, 120)
+
+	s := NewServer()
+	defer s.Close()
+
+	for _, c := range checkins {
+		s.AddCheckin(c)
+	}
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.User.AllCheckins(context.Background(), checkins[0].User.UserName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(got); l != len(checkins) {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, len(checkins))
+	}
+
+	for i := range got {
+		want := checkins[len(checkins)-1-i]
+		if got[i].ID != want.ID || got[i].Beer.Name != want.Beer.Name {
+			t.Fatalf("unexpected checkin %d: %d %q != %d %q", i, got[i].ID, got[i].Beer.Name, want.ID, want.Beer.Name)
+		}
+	}
+}
@@ -0,0 +1,679 @@
+// Package untappdtest provides a fake Untappd APIv4 server, for use in tests
+// of programs which use package untappd.
+//
+// A Server is seeded with beers, breweries, users, and checkins, and serves
+// them using the same JSON envelopes as the Untappd APIv4, including rate
+// limit headers and error responses.  A Client which communicates with the
+// Server can be created using Server.Client.
+package untappdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+const (
+	// defaultLimit is the number of items returned by list endpoints when no
+	// limit parameter is specified.
+	defaultLimit = 25
+
+	// timeFormat is the timestamp format used by the Untappd APIv4.
+	timeFormat = time.RFC1123Z
+)
+
+// Server is a fake Untappd APIv4 server.  Its methods are safe for concurrent
+// use, and may be used to modify the Server's data while it is serving
+// requests.
+type Server struct {
+	// URL is the base URL of the fake Untappd APIv4, for use with
+	// untappd.WithBaseURL.
+	URL string
+
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	beers     map[int64]*untappd.Beer
+	breweries map[int64]*untappd.Brewery
+	users     map[string]*untappd.User
+	checkins  []*untappd.Checkin
+	fixtures  map[string]fixture
+
+	limit     int
+	remaining int
+	failures  []*untappd.Error
+}
+
+// NewServer creates and starts a Server.  Close must be called when the
+// Server is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		beers:     make(map[int64]*untappd.Beer),
+		breweries: make(map[int64]*untappd.Brewery),
+		users:     make(map[string]*untappd.User),
+		fixtures:  make(map[string]fixture),
+	}
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL + "/v4"
+
+	return s
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client creates an untappd.Client which communicates with the Server.
+// Optional ClientOptions may be used to further configure the Client.
+func (s *Server) Client(opts ...untappd.ClientOption) (*untappd.Client, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]untappd.ClientOption{untappd.WithBaseURL(u)}, opts...)
+	return untappd.NewClient("untappdtest", "untappdtest", s.srv.Client(), opts...)
+}
+
+// AddBeer adds a beer to the Server.  If the beer's Brewery is set, it is
+// also added.
+func (s *Server) AddBeer(b *untappd.Beer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addBeer(b)
+}
+
+// AddBrewery adds a brewery to the Server.
+func (s *Server) AddBrewery(b *untappd.Brewery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.breweries[b.ID] = b
+}
+
+// AddUser adds a user to the Server.
+func (s *Server) AddUser(u *untappd.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[u.UserName] = u
+}
+
+// AddCheckin adds a checkin to the Server.  The checkin's User, Beer, and
+// Brewery, if set, are also added.
+func (s *Server) AddCheckin(c *untappd.Checkin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.User != nil {
+		s.users[c.User.UserName] = c.User
+	}
+	if c.Beer != nil {
+		s.addBeer(c.Beer)
+	}
+	if c.Brewery != nil {
+		s.breweries[c.Brewery.ID] = c.Brewery
+	}
+
+	s.checkins = append(s.checkins, c)
+}
+
+// addBeer adds a beer, and its brewery if set.  The caller must hold s.mu.
+func (s *Server) addBeer(b *untappd.Beer) {
+	s.beers[b.ID] = b
+	if b.Brewery != nil {
+		s.breweries[b.Brewery.ID] = b.Brewery
+	}
+}
+
+// SetRateLimit sets the number of requests permitted by the Server, and
+// resets the number of requests remaining.  Once no requests remain, the
+// Server responds with HTTP 429.  If limit is zero, requests are not limited
+// and no rate limit headers are sent, which is the default.
+func (s *Server) SetRateLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limit = limit
+	s.remaining = limit
+}
+
+// FailNext causes the next request to the Server to fail with the input
+// error.  If FailNext is called multiple times, errors are returned in order
+// for subsequent requests.  If err.Code is zero, HTTP 500 is used.
+func (s *Server) FailNext(err untappd.Error) {
+	if err.Code == 0 {
+		err.Code = http.StatusInternalServerError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &err)
+}
+
+// serveHTTP serves the fake Untappd APIv4.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limit > 0 {
+		exceeded := s.remaining == 0
+		if !exceeded {
+			s.remaining--
+		}
+
+		w.Header().Set("X-Ratelimit-Limit", strconv.Itoa(s.limit))
+		w.Header().Set("X-Ratelimit-Remaining", strconv.Itoa(s.remaining))
+
+		if exceeded {
+			writeError(w, http.StatusTooManyRequests, "invalid_limit", "You have reached the rate limit.")
+			return
+		}
+	}
+
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+
+		writeError(w, err.Code, err.Type, err.Detail)
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("access_token") == "" && (q.Get("client_id") == "" || q.Get("client_secret") == "") {
+		writeError(w, http.StatusInternalServerError, "invalid_auth", "You must authenticate to use the API.")
+		return
+	}
+
+	// Endpoints are of the form /v4/{object}/{method}[/{id}]/
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v4"), "/"), "/")
+	if len(parts) < 2 {
+		writeError(w, http.StatusNotFound, "invalid_method", "This method is not supported.")
+		return
+	}
+
+	if f, ok := s.fixtures[strings.Join(parts, "/")]; ok {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(f.code)
+		_, _ = w.Write(f.body)
+		return
+	}
+
+	var id string
+	if len(parts) > 2 {
+		id = parts[2]
+	}
+
+	switch parts[0] + "/" + parts[1] {
+	case "beer/info":
+		s.beerInfo(w, id)
+	case "search/beer":
+		s.beerSearch(w, q)
+	case "beer/checkins":
+		s.beerCheckins(w, id, q)
+	case "brewery/info":
+		s.breweryInfo(w, id)
+	case "user/info":
+		s.userInfo(w, id)
+	case "user/checkins":
+		s.userCheckins(w, id, q)
+	case "user/beers":
+		s.userBeers(w, id, q)
+	default:
+		writeError(w, http.StatusNotFound, "invalid_method", "This method is not supported.")
+	}
+}
+
+// beerInfo serves the beer/info endpoint.
+func (s *Server) beerInfo(w http.ResponseWriter, id string) {
+	b, ok := s.beer(id)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_param", "This Beer ID is invalid.")
+		return
+	}
+
+	writeResponse(w, object{"beer": beerJSON(b)})
+}
+
+// beerSearch serves the search/beer endpoint.
+func (s *Server) beerSearch(w http.ResponseWriter, q url.Values) {
+	query := strings.ToLower(q.Get("q"))
+	if query == "" {
+		writeError(w, http.StatusInternalServerError, "invalid_param", "Your missing the 'q' parameter.")
+		return
+	}
+
+	var found []*untappd.Beer
+	for _, b := range s.sortedBeers() {
+		if strings.Contains(strings.ToLower(b.Name), query) {
+			found = append(found, b)
+		}
+	}
+
+	page := paginate(len(found), q)
+	items := make([]object, 0, len(page))
+	for _, i := range page {
+		b := found[i]
+		items = append(items, object{
+			"checkin_count": b.Stats.TotalCount,
+			"have_had":      b.HaveHad,
+			"your_count":    b.Count,
+			"beer":          beerJSON(b),
+			"brewery":       breweryJSON(b.Brewery),
+		})
+	}
+
+	writeResponse(w, object{
+		"found": len(found),
+		"beers": object{
+			"count": len(items),
+			"items": items,
+		},
+	})
+}
+
+// beerCheckins serves the beer/checkins endpoint.
+func (s *Server) beerCheckins(w http.ResponseWriter, id string, q url.Values) {
+	b, ok := s.beer(id)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_param", "This Beer ID is invalid.")
+		return
+	}
+
+	s.writeCheckins(w, q, func(c *untappd.Checkin) bool {
+		return c.Beer != nil && c.Beer.ID == b.ID
+	})
+}
+
+// breweryInfo serves the brewery/info endpoint.
+func (s *Server) breweryInfo(w http.ResponseWriter, id string) {
+	n, _ := strconv.ParseInt(id, 10, 64)
+	b, ok := s.breweries[n]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_param", "This Brewery ID is invalid.")
+		return
+	}
+
+	writeResponse(w, object{"brewery": breweryJSON(b)})
+}
+
+// userInfo serves the user/info endpoint.
+func (s *Server) userInfo(w http.ResponseWriter, username string) {
+	u, ok := s.users[username]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_auth", "There is no user with that username.")
+		return
+	}
+
+	writeResponse(w, object{"user": userJSON(u)})
+}
+
+// userCheckins serves the user/checkins endpoint.
+func (s *Server) userCheckins(w http.ResponseWriter, username string, q url.Values) {
+	if _, ok := s.users[username]; !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_auth", "There is no user with that username.")
+		return
+	}
+
+	s.writeCheckins(w, q, func(c *untappd.Checkin) bool {
+		return c.User != nil && c.User.UserName == username
+	})
+}
+
+// userBeers serves the user/beers endpoint, aggregating the distinct beers
+// from a user's checkins.
+func (s *Server) userBeers(w http.ResponseWriter, username string, q url.Values) {
+	if _, ok := s.users[username]; !ok {
+		writeError(w, http.StatusInternalServerError, "invalid_auth", "There is no user with that username.")
+		return
+	}
+
+	type had struct {
+		beer          *untappd.Beer
+		first, recent time.Time
+		rating        float64
+		count         int
+	}
+
+	var order []int64
+	beers := make(map[int64]*had)
+	for _, c := range s.checkins {
+		if c.User == nil || c.User.UserName != username || c.Beer == nil {
+			continue
+		}
+
+		h, ok := beers[c.Beer.ID]
+		if !ok {
+			h = &had{beer: c.Beer, first: c.Created}
+			beers[c.Beer.ID] = h
+			order = append(order, c.Beer.ID)
+		}
+
+		if c.Created.Before(h.first) {
+			h.first = c.Created
+		}
+		if !c.Created.Before(h.recent) {
+			h.recent = c.Created
+			h.rating = c.UserRating
+		}
+		h.count++
+	}
+
+	// Most recently had beers are returned first
+	sort.SliceStable(order, func(i, j int) bool {
+		return beers[order[i]].recent.After(beers[order[j]].recent)
+	})
+
+	page := paginate(len(order), q)
+	items := make([]object, 0, len(page))
+	for _, i := range page {
+		h := beers[order[i]]
+		items = append(items, object{
+			"first_created_at":  formatTime(h.first),
+			"recent_created_at": formatTime(h.recent),
+			"rating_score":      h.rating,
+			"count":             h.count,
+			"beer":              beerJSON(h.beer),
+			"brewery":           breweryJSON(h.beer.Brewery),
+		})
+	}
+
+	writeResponse(w, object{
+		"total_count": len(order),
+		"beers": object{
+			"count": len(items),
+			"items": items,
+		},
+	})
+}
+
+// writeCheckins writes the checkins which satisfy match, newest first, using
+// the min_id, max_id, and limit parameters in q.
+func (s *Server) writeCheckins(w http.ResponseWriter, q url.Values, match func(c *untappd.Checkin) bool) {
+	minID, _ := strconv.ParseInt(q.Get("min_id"), 10, 64)
+	maxID, _ := strconv.ParseInt(q.Get("max_id"), 10, 64)
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+
+	checkins := make([]*untappd.Checkin, 0, len(s.checkins))
+	for _, c := range s.checkins {
+		if !match(c) {
+			continue
+		}
+		if minID != 0 && c.ID < minID {
+			continue
+		}
+		if maxID != 0 && c.ID > maxID {
+			continue
+		}
+
+		checkins = append(checkins, c)
+	}
+
+	sort.SliceStable(checkins, func(i, j int) bool {
+		return checkins[i].ID > checkins[j].ID
+	})
+	if len(checkins) > limit {
+		checkins = checkins[:limit]
+	}
+
+	items := make([]object, 0, len(checkins))
+	for _, c := range checkins {
+		items = append(items, checkinJSON(c))
+	}
+
+	writeResponse(w, object{
+		"checkins": object{
+			"count": len(items),
+			"items": items,
+		},
+	})
+}
+
+// beer returns the beer with the input ID string.
+func (s *Server) beer(id string) (*untappd.Beer, bool) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	b, ok := s.beers[n]
+	return b, ok
+}
+
+// sortedBeers returns all beers, ordered by ID.
+func (s *Server) sortedBeers() []*untappd.Beer {
+	beers := make([]*untappd.Beer, 0, len(s.beers))
+	for _, b := range s.beers {
+		beers = append(beers, b)
+	}
+
+	sort.Slice(beers, func(i, j int) bool {
+		return beers[i].ID < beers[j].ID
+	})
+
+	return beers
+}
+
+// paginate returns the indices of n items selected by the offset and limit
+// parameters in q.
+func paginate(n int, q url.Values) []int {
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+
+	var idx []int
+	for i := offset; i < n && len(idx) < limit; i++ {
+		if i >= 0 {
+			idx = append(idx, i)
+		}
+	}
+
+	return idx
+}
+
+// An object is a JSON object.
+type object map[string]interface{}
+
+// writeResponse writes a successful Untappd APIv4 envelope containing the
+// input response object.
+func writeResponse(w http.ResponseWriter, response object) {
+	writeJSON(w, http.StatusOK, object{
+		"meta":          meta(http.StatusOK),
+		"notifications": []object{},
+		"response":      response,
+	})
+}
+
+// writeError writes an Untappd APIv4 error envelope.
+func writeError(w http.ResponseWriter, code int, errorType string, detail string) {
+	m := meta(code)
+	m["error_type"] = errorType
+	m["error_detail"] = detail
+
+	writeJSON(w, code, object{
+		"meta":     m,
+		"response": []object{},
+	})
+}
+
+// meta returns the metadata object of an Untappd APIv4 envelope.
+func meta(code int) object {
+	return object{
+		"code": code,
+		"response_time": object{
+			"time":    0,
+			"measure": "seconds",
+		},
+		"init_time": object{
+			"time":    0,
+			"measure": "seconds",
+		},
+	}
+}
+
+// writeJSON writes v as JSON with the input HTTP status code.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// beerJSON returns the Untappd APIv4 JSON representation of a beer.
+func beerJSON(b *untappd.Beer) object {
+	o := object{
+		"bid":              b.ID,
+		"beer_name":        b.Name,
+		"beer_label":       b.Label.String(),
+		"beer_abv":         b.ABV,
+		"beer_ibu":         b.IBU,
+		"beer_slug":        b.Slug,
+		"beer_style":       b.Style,
+		"beer_description": b.Description,
+		"wish_list":        b.WishList,
+		"rating_score":     b.Rating.Score,
+		"rating_count":     b.Rating.Count,
+		"auth_rating":      b.Rating.AuthRating,
+		"stats": object{
+			"total_count":      b.Stats.TotalCount,
+			"monthly_count":    b.Stats.MonthlyCount,
+			"total_user_count": b.Stats.TotalUserCount,
+			"user_count":       b.Stats.UserCount,
+		},
+	}
+	if !b.Created.IsZero() {
+		o["created_at"] = formatTime(b.Created)
+	}
+	if b.Brewery != nil {
+		o["brewery"] = breweryJSON(b.Brewery)
+	}
+
+	return o
+}
+
+// breweryJSON returns the Untappd APIv4 JSON representation of a brewery.
+func breweryJSON(b *untappd.Brewery) object {
+	if b == nil {
+		return object{}
+	}
+
+	return object{
+		"brewery_id":          b.ID,
+		"brewery_name":        b.Name,
+		"brewery_slug":        b.Slug,
+		"brewery_label":       b.Logo.String(),
+		"country_name":        b.Country,
+		"brewery_active":      boolInt(b.Active),
+		"brewery_type":        string(b.Type),
+		"brewery_type_id":     b.TypeID,
+		"brewery_description": b.Description,
+		"location": object{
+			"brewery_city":  b.Location.City,
+			"brewery_state": b.Location.State,
+			"lat":           b.Location.Latitude,
+			"lng":           b.Location.Longitude,
+		},
+		"rating": object{
+			"count":        b.Rating.Count,
+			"rating_score": b.Rating.Score,
+		},
+	}
+}
+
+// userJSON returns the Untappd APIv4 JSON representation of a user.
+func userJSON(u *untappd.User) object {
+	if u == nil {
+		return object{}
+	}
+
+	return object{
+		"uid":          u.UID,
+		"id":           u.ID,
+		"user_name":    u.UserName,
+		"first_name":   u.FirstName,
+		"last_name":    u.LastName,
+		"location":     u.Location,
+		"bio":          u.Bio,
+		"is_supporter": boolInt(u.Supporter),
+		"user_avatar":  u.Avatar.String(),
+		"url":          u.URL.String(),
+		"untappd_url":  u.UntappdURL.String(),
+		"stats": object{
+			"total_badges":   u.Stats.TotalBadges,
+			"total_friends":  u.Stats.TotalFriends,
+			"total_checkins": u.Stats.TotalCheckins,
+			"total_beers":    u.Stats.TotalBeers,
+		},
+	}
+}
+
+// checkinJSON returns the Untappd APIv4 JSON representation of a checkin.
+func checkinJSON(c *untappd.Checkin) object {
+	o := object{
+		"checkin_id":      c.ID,
+		"checkin_comment": c.Comment,
+		"rating_score":    c.UserRating,
+		"user":            userJSON(c.User),
+		"beer":            object{},
+		"brewery":         breweryJSON(c.Brewery),
+		"comments":        object{"total_count": 0, "count": 0, "items": []object{}},
+		"toasts":          object{"total_count": 0, "count": 0, "items": []object{}},
+		"media":           object{"count": 0, "items": []object{}},
+		"badges":          object{"count": 0, "items": []object{}},
+		"source": object{
+			"app_name":    c.Source.Name,
+			"app_website": c.Source.Website.String(),
+		},
+
+		// The Untappd APIv4 returns an empty array for checkins with no venue
+		"venue": []object{},
+	}
+	if !c.Created.IsZero() {
+		o["created_at"] = formatTime(c.Created)
+	}
+	if c.Beer != nil {
+		o["beer"] = beerJSON(c.Beer)
+		if c.Brewery == nil {
+			o["brewery"] = breweryJSON(c.Beer.Brewery)
+		}
+	}
+	if v := c.Venue; v != nil {
+		o["venue"] = object{
+			"venue_id":   v.ID,
+			"venue_name": v.Name,
+		}
+	}
+
+	return o
+}
+
+// formatTime formats a timestamp as the Untappd APIv4 does.
+func formatTime(t time.Time) string {
+	return t.Format(timeFormat)
+}
+
+// boolInt returns the integer representation of a boolean used by the
+// Untappd APIv4.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
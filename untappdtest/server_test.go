@@ -0,0 +1,149 @@
+package untappdtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mdlayher/untappd"
+)
+
+// TestServerSeededData verifies that a Client can retrieve beers, users, and
+// checkins which were added to a Server.
+func TestServerSeededData(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	brewery := &untappd.Brewery{ID: 1, Name: "Kelso of Brooklyn"}
+	beer := &untappd.Beer{ID: 10, Name: "Brooklyn Bowl Pale Ale", Style: "American Pale Ale", Brewery: brewery}
+	user := &untappd.User{UserName: "gregavola", FirstName: "Greg"}
+
+	created := time.Date(2014, time.December, 13, 19, 15, 38, 0, time.UTC)
+	for i := 1; i <= 3; i++ {
+		s.AddCheckin(&untappd.Checkin{
+			ID:         int64(i),
+			Created:    created.Add(time.Duration(i) * time.Hour),
+			Comment:    "checkin " + strconv.Itoa(i),
+			UserRating: float64(i),
+			User:       user,
+			Beer:       beer,
+		})
+	}
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _, err := c.Beer.Info(beer.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != beer.Name || b.Brewery == nil || b.Brewery.Name != brewery.Name {
+		t.Fatalf("unexpected beer: %+v", b)
+	}
+
+	u, _, err := c.User.Info(user.UserName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.FirstName != user.FirstName {
+		t.Fatalf("unexpected user first name: %q != %q", u.FirstName, user.FirstName)
+	}
+
+	checkins, err := c.User.AllCheckins(context.Background(), user.UserName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(checkins); l != 3 {
+		t.Fatalf("unexpected number of checkins: %d != %d", l, 3)
+	}
+	if id := checkins[0].ID; id != 3 {
+		t.Fatalf("unexpected first checkin ID: %d != %d", id, 3)
+	}
+	if !checkins[0].Created.Equal(created.Add(3 * time.Hour)) {
+		t.Fatalf("unexpected first checkin time: %v", checkins[0].Created)
+	}
+
+	beers, _, err := c.User.Beers(user.UserName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if beers[0].Count != 3 || beers[0].UserRating != 3 {
+		t.Fatalf("unexpected beer count and rating: %d, %v", beers[0].Count, beers[0].UserRating)
+	}
+
+	found, _, err := c.Beer.Search("bowl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(found); l != 1 {
+		t.Fatalf("unexpected number of search results: %d != %d", l, 1)
+	}
+}
+
+// TestServerErrors verifies that a Server returns Untappd APIv4 errors for
+// missing data and injected failures.
+func TestServerErrors(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var uerr *untappd.Error
+	if _, _, err := c.Beer.Info(1, false); !errors.As(err, &uerr) || uerr.Type != "invalid_param" {
+		t.Fatalf("unexpected error for missing beer: %v", err)
+	}
+
+	s.AddBeer(&untappd.Beer{ID: 1})
+	// Errors which indicate maintenance halt all requests until the outage
+	// ends, so inject an error which only fails a single request
+	s.FailNext(untappd.Error{Code: http.StatusServiceUnavailable, Type: "unavailable", Detail: "overloaded"})
+
+	if _, _, err := c.Beer.Info(1, false); !errors.As(err, &uerr) || uerr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected error for injected failure: %v", err)
+	}
+	if _, _, err := c.Beer.Info(1, false); err != nil {
+		t.Fatalf("unexpected error after injected failure: %v", err)
+	}
+}
+
+// TestServerRateLimit verifies that a Server reports rate limit headers, and
+// rejects requests once no requests remain.
+func TestServerRateLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.AddBeer(&untappd.Beer{ID: 1})
+	s.SetRateLimit(2)
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.Beer.Info(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if rl := c.RateLimit(); rl.Limit != 2 || rl.Remaining != 1 {
+		t.Fatalf("unexpected rate limit: %+v", rl)
+	}
+
+	if _, _, err := c.Beer.Info(1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var uerr *untappd.Error
+	if _, _, err := c.Beer.Info(1, false); !errors.As(err, &uerr) || uerr.Code != http.StatusTooManyRequests {
+		t.Fatalf("unexpected error after rate limit exceeded: %v", err)
+	}
+}
@@ -1,9 +1,18 @@
 package untappd
 
 import (
+	"errors"
 	"net/url"
 )
 
+var (
+	// ErrPrivateProfile is matched by errors.Is when the Untappd APIv4
+	// refuses to return a User's activity, such as their checkins or
+	// friends, because the User's profile is private.  The returned error
+	// is an *Error.
+	ErrPrivateProfile = errors.New("private profile")
+)
+
 // UserService is a "service" which allows access to API methods involving users.
 type UserService struct {
 	client *Client
@@ -13,8 +22,8 @@ type UserService struct {
 // username, first and last name, avatar, cover photo, and various other attributes.
 type User struct {
 	// Metadata from Untappd.
-	UID       int
-	ID        int
+	UID       int64
+	ID        int64
 	UserName  string
 	FirstName string
 	LastName  string
@@ -22,6 +31,10 @@ type User struct {
 	Bio       string
 	Supporter bool
 
+	// IsPrivate reports whether this user's profile is private, so that
+	// only their friends may view their activity.
+	IsPrivate bool
+
 	// Links to the user's avatar, cover photo, custom URL, and Untappd profile.
 	Avatar     url.URL
 	CoverPhoto url.URL
@@ -31,37 +44,59 @@ type User struct {
 	// Struct containing this user's total badges, friends, checkins,
 	// and other various totals.
 	Stats UserStats
+
+	// Beers most recently checked in by this user, each with its Brewery,
+	// and photos most recently attached to this user's checkins.  They are
+	// only reported by UserService.Info.
+	RecentBeers []*Beer
+	RecentMedia []*Media
 }
 
 // UserStats is a struct which contains various statistics regarding an Untappd
 // user.
 type UserStats struct {
-	TotalBadges       int `json:"total_badges"`
-	TotalFriends      int `json:"total_friends"`
-	TotalCheckins     int `json:"total_checkins"`
-	TotalBeers        int `json:"total_beers"`
+	// Number of badges earned and friends made by this user.
+	TotalBadges  int `json:"total_badges"`
+	TotalFriends int `json:"total_friends"`
+
+	// Total number of checkins, and number of distinct beers checked in.
+	TotalCheckins int `json:"total_checkins"`
+	TotalBeers    int `json:"total_beers"`
+
+	// Number of beers this user has added to Untappd.
 	TotalCreatedBeers int `json:"total_created_beers"`
-	TotalFollowings   int `json:"total_followings"`
-	TotalPhotos       int `json:"total_photos"`
+
+	// Number of users and breweries this user follows, and number of
+	// photos this user has attached to checkins.
+	TotalFollowings int `json:"total_followings"`
+	TotalPhotos     int `json:"total_photos"`
 }
 
 // rawUser is the raw JSON representation of an Untappd user.  Its data is
 // unmarshaled from JSON and then exported to a User struct.
 type rawUser struct {
-	UID        int          `json:"uid"`
-	ID         int          `json:"id"`
-	UserName   string       `json:"user_name"`
-	FirstName  string       `json:"first_name"`
-	LastName   string       `json:"last_name"`
-	Avatar     responseURL  `json:"user_avatar"`
-	AvatarHD   responseURL  `json:"user_avatar_hd"`
-	CoverPhoto responseURL  `json:"user_cover_photo"`
-	Location   string       `json:"location"`
-	URL        responseURL  `json:"url"`
-	Bio        string       `json:"bio"`
-	Supporter  responseBool `json:"is_supporter"`
-	UntappdURL responseURL  `json:"untappd_url"`
-	Stats      UserStats    `json:"stats"`
+	UID        int64     `json:"uid"`
+	ID         int64     `json:"id"`
+	UserName   string    `json:"user_name"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	Avatar     URL       `json:"user_avatar"`
+	AvatarHD   URL       `json:"user_avatar_hd"`
+	CoverPhoto URL       `json:"user_cover_photo"`
+	Location   string    `json:"location"`
+	URL        URL       `json:"url"`
+	Bio        string    `json:"bio"`
+	Supporter  Bool      `json:"is_supporter"`
+	IsPrivate  Bool      `json:"is_private"`
+	UntappdURL URL       `json:"untappd_url"`
+	Stats      UserStats `json:"stats"`
+
+	RecentBrews struct {
+		Items rawRecentBrews `json:"items"`
+	} `json:"recent_brews"`
+	Media struct {
+		Items rawMediaItems `json:"items"`
+	} `json:"media"`
 }
 
 // export creates an exported User from a rawUser struct, allowing for more
@@ -79,10 +114,20 @@ func (r *rawUser) export() *User {
 		URL:        url.URL(r.URL),
 		Bio:        r.Bio,
 		Supporter:  bool(r.Supporter),
+		IsPrivate:  bool(r.IsPrivate),
 		UntappdURL: url.URL(r.UntappdURL),
 		Stats:      r.Stats,
 	}
 
+	for _, rb := range r.RecentBrews.Items {
+		b := rb.Beer.export()
+		b.Brewery = rb.Brewery.export()
+		u.RecentBeers = append(u.RecentBeers, b)
+	}
+	for i := range r.Media.Items {
+		u.RecentMedia = append(u.RecentMedia, r.Media.Items[i].export())
+	}
+
 	// If high resolution avatar is available, use it instead
 	if a := url.URL(r.AvatarHD); a.String() != "" {
 		u.Avatar = a
@@ -90,3 +135,16 @@ func (r *rawUser) export() *User {
 
 	return u
 }
+
+// rawRecentBrews is the raw JSON representation of the beers most recently
+// checked in by an Untappd user.
+type rawRecentBrews []struct {
+	Beer    rawBeer    `json:"beer"`
+	Brewery rawBrewery `json:"brewery"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawRecentBrews) UnmarshalJSON(data []byte) error {
+	type items rawRecentBrews
+	return unmarshalItems(data, (*items)(r))
+}
@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -24,6 +25,16 @@ func (u *UserService) Badges(username string) ([]*Badge, *http.Response, error)
 //
 // 50 badges is the maximum number of badges which may be returned by one call.
 func (u *UserService) BadgesOffsetLimit(username string, offset int, limit int) ([]*Badge, *http.Response, error) {
+	return u.badges(context.Background(), username, offset, limit)
+}
+
+// badges implements BadgesOffsetLimit, binding the HTTP request to the input
+// context.
+func (u *UserService) badges(ctx context.Context, username string, offset int, limit int) ([]*Badge, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxBadgesLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -38,7 +49,7 @@ func (u *UserService) BadgesOffsetLimit(username string, offset int, limit int)
 	}
 
 	// Perform request for user badges by username
-	res, err := u.client.request("GET", "user/badges/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/badges/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -49,5 +60,9 @@ func (u *UserService) BadgesOffsetLimit(username string, offset int, limit int)
 		badges[i] = v.Response.Items[i].export()
 	}
 
+	if err := u.client.afterResponse(res, badges); err != nil {
+		return nil, res, err
+	}
+
 	return badges, res, nil
 }
@@ -84,6 +84,17 @@ func TestClientUserBadgesOffsetLimitOK(t *testing.T) {
 		},
 	}
 
+	b := badges[0]
+	if !b.IsLevel {
+		t.Fatal("expected badge to be a level badge")
+	}
+	if b.Level != 1 || b.TotalLevels != 20 {
+		t.Fatalf("unexpected badge level: %d of %d", b.Level, b.TotalLevels)
+	}
+	if u := b.Media.Images().BestFor(1000); u.String() != "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_hd.jpg" {
+		t.Fatalf("unexpected badge HD image: %q", u.String())
+	}
+
 	for i := range badges {
 		if badges[i].ID != expected[i].ID {
 			t.Fatalf("unexpected badge ID: %d != %d", badges[i].ID, expected[i].ID)
@@ -135,10 +146,12 @@ var userBadgesJSON = []byte(`{
     "media": {
       "badge_image_sm": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_sm.jpg",
       "badge_image_md": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_md.jpg",
-      "badge_image_lg": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_lg.jpg"
+      "badge_image_lg": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_lg.jpg",
+      "badge_image_hd": "https://d1c8v1qci5en44.cloudfront.net/badges/bdg_ConcertVenue_hd.jpg"
     },
     "created_at": "Sat, 13 Dec 2014 19:15:41 +0000",
     "is_level": true,
+    "total_levels": 20,
     "category_id": 2,
     "levels": {
       "count": 1,
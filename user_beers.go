@@ -1,8 +1,8 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
-	"net/url"
 	"strconv"
 	"time"
 )
@@ -21,16 +21,46 @@ func (u *UserService) Beers(username string) ([]*Beer, *http.Response, error) {
 // BeersOffsetLimitSort queries for information about a User's checked-in beers,
 // but also accepts offset, limit, and sort parameters to enable paging and sorting
 // through more than 25 beers.  The username parameter specifies the User whose
-// checked-in beers will be returned.  Beers may be sorted using any of the provided
-// Sort constants with this package.
+// checked-in beers will be returned.  Beers may be sorted using any of the Sort
+// constants returned by UserBeerSorts.  Other sorts return an *InvalidSortError.
 //
 // 50 beers is the maximum number of beers which may be returned by one call.
 func (u *UserService) BeersOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
-	q := url.Values{
-		"offset": []string{strconv.Itoa(offset)},
-		"limit":  []string{strconv.Itoa(limit)},
-		"sort":   []string{string(sort)},
+	return u.BeersOffsetLimitSortFilter(username, offset, limit, sort, BeerFilter{})
+}
+
+// BeersOffsetLimitSortFilter queries for information about a User's checked-in
+// beers, accepting the same parameters as BeersOffsetLimitSort, as well as a
+// BeerFilter used to restrict the results.
+//
+// Style, brewery, and container criteria are applied by the Untappd APIv4,
+// while rating ranges are applied to each page of results by the client.
+// As a result, fewer than limit beers may be returned, even if more beers
+// are available at a higher offset.
+func (u *UserService) BeersOffsetLimitSortFilter(username string, offset int, limit int, sort Sort, f BeerFilter) ([]*Beer, *http.Response, error) {
+	beers, res, err := u.beers(context.Background(), username, offset, limit, sort, f)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return f.Filter(beers), res, nil
+}
+
+// beers is the backing method for user beers requests.  It returns a single
+// page of beers, with only the server-side criteria of the input BeerFilter
+// applied.
+func (u *UserService) beers(ctx context.Context, username string, offset int, limit int, sort Sort, f BeerFilter) ([]*Beer, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxBeersLimit); err != nil {
+		return nil, nil, err
 	}
+	if err := checkSort(sort, UserBeerSorts()); err != nil {
+		return nil, nil, err
+	}
+
+	q := f.values()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("sort", string(sort))
 
 	// Temporary struct to unmarshal beers JSON
 	var v struct {
@@ -38,10 +68,10 @@ func (u *UserService) BeersOffsetLimitSort(username string, offset int, limit in
 			Beers struct {
 				Count int `json:"count"`
 				Items []struct {
-					FirstCheckin  responseTime `json:"first_created_at"`
-					RecentCheckin responseTime `json:"recent_created_at"`
-					UserRating    float64      `json:"rating_score"`
-					Count         int          `json:"count"`
+					FirstCheckin  Time    `json:"first_created_at"`
+					RecentCheckin Time    `json:"recent_created_at"`
+					UserRating    float64 `json:"rating_score"`
+					Count         int     `json:"count"`
 
 					Beer    rawBeer    `json:"beer"`
 					Brewery rawBrewery `json:"brewery"`
@@ -51,7 +81,7 @@ func (u *UserService) BeersOffsetLimitSort(username string, offset int, limit in
 	}
 
 	// Perform request for user beers by username
-	res, err := u.client.request("GET", "user/beers/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/beers/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -72,5 +102,9 @@ func (u *UserService) BeersOffsetLimitSort(username string, offset int, limit in
 		beers[i].Count = v.Response.Beers.Items[i].Count
 	}
 
+	if err := u.client.afterResponse(res, beers); err != nil {
+		return nil, res, err
+	}
+
 	return beers, res, nil
 }
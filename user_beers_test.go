@@ -130,11 +130,43 @@ func TestClientUserBeersOffsetLimitOK(t *testing.T) {
 			t.Fatalf("unexpected beer UserRating: %f != %f", beers[i].UserRating, expected[i].UserRating)
 		}
 		if beers[i].Count != expected[i].Count {
-			t.Fatalf("unexpected beer Count: %q != %q", beers[i].Count, expected[i].Count)
+			t.Fatalf("unexpected beer Count: %d != %d", beers[i].Count, expected[i].Count)
 		}
 	}
 }
 
+// TestClientUserBeersOffsetLimitSortFilterOK verifies that
+// Client.User.BeersOffsetLimitSortFilter sends server-side filter parameters,
+// and applies rating ranges to the results.
+func TestClientUserBeersOffsetLimitSortFilterOK(t *testing.T) {
+	c, done := userBeersTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		assertParameters(t, r, url.Values{
+			"type_id":      []string{"10"},
+			"container_id": []string{"2"},
+			"brewery_id":   []string{""},
+		})
+
+		w.Write(userBeersJSON)
+	})
+	defer done()
+
+	beers, _, err := c.User.BeersOffsetLimitSortFilter("mdlayher", 0, 25, SortDate, BeerFilter{
+		MinRating:   4,
+		StyleID:     10,
+		ContainerID: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(beers); l != 1 {
+		t.Fatalf("unexpected number of beers: %d != %d", l, 1)
+	}
+	if id := beers[0].ID; id != 2 {
+		t.Fatalf("unexpected beer ID: %d != %d", id, 2)
+	}
+}
+
 // userBeersTestClient builds upon testClient, and adds additional sanity checks
 // for tests which target the user beers API.
 func userBeersTestClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
@@ -15,9 +15,9 @@ import (
 // For more granular control, and to page through the checkins list using ID
 // parameters, use CheckinsMinMaxIDLimit instead.
 func (u *UserService) Checkins(username string) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return u.CheckinsMinMaxIDLimit(username, 0, math.MaxInt32, 25)
+	// Use default parameters as specified by API, with no upper bound on
+	// checkin IDs so that no recent checkins are excluded.
+	return u.CheckinsMinMaxIDLimit(username, 0, math.MaxInt64, 25)
 }
 
 // CheckinsMinMaxIDLimit queries for information about a User's checkins,
@@ -25,16 +25,23 @@ func (u *UserService) Checkins(username string) ([]*Checkin, *http.Response, err
 // parameter to enable paging through checkins. The username parameter
 // specifies the User whose checkins will be returned.
 //
+// If maxID is math.MaxInt64, no upper bound is sent.
+//
 // 50 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (u *UserService) CheckinsMinMaxIDLimit(username string, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
+// one call.  If limit is greater than 50, the checkins are fetched using
+// multiple calls, and combined.
+func (u *UserService) CheckinsMinMaxIDLimit(username string, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkMinMaxIDLimit(minID, maxID, limit); err != nil {
+		return nil, nil, err
+	}
+
 	v := url.Values{}
 	if minID != 0 {
-		v.Set("min_id", strconv.Itoa(minID))
+		v.Set("min_id", strconv.FormatInt(minID, 10))
 	}
-	if maxID != math.MaxInt32 {
-		v.Set("max_id", strconv.Itoa(maxID))
+	if maxID != math.MaxInt64 {
+		v.Set("max_id", strconv.FormatInt(maxID, 10))
 	}
 	v.Set("limit", strconv.Itoa(limit))
-	return u.client.getCheckins("user/checkins/"+username, v)
+	return u.client.getCheckinsLimit("user/checkins/"+username, v, limit, maxCheckinsLimit)
 }
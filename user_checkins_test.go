@@ -39,15 +39,15 @@ func TestClientUserCheckinsMinMaxIDLimitBadUser(t *testing.T) {
 	})
 	defer done()
 
-	_, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, math.MaxInt32, 25)
+	_, _, err := c.User.CheckinsMinMaxIDLimit("foo", 0, math.MaxInt64, 25)
 	assertInvalidUserErr(t, err)
 }
 
 // TestClientUserCheckinsMinMaxIDLimitOK verifies that Client.User.CheckinsMinMaxIDLimit
 // returns a valid checkins list, when used with correct parameters.
 func TestClientUserCheckinsMinMaxIDLimitOffsetLimitOK(t *testing.T) {
-	var minID int
-	var maxID = math.MaxInt32
+	var minID int64
+	var maxID int64 = math.MaxInt64
 	var limit = 25
 	sLimit := strconv.Itoa(limit)
 
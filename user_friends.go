@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -28,6 +29,16 @@ func (u *UserService) Friends(username string) ([]*User, *http.Response, error)
 //
 // 25 friends is the maximum number of friends which may be returned by one call.
 func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int) ([]*User, *http.Response, error) {
+	return u.friends(context.Background(), username, offset, limit)
+}
+
+// friends implements FriendsOffsetLimit, binding the HTTP request to the
+// input context.
+func (u *UserService) friends(ctx context.Context, username string, offset int, limit int) ([]*User, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxFriendsLimit); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -44,7 +55,7 @@ func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int)
 	}
 
 	// Perform request for user friends by username
-	res, err := u.client.request("GET", "user/friends/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/friends/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -55,5 +66,9 @@ func (u *UserService) FriendsOffsetLimit(username string, offset int, limit int)
 		users[i] = v.Response.Items[i].User.export()
 	}
 
+	if err := u.client.afterResponse(res, users); err != nil {
+		return nil, res, err
+	}
+
 	return users, res, nil
 }
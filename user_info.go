@@ -1,14 +1,21 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 )
 
 // Info queries for information about a User with the specified username.
 // If the compact parameter is set to 'true', only basic user information will
-// be populated.
+// be populated.  If username is empty, information about the authenticated
+// user is returned.
 func (u *UserService) Info(username string, compact bool) (*User, *http.Response, error) {
+	return u.info(context.Background(), username, compact)
+}
+
+// info implements Info, binding the HTTP request to the input context.
+func (u *UserService) info(ctx context.Context, username string, compact bool) (*User, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -22,11 +29,21 @@ func (u *UserService) Info(username string, compact bool) (*User, *http.Response
 		} `json:"response"`
 	}
 
-	// Perform request for user information by username
-	res, err := u.client.request("GET", "user/info/"+username, nil, q, &v)
+	// Perform request for user information by username, or for the
+	// authenticated user
+	endpoint := "user/info"
+	if username != "" {
+		endpoint += "/" + username
+	}
+	res, err := u.client.requestContext(ctx, "GET", endpoint, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return v.Response.User.export(), res, nil
+	user := v.Response.User.export()
+	if err := u.client.afterResponse(res, user); err != nil {
+		return nil, res, err
+	}
+
+	return user, res, nil
 }
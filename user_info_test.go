@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strings"
@@ -46,6 +47,27 @@ func TestClientUserInfoCompactOK(t *testing.T) {
 	}
 }
 
+// TestClientUserInfoAuthenticated verifies that Client.User.Info requests
+// information about the authenticated user when no username is specified.
+func TestClientUserInfoAuthenticated(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/v4/user/info/" {
+			t.Fatalf("unexpected path: %q", p)
+		}
+
+		w.Write(gregavolaUserJSON)
+	})
+	defer done()
+
+	user, _, err := c.User.Info("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UserName != "gregavola" {
+		t.Fatalf("unexpected user: %q", user.UserName)
+	}
+}
+
 // TestClientUserInfoOK verifies that Client.User.Info returns a valid user when
 // provided with correct input parameters.
 func TestClientUserInfoOK(t *testing.T) {
@@ -74,6 +96,54 @@ func TestClientUserInfoOK(t *testing.T) {
 	if u := u.UserName; u != username {
 		t.Fatalf("unexpected username: %q != %q", u, username)
 	}
+
+	want := UserStats{
+		TotalBadges:       379,
+		TotalFriends:      1723,
+		TotalCheckins:     2197,
+		TotalBeers:        1187,
+		TotalCreatedBeers: 65,
+		TotalFollowings:   176,
+		TotalPhotos:       325,
+	}
+	if s := u.Stats; s != want {
+		t.Fatalf("unexpected stats:\n- want: %+v\n-  got: %+v", want, s)
+	}
+
+	if l := len(u.RecentBeers); l != 1 {
+		t.Fatalf("unexpected number of recent beers: %d != %d", l, 1)
+	}
+	if b := u.RecentBeers[0]; b.Name != "Brooklyn Bowl Pale Ale" || b.Brewery == nil || b.Brewery.Name != "Kelso of Brooklyn" {
+		t.Fatalf("unexpected recent beer: %+v", b)
+	}
+
+	if l := len(u.RecentMedia); l != 1 {
+		t.Fatalf("unexpected number of recent media: %d != %d", l, 1)
+	}
+	m := u.RecentMedia[0]
+	if m.PhotoID != 24739915 || m.CheckinID != 133319903 || m.Created.IsZero() {
+		t.Fatalf("unexpected recent media: %+v", m)
+	}
+	if m.Beer.Name != "Holiday Ale" || m.Brewery.Name != "Two Roads Brewing Company" || m.Venue != nil {
+		t.Fatalf("unexpected recent media checkin: %+v", m)
+	}
+	if p := m.Photo.BestFor(640); !strings.HasSuffix(p.Path, "_640x640.jpg") {
+		t.Fatalf("unexpected recent media photo: %q", p.String())
+	}
+}
+
+// Test_unmarshalItems verifies that lists of items are decoded whether they
+// are reported as an array or as a single bare object.
+func Test_unmarshalItems(t *testing.T) {
+	for _, s := range []string{`[{"photo_id":1}]`, ` {"photo_id":1}`} {
+		var items rawMediaItems
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].PhotoID != 1 {
+			t.Fatalf("unexpected items for %q: %+v", s, items)
+		}
+	}
 }
 
 // userInfoTestClient builds upon testClient, and adds additional sanity checks
@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -21,11 +22,24 @@ func (u *UserService) WishList(username string) ([]*Beer, *http.Response, error)
 // WishListOffsetLimitSort queries for information about a User's wish list beers,
 // but also accepts offset, limit, and sort parameters to enable paging and sorting
 // through more than 25 beers.  The username parameter specifies the User whose
-// wish list beers will be returned.  Beers may be sorted using any of the provided
-// Sort constants with this package.
+// wish list beers will be returned.  Beers may be sorted using any of the Sort
+// constants returned by WishListSorts.  Other sorts return an *InvalidSortError.
 //
 // 50 beers is the maximum number of beers which may be returned by one call.
 func (u *UserService) WishListOffsetLimitSort(username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
+	return u.wishList(context.Background(), username, offset, limit, sort)
+}
+
+// wishList implements WishListOffsetLimitSort, binding the HTTP request to
+// the input context.
+func (u *UserService) wishList(ctx context.Context, username string, offset int, limit int, sort Sort) ([]*Beer, *http.Response, error) {
+	if err := checkOffsetLimit(offset, limit, maxBeersLimit); err != nil {
+		return nil, nil, err
+	}
+	if err := checkSort(sort, WishListSorts()); err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{
 		"offset": []string{strconv.Itoa(offset)},
 		"limit":  []string{strconv.Itoa(limit)},
@@ -38,16 +52,16 @@ func (u *UserService) WishListOffsetLimitSort(username string, offset int, limit
 			Beers struct {
 				Count int `json:"count"`
 				Items []struct {
-					WishListed responseTime `json:"created_at"`
-					Beer       rawBeer      `json:"beer"`
-					Brewery    rawBrewery   `json:"brewery"`
+					WishListed Time       `json:"created_at"`
+					Beer       rawBeer    `json:"beer"`
+					Brewery    rawBrewery `json:"brewery"`
 				} `json:"items"`
 			} `json:"beers"`
 		} `json:"response"`
 	}
 
 	// Perform request for user beers by username
-	res, err := u.client.request("GET", "user/wishlist/"+username, nil, q, &v)
+	res, err := u.client.requestContext(ctx, "GET", "user/wishlist/"+username, nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
@@ -65,5 +79,9 @@ func (u *UserService) WishListOffsetLimitSort(username string, offset int, limit
 		beers[i].WishListed = time.Time(v.Response.Beers.Items[i].WishListed)
 	}
 
+	if err := u.client.afterResponse(res, beers); err != nil {
+		return nil, res, err
+	}
+
 	return beers, res, nil
 }
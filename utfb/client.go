@@ -0,0 +1,265 @@
+// Package utfb provides an Untappd for Business API client, written in Go.
+//
+// Untappd for Business (UTFB) is used by venues to manage their locations and
+// beer menus.  Its API is separate from the Untappd APIv4, with its own base
+// URL and authentication: requests are authenticated using the email address
+// of a UTFB account and an API token generated for it.  Documentation can be
+// found here: https://docs.business.untappd.com.
+package utfb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// jsonContentType is the content type for JSON data.
+	jsonContentType = "application/json"
+
+	// utfbUserAgent is the default user agent this package will report to
+	// the Untappd for Business API.
+	utfbUserAgent = "github.com/mdlayher/untappd/utfb"
+
+	// errorSnippetSize is the maximum number of bytes of a response body
+	// which are retained by an Error when the body cannot be decoded.
+	errorSnippetSize = 256
+
+	// DefaultBaseURL is the base URL of the Untappd for Business API used
+	// by a Client, if no other URL is specified using WithBaseURL.
+	DefaultBaseURL = "https://business.untappd.com/api/v1"
+)
+
+var (
+	// ErrNoEmail is returned when an empty email address is passed to
+	// NewClient.
+	ErrNoEmail = errors.New("no email")
+
+	// ErrNoToken is returned when an empty API token is passed to NewClient.
+	ErrNoToken = errors.New("no API token")
+)
+
+// Client is a HTTP client for the Untappd for Business API.  It enables access
+// to various methods of the Untappd for Business API.
+//
+// A Client is safe for concurrent use by multiple goroutines.  Its exported
+// fields must not be modified once the Client is in use.
+type Client struct {
+	UserAgent string
+
+	client *http.Client
+	url    *url.URL
+
+	email string
+	token string
+
+	// Methods involving locations
+	Locations *LocationService
+
+	// Methods involving menus, their sections, and their items
+	Menus      *MenuService
+	Sections   *SectionService
+	Items      *ItemService
+	Containers *ContainerService
+}
+
+// A ClientOption configures a Client.  ClientOptions are passed to NewClient.
+type ClientOption func(c *Client) error
+
+// WithBaseURL sets the base URL of the Untappd for Business API, in place of
+// DefaultBaseURL.  It is typically used to communicate with a fake server in
+// tests.
+func WithBaseURL(u *url.URL) ClientOption {
+	return func(c *Client) error {
+		uu := *u
+		uu.Path = strings.TrimSuffix(uu.Path, "/")
+		c.url = &uu
+		return nil
+	}
+}
+
+// NewClient creates a properly initialized instance of Client, using the input
+// email address and API token of an Untappd for Business account.
+//
+// To use a Client with a custom http.Client, pass one as a parameter.
+// If a nil *http.Client is specified, http.DefaultClient will be used.
+func NewClient(email string, token string, client *http.Client, opts ...ClientOption) (*Client, error) {
+	if email == "" {
+		return nil, ErrNoEmail
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	u, err := url.Parse(DefaultBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		UserAgent: utfbUserAgent,
+
+		client: client,
+		url:    u,
+
+		email: email,
+		token: token,
+	}
+
+	for _, o := range opts {
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Locations = &LocationService{client: c}
+	c.Menus = &MenuService{client: c}
+	c.Sections = &SectionService{client: c}
+	c.Items = &ItemService{client: c}
+	c.Containers = &ContainerService{client: c}
+
+	return c, nil
+}
+
+// Error is the error type returned when the Untappd for Business API returns
+// an HTTP status code outside the 200-range.
+type Error struct {
+	// HTTP status code of the response.
+	StatusCode int
+
+	// Type and description of the error, if reported.  If the response
+	// body could not be decoded, Message contains a snippet of it.
+	Type    string
+	Message string
+}
+
+// Error returns the string representation of an Error.
+func (e *Error) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("utfb: %d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("utfb: %d [%s]: %s", e.StatusCode, e.Type, e.Message)
+}
+
+// request creates a new HTTP request for the input method and API path, using
+// the input query parameters, and encoding body as JSON if it is not nil.  The
+// response body is decoded into v, if it is not nil.
+func (c *Client) request(ctx context.Context, method string, path string, query url.Values, body interface{}, v interface{}) (*http.Response, error) {
+	u := *c.url
+	u.Path += "/" + path
+	u.RawQuery = query.Encode()
+
+	var buf io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		buf = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(c.email, c.token)
+	req.Header.Set("Accept", jsonContentType)
+	req.Header.Set("User-Agent", c.UserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", jsonContentType)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err := checkResponse(res); err != nil {
+		return res, err
+	}
+
+	// Responses with no content, such as to DELETE requests, are not
+	// decoded
+	if v == nil || res.StatusCode == http.StatusNoContent {
+		return res, nil
+	}
+
+	return res, json.NewDecoder(res.Body).Decode(v)
+}
+
+// checkResponse returns an *Error if the input HTTP response has a status code
+// outside the 200-range.
+func checkResponse(res *http.Response) error {
+	if c := res.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+
+	// Errors are typically reported as an object with a type and message,
+	// but intermediaries may return other content
+	var body struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		if len(b) > errorSnippetSize {
+			b = b[:errorSnippetSize]
+		}
+
+		return &Error{
+			StatusCode: res.StatusCode,
+			Message:    strings.TrimSpace(string(b)),
+		}
+	}
+
+	e := &Error{
+		StatusCode: res.StatusCode,
+		Type:       body.Error.Type,
+		Message:    body.Error.Message,
+	}
+	if e.Message == "" {
+		e.Message = body.Message
+	}
+
+	return e
+}
+
+// String returns a pointer to the input string, for use in update structs
+// such as ItemUpdate.
+func String(s string) *string { return &s }
+
+// Int returns a pointer to the input int, for use in update structs such as
+// ItemUpdate.
+func Int(i int) *int { return &i }
+
+// Int64 returns a pointer to the input int64, for use in update structs such
+// as ItemUpdate.
+func Int64(i int64) *int64 { return &i }
+
+// Float64 returns a pointer to the input float64, for use in update structs
+// such as ItemUpdate.
+func Float64(f float64) *float64 { return &f }
+
+// Bool returns a pointer to the input bool, for use in update structs such as
+// MenuUpdate.
+func Bool(b bool) *bool { return &b }
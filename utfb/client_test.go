@@ -0,0 +1,135 @@
+package utfb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestNewClientBadArguments verifies that NewClient returns an error when an
+// empty email address or API token is passed.
+func TestNewClientBadArguments(t *testing.T) {
+	if _, err := NewClient("", "bar", nil); err != ErrNoEmail {
+		t.Fatalf("unexpected error for empty email: %v != %v", err, ErrNoEmail)
+	}
+	if _, err := NewClient("foo@example.com", "", nil); err != ErrNoToken {
+		t.Fatalf("unexpected error for empty token: %v != %v", err, ErrNoToken)
+	}
+}
+
+// TestClientRequestAuthentication verifies that requests are authenticated
+// using the Client's email address and API token.
+func TestClientRequestAuthentication(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		email, token, ok := r.BasicAuth()
+		if !ok || email != "foo@example.com" || token != "bar" {
+			t.Fatalf("unexpected credentials: %q, %q, %v", email, token, ok)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != utfbUserAgent {
+			t.Fatalf("unexpected User-Agent: %q != %q", ua, utfbUserAgent)
+		}
+
+		w.Write([]byte(`{"locations":[]}`))
+	})
+	defer done()
+
+	if _, _, err := c.Locations.List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientRequestError verifies that error responses are returned as an
+// *Error, whether or not they can be decoded.
+func TestClientRequestError(t *testing.T) {
+	var tests = []struct {
+		desc string
+		body string
+		want Error
+	}{
+		{
+			desc: "error object",
+			body: `{"error":{"type":"unauthorized","message":"Invalid token"}}`,
+			want: Error{StatusCode: http.StatusUnauthorized, Type: "unauthorized", Message: "Invalid token"},
+		},
+		{
+			desc: "message",
+			body: `{"message":"Invalid token"}`,
+			want: Error{StatusCode: http.StatusUnauthorized, Message: "Invalid token"},
+		},
+		{
+			desc: "not JSON",
+			body: "<html>Unauthorized</html>\n",
+			want: Error{StatusCode: http.StatusUnauthorized, Message: "<html>Unauthorized</html>"},
+		},
+	}
+
+	for _, tt := range tests {
+		c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(tt.body))
+		})
+
+		_, _, err := c.Locations.List(context.Background())
+		done()
+
+		var uerr *Error
+		if !errors.As(err, &uerr) {
+			t.Fatalf("[%s] unexpected error: %v", tt.desc, err)
+		}
+		if *uerr != tt.want {
+			t.Fatalf("[%s] unexpected Error:\n- want: %+v\n-  got: %+v", tt.desc, tt.want, *uerr)
+		}
+	}
+}
+
+// assertBody verifies that the JSON body of r is equivalent to want.
+func assertBody(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+
+	if ct := r.Header.Get("Content-Type"); ct != jsonContentType {
+		t.Fatalf("unexpected Content-Type: %q != %q", ct, jsonContentType)
+	}
+
+	var got, wantV interface{}
+	if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantV); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, wantV) {
+		b, _ := json.Marshal(got)
+		t.Fatalf("unexpected request body:\n- want: %s\n-  got: %s", want, b)
+	}
+}
+
+// testClient creates a Client which communicates with a test HTTP server,
+// whose requests are handled by fn.  Invoke the returned function to close
+// the server.
+func testClient(t *testing.T, fn func(t *testing.T, w http.ResponseWriter, r *http.Request)) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", jsonContentType)
+
+		if fn != nil {
+			fn(t, w, r)
+		}
+	}))
+
+	u, err := url.Parse(srv.URL + "/api/v1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewClient("foo@example.com", "bar", nil, WithBaseURL(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, srv.Close
+}
@@ -0,0 +1,205 @@
+package utfb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ItemService is a "service" which allows access to API methods involving
+// the items of menu sections.
+type ItemService struct {
+	client *Client
+}
+
+// ContainerService is a "service" which allows access to API methods involving
+// the serving sizes and prices of menu items.
+type ContainerService struct {
+	client *Client
+}
+
+// An Item is a beer or other drink listed in a Section of a Menu.
+type Item struct {
+	ID        int64 `json:"id"`
+	SectionID int64 `json:"section_id"`
+
+	// ID of the corresponding beer in the Untappd APIv4, for use with
+	// untappd.BeerService.Info, or zero if the item is not linked to a
+	// beer.
+	UntappdID int64 `json:"untappd_id"`
+
+	Name        string `json:"name"`
+	Brewery     string `json:"brewery"`
+	Style       string `json:"style"`
+	Description string `json:"description"`
+
+	// ABV is a percentage, such as 5.5.  ABV and IBU are zero if unknown.
+	ABV Number `json:"abv"`
+	IBU Number `json:"ibu"`
+
+	Position int `json:"position"`
+
+	// Serving sizes and prices of this item.
+	Containers []*Container `json:"containers"`
+
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// A Container is a serving size of an Item, such as a pint or a bottle, and
+// its price.
+type Container struct {
+	ID     int64 `json:"id"`
+	ItemID int64 `json:"item_id"`
+
+	// ID and name of the container size, such as "16oz Draft".
+	ContainerSizeID int64 `json:"container_size_id"`
+	ContainerSize   struct {
+		Name string `json:"name"`
+	} `json:"container_size"`
+
+	// Price is a decimal amount in the currency of the Location, such as
+	// "6.50", and is empty if no price is listed.
+	Price string `json:"price"`
+
+	Position int `json:"position"`
+
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// An ItemUpdate specifies the fields of an Item to create or modify.  Fields
+// which are nil are left unchanged.
+//
+// When creating an Item, UntappdID may be set to populate the Item's name,
+// brewery, and style from the corresponding beer in the Untappd APIv4.
+type ItemUpdate struct {
+	UntappdID   *int64   `json:"untappd_id,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+	Brewery     *string  `json:"brewery,omitempty"`
+	Style       *string  `json:"style,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	ABV         *float64 `json:"abv,omitempty"`
+	IBU         *float64 `json:"ibu,omitempty"`
+	Position    *int     `json:"position,omitempty"`
+}
+
+// A ContainerUpdate specifies the fields of a Container to create or modify.
+// Fields which are nil are left unchanged.
+type ContainerUpdate struct {
+	ContainerSizeID *int64  `json:"container_size_id,omitempty"`
+	Price           *string `json:"price,omitempty"`
+	Position        *int    `json:"position,omitempty"`
+}
+
+// A Number is a numeric value which the Untappd for Business API may report as
+// either a JSON number or a string, such as 5.5 or "5.5".  Empty strings and
+// null are decoded as zero.
+type Number float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("utfb: invalid number %s", data)
+	}
+
+	*n = Number(f)
+	return nil
+}
+
+// List queries for the Items of the Section with the specified ID, including
+// their Containers.
+func (i *ItemService) List(ctx context.Context, sectionID int64) ([]*Item, *http.Response, error) {
+	var v struct {
+		Items []*Item `json:"items"`
+	}
+
+	res, err := i.client.request(ctx, "GET", "sections/"+strconv.FormatInt(sectionID, 10)+"/items", nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Items, res, nil
+}
+
+// Create adds an Item to the Section with the specified ID, and returns the
+// created Item.
+func (i *ItemService) Create(ctx context.Context, sectionID int64, u ItemUpdate) (*Item, *http.Response, error) {
+	return i.send(ctx, "POST", "sections/"+strconv.FormatInt(sectionID, 10)+"/items", u)
+}
+
+// Update modifies the Item with the specified ID, and returns the updated
+// Item.
+func (i *ItemService) Update(ctx context.Context, id int64, u ItemUpdate) (*Item, *http.Response, error) {
+	return i.send(ctx, "PUT", "items/"+strconv.FormatInt(id, 10), u)
+}
+
+// Delete removes the Item with the specified ID, along with its Containers.
+func (i *ItemService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return i.client.request(ctx, "DELETE", "items/"+strconv.FormatInt(id, 10), nil, nil, nil)
+}
+
+// send sends an ItemUpdate using the input method and path, and returns the
+// resulting Item.
+func (i *ItemService) send(ctx context.Context, method string, path string, u ItemUpdate) (*Item, *http.Response, error) {
+	body := struct {
+		Item ItemUpdate `json:"item"`
+	}{Item: u}
+
+	var v struct {
+		Item *Item `json:"item"`
+	}
+
+	res, err := i.client.request(ctx, method, path, nil, body, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Item, res, nil
+}
+
+// Create adds a Container to the Item with the specified ID, and returns the
+// created Container.
+func (c *ContainerService) Create(ctx context.Context, itemID int64, u ContainerUpdate) (*Container, *http.Response, error) {
+	return c.send(ctx, "POST", "items/"+strconv.FormatInt(itemID, 10)+"/containers", u)
+}
+
+// Update modifies the Container with the specified ID, such as to change its
+// price, and returns the updated Container.
+func (c *ContainerService) Update(ctx context.Context, id int64, u ContainerUpdate) (*Container, *http.Response, error) {
+	return c.send(ctx, "PUT", "containers/"+strconv.FormatInt(id, 10), u)
+}
+
+// Delete removes the Container with the specified ID.
+func (c *ContainerService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return c.client.request(ctx, "DELETE", "containers/"+strconv.FormatInt(id, 10), nil, nil, nil)
+}
+
+// send sends a ContainerUpdate using the input method and path, and returns
+// the resulting Container.
+func (c *ContainerService) send(ctx context.Context, method string, path string, u ContainerUpdate) (*Container, *http.Response, error) {
+	body := struct {
+		Container ContainerUpdate `json:"container"`
+	}{Container: u}
+
+	var v struct {
+		Container *Container `json:"container"`
+	}
+
+	res, err := c.client.request(ctx, method, path, nil, body, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Container, res, nil
+}
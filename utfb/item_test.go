@@ -0,0 +1,119 @@
+package utfb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// TestClientItems verifies that Client.Items lists, creates, updates, and
+// deletes the Items of a Section, including their Containers.
+func TestClientItems(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/sections/100/items":
+			w.Write([]byte(`{"items":[{"id":1000,"section_id":100,"name":"Two Hearted Ale","containers":[
+				{"id":5,"item_id":1000,"container_size_id":2,"container_size":{"name":"16oz Draft"},"price":"6.50"}
+			]}]}`))
+		case "POST /api/v1/sections/100/items":
+			assertBody(t, r, `{"item":{"untappd_id":3784}}`)
+			w.Write([]byte(`{"item":{"id":1001,"section_id":100,"untappd_id":3784,"name":"Two Hearted Ale","abv":"7.0"}}`))
+		case "PUT /api/v1/items/1001":
+			assertBody(t, r, `{"item":{"position":0,"abv":7.1}}`)
+			w.Write([]byte(`{"item":{"id":1001,"abv":7.1,"position":0}}`))
+		case "DELETE /api/v1/items/1001":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer done()
+
+	ctx := context.Background()
+
+	items, _, err := c.Items.List(ctx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(items); l != 1 || len(items[0].Containers) != 1 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if ct := items[0].Containers[0]; ct.ContainerSize.Name != "16oz Draft" || ct.Price != "6.50" {
+		t.Fatalf("unexpected Container: %+v", ct)
+	}
+
+	i, _, err := c.Items.Create(ctx, 100, ItemUpdate{UntappdID: Int64(3784)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.ID != 1001 || i.Name != "Two Hearted Ale" || i.ABV != 7 {
+		t.Fatalf("unexpected created Item: %+v", i)
+	}
+
+	i, _, err = c.Items.Update(ctx, 1001, ItemUpdate{ABV: Float64(7.1), Position: Int(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.ABV != 7.1 {
+		t.Fatalf("unexpected updated Item: %+v", i)
+	}
+
+	if _, err := c.Items.Delete(ctx, 1001); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientContainers verifies that Client.Containers creates, updates, and
+// deletes the Containers of an Item.
+func TestClientContainers(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/items/1000/containers":
+			assertBody(t, r, `{"container":{"container_size_id":2,"price":"6.50"}}`)
+			w.Write([]byte(`{"container":{"id":5,"item_id":1000,"container_size_id":2,"price":"6.50"}}`))
+		case "PUT /api/v1/containers/5":
+			assertBody(t, r, `{"container":{"price":"7.00"}}`)
+			w.Write([]byte(`{"container":{"id":5,"item_id":1000,"container_size_id":2,"price":"7.00"}}`))
+		case "DELETE /api/v1/containers/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer done()
+
+	ctx := context.Background()
+
+	ct, _, err := c.Containers.Create(ctx, 1000, ContainerUpdate{
+		ContainerSizeID: Int64(2),
+		Price:           String("6.50"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct.ID != 5 || ct.Price != "6.50" {
+		t.Fatalf("unexpected created Container: %+v", ct)
+	}
+
+	ct, _, err = c.Containers.Update(ctx, 5, ContainerUpdate{Price: String("7.00")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct.Price != "7.00" {
+		t.Fatalf("unexpected updated Container: %+v", ct)
+	}
+
+	if _, err := c.Containers.Delete(ctx, 5); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNumberUnmarshalJSON verifies that a Number rejects values which are
+// not numeric.
+func TestNumberUnmarshalJSON(t *testing.T) {
+	var n Number
+	if err := json.Unmarshal([]byte(`"foo"`), &n); err == nil {
+		t.Fatal("expected an error for a non-numeric string")
+	}
+}
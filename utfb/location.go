@@ -0,0 +1,70 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LocationService is a "service" which allows access to API methods involving
+// locations.
+type LocationService struct {
+	client *Client
+}
+
+// A Location is a venue managed using Untappd for Business.
+type Location struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	// ID of the corresponding venue in the Untappd APIv4, for use with
+	// untappd.VenueService.Info, or zero if the location is not linked to
+	// a venue.
+	UntappdVenueID int64 `json:"untappd_venue_id"`
+
+	// Address and contact information.
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postcode"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+	Website    string `json:"website"`
+
+	// IANA time zone name of the location, such as "America/New_York".
+	TimeZone string `json:"timezone"`
+
+	// Times when this location was created and last updated.
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// List queries for all locations which the Client's account may access.
+func (l *LocationService) List(ctx context.Context) ([]*Location, *http.Response, error) {
+	var v struct {
+		Locations []*Location `json:"locations"`
+	}
+
+	res, err := l.client.request(ctx, "GET", "locations", nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Locations, res, nil
+}
+
+// Get queries for information about the Location with the specified ID.
+func (l *LocationService) Get(ctx context.Context, id int64) (*Location, *http.Response, error) {
+	var v struct {
+		Location *Location `json:"location"`
+	}
+
+	res, err := l.client.request(ctx, "GET", "locations/"+strconv.FormatInt(id, 10), nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Location, res, nil
+}
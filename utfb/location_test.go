@@ -0,0 +1,89 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientLocationsList verifies that Client.Locations.List returns each
+// Location of the account.
+func TestClientLocationsList(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if m, p := r.Method, r.URL.Path; m != "GET" || p != "/api/v1/locations" {
+			t.Fatalf("unexpected request: %s %s", m, p)
+		}
+
+		w.Write([]byte(`{"locations":[` + locationJSON + `]}`))
+	})
+	defer done()
+
+	locations, _, err := c.Locations.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(locations); l != 1 {
+		t.Fatalf("unexpected number of locations: %d != %d", l, 1)
+	}
+	assertLocation(t, locations[0])
+}
+
+// TestClientLocationsGet verifies that Client.Locations.Get returns a single
+// Location.
+func TestClientLocationsGet(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/api/v1/locations/1" {
+			t.Fatalf("unexpected URL path: %q", p)
+		}
+
+		w.Write([]byte(`{"location":` + locationJSON + `}`))
+	})
+	defer done()
+
+	l, _, err := c.Locations.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertLocation(t, l)
+}
+
+// assertLocation verifies that l was decoded from locationJSON.
+func assertLocation(t *testing.T, l *Location) {
+	t.Helper()
+
+	want := Location{
+		ID:             1,
+		Name:           "Bell's Eccentric Cafe",
+		UntappdVenueID: 2,
+		Address1:       "355 E Kalamazoo Ave",
+		City:           "Kalamazoo",
+		Region:         "MI",
+		PostalCode:     "49007",
+		Country:        "United States",
+		TimeZone:       "America/Detroit",
+		Created:        time.Date(2016, time.May, 2, 0, 48, 33, 0, time.UTC),
+		Updated:        time.Date(2016, time.May, 21, 0, 15, 40, 0, time.UTC),
+	}
+	if *l != want {
+		t.Fatalf("unexpected Location:\n- want: %+v\n-  got: %+v", want, *l)
+	}
+}
+
+// Canned location JSON used in tests
+const locationJSON = `{
+  "id": 1,
+  "name": "Bell's Eccentric Cafe",
+  "untappd_venue_id": 2,
+  "address1": "355 E Kalamazoo Ave",
+  "address2": "",
+  "city": "Kalamazoo",
+  "region": "MI",
+  "postcode": "49007",
+  "country": "United States",
+  "timezone": "America/Detroit",
+  "created_at": "2016-05-02T00:48:33Z",
+  "updated_at": "2016-05-21T00:15:40Z"
+}`
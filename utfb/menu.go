@@ -0,0 +1,100 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MenuService is a "service" which allows access to API methods involving
+// menus.
+type MenuService struct {
+	client *Client
+}
+
+// A Menu is a menu of a Location, divided into Sections.
+type Menu struct {
+	ID          int64  `json:"id"`
+	LocationID  int64  `json:"location_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Whether this menu is hidden from the public, and its order among the
+	// menus of its Location.
+	Unpublished bool `json:"unpublished"`
+	Position    int  `json:"position"`
+
+	// Sections of this menu.  Sections are only reported by MenuService.Get
+	// when the full menu is requested.
+	Sections []*Section `json:"sections"`
+
+	// Times when this menu was created and last updated.
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// List queries for the Menus of the Location with the specified ID.  Sections
+// are not included.
+func (m *MenuService) List(ctx context.Context, locationID int64) ([]*Menu, *http.Response, error) {
+	var v struct {
+		Menus []*Menu `json:"menus"`
+	}
+
+	path := "locations/" + strconv.FormatInt(locationID, 10) + "/menus"
+	res, err := m.client.request(ctx, "GET", path, nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Menus, res, nil
+}
+
+// A MenuUpdate specifies the fields of a Menu to modify.  Fields which are nil
+// are left unchanged.
+type MenuUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Unpublished *bool   `json:"unpublished,omitempty"`
+	Position    *int    `json:"position,omitempty"`
+}
+
+// Get queries for the Menu with the specified ID.  If full is true, the Menu's
+// Sections and their Items are included.
+func (m *MenuService) Get(ctx context.Context, id int64, full bool) (*Menu, *http.Response, error) {
+	var q url.Values
+	if full {
+		q = url.Values{"full": []string{"true"}}
+	}
+
+	var v struct {
+		Menu *Menu `json:"menu"`
+	}
+
+	res, err := m.client.request(ctx, "GET", "menus/"+strconv.FormatInt(id, 10), q, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Menu, res, nil
+}
+
+// Update modifies the Menu with the specified ID, and returns the updated
+// Menu.
+func (m *MenuService) Update(ctx context.Context, id int64, u MenuUpdate) (*Menu, *http.Response, error) {
+	body := struct {
+		Menu MenuUpdate `json:"menu"`
+	}{Menu: u}
+
+	var v struct {
+		Menu *Menu `json:"menu"`
+	}
+
+	res, err := m.client.request(ctx, "PUT", "menus/"+strconv.FormatInt(id, 10), nil, body, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Menu, res, nil
+}
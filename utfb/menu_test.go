@@ -0,0 +1,95 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestClientMenusList verifies that Client.Menus.List returns each Menu of a
+// Location.
+func TestClientMenusList(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/api/v1/locations/1/menus" {
+			t.Fatalf("unexpected URL path: %q", p)
+		}
+
+		w.Write([]byte(`{"menus":[{"id":10,"location_id":1,"name":"Taps","position":0},{"id":11,"location_id":1,"name":"Bottles","unpublished":true,"position":1}]}`))
+	})
+	defer done()
+
+	menus, _, err := c.Menus.List(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(menus); l != 2 {
+		t.Fatalf("unexpected number of menus: %d != %d", l, 2)
+	}
+	if m := menus[1]; m.ID != 11 || m.LocationID != 1 || m.Name != "Bottles" || !m.Unpublished || m.Position != 1 {
+		t.Fatalf("unexpected second Menu: %+v", m)
+	}
+}
+
+// TestClientMenusGetFull verifies that Client.Menus.Get requests and decodes
+// the Sections and Items of a full Menu.
+func TestClientMenusGetFull(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Path; p != "/api/v1/menus/10" {
+			t.Fatalf("unexpected URL path: %q", p)
+		}
+		if q := r.URL.Query().Get("full"); q != "true" {
+			t.Fatalf("unexpected full parameter: %q", q)
+		}
+
+		w.Write([]byte(`{"menu":{"id":10,"name":"Taps","sections":[{"id":100,"menu_id":10,"name":"On Tap","items":[
+			{"id":1000,"section_id":100,"untappd_id":3784,"name":"Two Hearted Ale","brewery":"Bell's Brewery","style":"IPA - American","abv":"7.0","ibu":55},
+			{"id":1001,"section_id":100,"name":"House Cider","abv":"","ibu":null}
+		]}]}}`))
+	})
+	defer done()
+
+	m, _, err := c.Menus.Get(context.Background(), 10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(m.Sections); l != 1 {
+		t.Fatalf("unexpected number of sections: %d != %d", l, 1)
+	}
+	s := m.Sections[0]
+	if s.ID != 100 || s.MenuID != 10 || s.Name != "On Tap" || len(s.Items) != 2 {
+		t.Fatalf("unexpected Section: %+v", s)
+	}
+	if i := s.Items[0]; i.UntappdID != 3784 || i.Name != "Two Hearted Ale" || i.ABV != 7 || i.IBU != 55 {
+		t.Fatalf("unexpected first Item: %+v", i)
+	}
+	if i := s.Items[1]; i.ABV != 0 || i.IBU != 0 {
+		t.Fatalf("unexpected second Item: %+v", i)
+	}
+}
+
+// TestClientMenusUpdate verifies that Client.Menus.Update sends only the
+// fields which are set.
+func TestClientMenusUpdate(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if m, p := r.Method, r.URL.Path; m != "PUT" || p != "/api/v1/menus/10" {
+			t.Fatalf("unexpected request: %s %s", m, p)
+		}
+		assertBody(t, r, `{"menu":{"name":"Taps","unpublished":false}}`)
+
+		w.Write([]byte(`{"menu":{"id":10,"name":"Taps"}}`))
+	})
+	defer done()
+
+	m, _, err := c.Menus.Update(context.Background(), 10, MenuUpdate{
+		Name:        String("Taps"),
+		Unpublished: Bool(false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != 10 || m.Name != "Taps" {
+		t.Fatalf("unexpected updated Menu: %+v", m)
+	}
+}
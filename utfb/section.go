@@ -0,0 +1,87 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SectionService is a "service" which allows access to API methods involving
+// the sections of menus.
+type SectionService struct {
+	client *Client
+}
+
+// A Section is a group of Items in a Menu, such as "On Tap" or "Bottles".
+type Section struct {
+	ID          int64  `json:"id"`
+	MenuID      int64  `json:"menu_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Position    int    `json:"position"`
+
+	Items []*Item `json:"items"`
+
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+// A SectionUpdate specifies the fields of a Section to create or modify.
+// Fields which are nil are left unchanged.
+type SectionUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Position    *int    `json:"position,omitempty"`
+}
+
+// List queries for the Sections of the Menu with the specified ID, including
+// their Items.
+func (s *SectionService) List(ctx context.Context, menuID int64) ([]*Section, *http.Response, error) {
+	var v struct {
+		Sections []*Section `json:"sections"`
+	}
+
+	res, err := s.client.request(ctx, "GET", "menus/"+strconv.FormatInt(menuID, 10)+"/sections", nil, nil, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Sections, res, nil
+}
+
+// Create adds a Section to the Menu with the specified ID, and returns the
+// created Section.
+func (s *SectionService) Create(ctx context.Context, menuID int64, u SectionUpdate) (*Section, *http.Response, error) {
+	return s.send(ctx, "POST", "menus/"+strconv.FormatInt(menuID, 10)+"/sections", u)
+}
+
+// Update modifies the Section with the specified ID, and returns the updated
+// Section.
+func (s *SectionService) Update(ctx context.Context, id int64, u SectionUpdate) (*Section, *http.Response, error) {
+	return s.send(ctx, "PUT", "sections/"+strconv.FormatInt(id, 10), u)
+}
+
+// Delete removes the Section with the specified ID, along with its Items.
+func (s *SectionService) Delete(ctx context.Context, id int64) (*http.Response, error) {
+	return s.client.request(ctx, "DELETE", "sections/"+strconv.FormatInt(id, 10), nil, nil, nil)
+}
+
+// send sends a SectionUpdate using the input method and path, and returns the
+// resulting Section.
+func (s *SectionService) send(ctx context.Context, method string, path string, u SectionUpdate) (*Section, *http.Response, error) {
+	body := struct {
+		Section SectionUpdate `json:"section"`
+	}{Section: u}
+
+	var v struct {
+		Section *Section `json:"section"`
+	}
+
+	res, err := s.client.request(ctx, method, path, nil, body, &v)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return v.Section, res, nil
+}
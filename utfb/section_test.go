@@ -0,0 +1,67 @@
+package utfb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestClientSections verifies that Client.Sections lists, creates, updates,
+// and deletes the Sections of a Menu.
+func TestClientSections(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/menus/10/sections":
+			w.Write([]byte(`{"sections":[{"id":100,"menu_id":10,"name":"On Tap","items":[{"id":1000,"name":"Two Hearted Ale"}]}]}`))
+		case "POST /api/v1/menus/10/sections":
+			assertBody(t, r, `{"section":{"name":"Cans","position":1}}`)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"section":{"id":101,"menu_id":10,"name":"Cans","position":1}}`))
+		case "PUT /api/v1/sections/101":
+			assertBody(t, r, `{"section":{"description":"16oz cans"}}`)
+			w.Write([]byte(`{"section":{"id":101,"menu_id":10,"name":"Cans","description":"16oz cans"}}`))
+		case "DELETE /api/v1/sections/101":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer done()
+
+	ctx := context.Background()
+
+	sections, _, err := c.Sections.List(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(sections); l != 1 || len(sections[0].Items) != 1 {
+		t.Fatalf("unexpected sections: %+v", sections)
+	}
+
+	s, _, err := c.Sections.Create(ctx, 10, SectionUpdate{
+		Name:     String("Cans"),
+		Position: Int(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID != 101 || s.Name != "Cans" {
+		t.Fatalf("unexpected created Section: %+v", s)
+	}
+
+	s, _, err = c.Sections.Update(ctx, 101, SectionUpdate{Description: String("16oz cans")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Description != "16oz cans" {
+		t.Fatalf("unexpected updated Section: %+v", s)
+	}
+
+	res, err := c.Sections.Delete(ctx, 101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected HTTP status: %d != %d", res.StatusCode, http.StatusNoContent)
+	}
+}
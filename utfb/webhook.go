@@ -0,0 +1,195 @@
+package utfb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the HTTP header which carries the signature of a
+	// webhook payload.
+	SignatureHeader = "X-Untappd-Signature"
+
+	// maxWebhookSize is the maximum size of a webhook payload accepted by
+	// ParseEvent.
+	maxWebhookSize = 1 << 20
+)
+
+var (
+	// ErrInvalidSignature is returned by ParseEvent when a webhook payload's
+	// signature is missing or does not match the payload.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrNoSecret is returned by ParseEvent when the webhook secret is
+	// empty.  Any payload could be signed with an empty secret, so no
+	// payload is accepted.
+	ErrNoSecret = errors.New("no webhook secret configured")
+
+	// ErrPayloadTooLarge is returned by ParseEvent when a webhook payload
+	// is larger than 1 MiB.
+	ErrPayloadTooLarge = errors.New("webhook payload too large")
+)
+
+// An EventType identifies the kind of change reported by a webhook Event.
+type EventType string
+
+// EventType constants which correspond to each of the webhook events sent by
+// Untappd for Business.
+const (
+	// A Menu was published.  Event.Menu contains the full Menu, including
+	// its Sections and Items.
+	EventMenuPublished EventType = "menu.published"
+
+	// An Item was added to or removed from a Section.  Event.Item contains
+	// the Item.
+	EventItemAdded   EventType = "item.added"
+	EventItemRemoved EventType = "item.removed"
+)
+
+// An Event is a webhook payload sent by Untappd for Business when a menu of
+// a Location changes.
+type Event struct {
+	// Unique ID of this event, which may be used to ignore duplicate
+	// deliveries, and the kind of change it reports.
+	ID   string    `json:"id"`
+	Type EventType `json:"type"`
+
+	// Time when the change occurred, and the Location it occurred at.
+	Created    time.Time `json:"created_at"`
+	LocationID int64     `json:"location_id"`
+
+	// The Menu or Item affected by the change, depending on Type.  Fields
+	// which do not apply to Type are nil.
+	Menu *Menu `json:"-"`
+	Item *Item `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var v struct {
+		event
+		Data struct {
+			Menu *Menu `json:"menu"`
+			Item *Item `json:"item"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Event(v.event)
+	e.Menu = v.Data.Menu
+	e.Item = v.Data.Item
+	return nil
+}
+
+// Sign returns the signature of a webhook payload using the input secret, as
+// sent by Untappd for Business in SignatureHeader: the hex-encoded HMAC-SHA256
+// of the payload.
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseEvent reads a webhook Event from the body of an HTTP request, after
+// verifying its signature using the input secret.  If the signature is missing
+// or incorrect, ErrInvalidSignature is returned.  If secret is empty,
+// ErrNoSecret is returned, and if the payload is larger than 1 MiB,
+// ErrPayloadTooLarge is returned.
+func ParseEvent(r *http.Request, secret []byte) (*Event, error) {
+	if len(secret) == 0 {
+		return nil, ErrNoSecret
+	}
+
+	// Read one byte past the limit to detect oversized payloads
+	b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxWebhookSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	// The signature may be prefixed with its algorithm
+	sig := strings.TrimPrefix(r.Header.Get(SignatureHeader), "sha256=")
+	want, err := hex.DecodeString(sig)
+	if err != nil || sig == "" {
+		return nil, ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(b)
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return nil, ErrInvalidSignature
+	}
+
+	var e Event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// A WebhookHandler is an http.Handler which receives webhook Events from
+// Untappd for Business.
+type WebhookHandler struct {
+	// Secret is the webhook secret configured in Untappd for Business,
+	// which is used to verify each Event's signature.
+	Secret []byte
+
+	// Handle is invoked with each verified Event.  If it returns an error,
+	// the request fails with HTTP 500, so that the Event may be delivered
+	// again.
+	Handle func(ctx context.Context, e *Event) error
+}
+
+// ServeHTTP implements http.Handler.  Requests which are not POST requests
+// fail with HTTP 405, requests with an invalid signature fail with HTTP 401,
+// payloads larger than 1 MiB fail with HTTP 413, and payloads which cannot be
+// decoded fail with HTTP 400.  If Secret is empty, all requests fail with
+// HTTP 500.  Once an Event is handled, HTTP 204 is returned.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	e, err := ParseEvent(r, h.Secret)
+	switch {
+	case err == ErrInvalidSignature:
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err == ErrPayloadTooLarge:
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	case err == ErrNoSecret:
+		http.Error(w, "webhook not configured", http.StatusInternalServerError)
+		return
+	case err != nil:
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	if h.Handle != nil {
+		if err := h.Handle(r.Context(), e); err != nil {
+			http.Error(w, "failed to handle webhook", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,170 @@
+package utfb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWebhookHandler verifies that a WebhookHandler verifies signatures and
+// passes decoded Events to its Handle function.
+func TestWebhookHandler(t *testing.T) {
+	secret := []byte("secret")
+	payload := `{"id":"evt_1","type":"item.added","created_at":"2016-05-21T00:15:40Z","location_id":1,
+		"data":{"item":{"id":1000,"section_id":100,"untappd_id":3784,"name":"Two Hearted Ale"}}}`
+
+	large := `{"id":"` + strings.Repeat("a", maxWebhookSize) + `"}`
+
+	var events []*Event
+	h := &WebhookHandler{
+		Secret: secret,
+		Handle: func(ctx context.Context, e *Event) error {
+			if e.ID == "evt_fail" {
+				return errors.New("failed")
+			}
+
+			events = append(events, e)
+			return nil
+		},
+	}
+
+	var tests = []struct {
+		desc      string
+		method    string
+		body      string
+		signature string
+		code      int
+	}{
+		{
+			desc:      "OK",
+			method:    "POST",
+			body:      payload,
+			signature: Sign(secret, []byte(payload)),
+			code:      http.StatusNoContent,
+		},
+		{
+			desc:      "OK with algorithm prefix",
+			method:    "POST",
+			body:      payload,
+			signature: "sha256=" + Sign(secret, []byte(payload)),
+			code:      http.StatusNoContent,
+		},
+		{
+			desc:   "GET request",
+			method: "GET",
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			desc:   "no signature",
+			method: "POST",
+			body:   payload,
+			code:   http.StatusUnauthorized,
+		},
+		{
+			desc:      "wrong secret",
+			method:    "POST",
+			body:      payload,
+			signature: Sign([]byte("foo"), []byte(payload)),
+			code:      http.StatusUnauthorized,
+		},
+		{
+			desc:      "invalid payload",
+			method:    "POST",
+			body:      "foo",
+			signature: Sign(secret, []byte("foo")),
+			code:      http.StatusBadRequest,
+		},
+		{
+			desc:      "payload too large",
+			method:    "POST",
+			body:      large,
+			signature: Sign(secret, []byte(large)),
+			code:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:      "handler error",
+			method:    "POST",
+			body:      `{"id":"evt_fail"}`,
+			signature: Sign(secret, []byte(`{"id":"evt_fail"}`)),
+			code:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+		if tt.signature != "" {
+			r.Header.Set(SignatureHeader, tt.signature)
+		}
+		w := httptest.NewRecorder()
+
+		h.ServeHTTP(w, r)
+		if w.Code != tt.code {
+			t.Fatalf("[%s] unexpected HTTP status: %d != %d", tt.desc, w.Code, tt.code)
+		}
+	}
+
+	if l := len(events); l != 2 {
+		t.Fatalf("unexpected number of events: %d != %d", l, 2)
+	}
+	e := events[0]
+	if e.ID != "evt_1" || e.Type != EventItemAdded || e.LocationID != 1 || e.Created.IsZero() {
+		t.Fatalf("unexpected Event: %+v", e)
+	}
+	if e.Menu != nil || e.Item == nil || e.Item.UntappdID != 3784 {
+		t.Fatalf("unexpected Event data: %+v, %+v", e.Menu, e.Item)
+	}
+}
+
+// TestWebhookHandlerNoSecret verifies that a WebhookHandler with no secret
+// rejects payloads signed with an empty secret.
+func TestWebhookHandlerNoSecret(t *testing.T) {
+	payload := `{"id":"evt_1","type":"item.added"}`
+
+	var handled bool
+	h := &WebhookHandler{
+		Handle: func(ctx context.Context, e *Event) error {
+			handled = true
+			return nil
+		},
+	}
+
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(payload))
+	r.Header.Set(SignatureHeader, Sign(nil, []byte(payload)))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("unexpected HTTP status: %d != %d", w.Code, http.StatusInternalServerError)
+	}
+	if handled {
+		t.Fatal("payload signed with empty secret was handled")
+	}
+
+	r = httptest.NewRequest("POST", "/webhook", strings.NewReader(payload))
+	r.Header.Set(SignatureHeader, Sign(nil, []byte(payload)))
+	if _, err := ParseEvent(r, nil); err != ErrNoSecret {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestParseEventMenuPublished verifies that ParseEvent decodes the full Menu
+// of a menu published event.
+func TestParseEventMenuPublished(t *testing.T) {
+	secret := []byte("secret")
+	payload := `{"id":"evt_2","type":"menu.published","location_id":1,
+		"data":{"menu":{"id":10,"name":"Taps","sections":[{"id":100,"items":[{"id":1000}]}]}}}`
+
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(payload))
+	r.Header.Set(SignatureHeader, Sign(secret, []byte(payload)))
+
+	e, err := ParseEvent(r, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != EventMenuPublished || e.Menu == nil || len(e.Menu.Sections) != 1 || len(e.Menu.Sections[0].Items) != 1 {
+		t.Fatalf("unexpected Event: %+v", e)
+	}
+}
@@ -0,0 +1,153 @@
+package untappd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// maxRating is the highest rating which may be given to a beer, and
+	// ratingIncrement is the smallest increment between ratings.
+	maxRating       = 5
+	ratingIncrement = 0.25
+
+	// maxLocalRadius is the largest radius which may be used to query local
+	// checkins.
+	maxLocalRadius = 25
+)
+
+var (
+	// ErrInvalidParam is returned when a request parameter is not accepted
+	// by the Untappd APIv4.  Errors of type *ParamError and *InvalidSortError
+	// match ErrInvalidParam when using errors.Is.
+	ErrInvalidParam = errors.New("invalid parameter")
+)
+
+// A ParamError is returned when a request parameter is not accepted by the
+// Untappd APIv4.  Parameters are validated before a request is sent, so an
+// invalid parameter does not consume a rate-limited request.
+type ParamError struct {
+	// Name of the API parameter, such as "limit", and the reason it is
+	// invalid.
+	Param  string
+	Reason string
+}
+
+// Error returns the string representation of a ParamError.
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid parameter %q: %s", e.Param, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidParam.
+func (e *ParamError) Is(target error) bool {
+	return target == ErrInvalidParam
+}
+
+// paramErrorf creates a *ParamError for the input parameter, with a
+// formatted reason.
+func paramErrorf(param string, format string, v ...interface{}) error {
+	return &ParamError{
+		Param:  param,
+		Reason: fmt.Sprintf(format, v...),
+	}
+}
+
+// checkOffsetLimit validates offset and limit parameters for an endpoint
+// which returns at most max items per call.
+func checkOffsetLimit(offset int, limit int, max int) error {
+	if offset < 0 {
+		return paramErrorf("offset", "%d must not be negative", offset)
+	}
+	if limit < 1 {
+		return paramErrorf("limit", "%d must be at least 1", limit)
+	}
+	if limit > max {
+		return paramErrorf("limit", "%d exceeds maximum of %d", limit, max)
+	}
+
+	return nil
+}
+
+// checkMinMaxIDLimit validates checkin ID and limit parameters for a checkins
+// endpoint.  Limits greater than the endpoint's maximum are permitted, because
+// such requests are split into multiple calls.
+func checkMinMaxIDLimit(minID int64, maxID int64, limit int) error {
+	if minID < 0 {
+		return paramErrorf("min_id", "%d must not be negative", minID)
+	}
+	if maxID < 0 {
+		return paramErrorf("max_id", "%d must not be negative", maxID)
+	}
+	if limit < 1 {
+		return paramErrorf("limit", "%d must be at least 1", limit)
+	}
+
+	return nil
+}
+
+// checkCoordinates validates a latitude and longitude pair, using the input
+// parameter names.
+func checkCoordinates(latParam string, lngParam string, lat float64, lng float64) error {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return paramErrorf(latParam, "%v must be between -90 and 90", lat)
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return paramErrorf(lngParam, "%v must be between -180 and 180", lng)
+	}
+
+	return nil
+}
+
+// validate validates the parameters of a CheckinRequest.
+func (r CheckinRequest) validate() error {
+	if err := checkCoordinates("geolat", "geolng", r.Latitude, r.Longitude); err != nil {
+		return err
+	}
+
+	// A rating of zero indicates no rating
+	if r.Rating < 0 || r.Rating > maxRating {
+		return paramErrorf("rating", "%v must be between 0 and %d", r.Rating, maxRating)
+	}
+	if n := r.Rating / ratingIncrement; n != math.Trunc(n) {
+		return paramErrorf("rating", "%v must be a multiple of %v", r.Rating, ratingIncrement)
+	}
+
+	if r.Foursquare && r.FoursquareID == "" {
+		return paramErrorf("foursquare", "requires a Foursquare venue ID")
+	}
+
+	return nil
+}
+
+// validate validates the parameters of a LocalCheckinsRequest.
+func (r LocalCheckinsRequest) validate() error {
+	if err := checkCoordinates("lat", "lng", r.Latitude, r.Longitude); err != nil {
+		return err
+	}
+
+	// Zero values indicate that optional parameters are omitted
+	if r.MinID < 0 {
+		return paramErrorf("min_id", "%d must not be negative", r.MinID)
+	}
+	if r.MaxID < 0 {
+		return paramErrorf("max_id", "%d must not be negative", r.MaxID)
+	}
+	if r.Limit < 0 {
+		return paramErrorf("limit", "%d must not be negative", r.Limit)
+	}
+
+	if r.Radius < 0 || r.Radius > maxLocalRadius {
+		return paramErrorf("radius", "%d must be between 0 and %d", r.Radius, maxLocalRadius)
+	}
+	switch r.Units {
+	case "", DistanceMiles, DistanceKilometers:
+	default:
+		return paramErrorf("dist_pref", "unknown distance unit %q", r.Units)
+	}
+	if r.Units != "" && r.Radius == 0 {
+		return paramErrorf("dist_pref", "requires a radius")
+	}
+
+	return nil
+}
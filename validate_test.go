@@ -0,0 +1,137 @@
+package untappd
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+)
+
+// TestParameterValidation verifies that invalid parameters are rejected with
+// a *ParamError before a request is sent.
+func TestParameterValidation(t *testing.T) {
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("request should not be sent: %s", r.URL)
+	})
+	defer done()
+
+	var tests = []struct {
+		desc  string
+		param string
+		fn    func() error
+	}{
+		{
+			desc:  "negative offset",
+			param: "offset",
+			fn: func() error {
+				_, _, err := c.User.FriendsOffsetLimit("mdlayher", -1, 25)
+				return err
+			},
+		},
+		{
+			desc:  "limit exceeds maximum",
+			param: "limit",
+			fn: func() error {
+				_, _, err := c.User.FriendsOffsetLimit("mdlayher", 0, 26)
+				return err
+			},
+		},
+		{
+			desc:  "zero limit",
+			param: "limit",
+			fn: func() error {
+				_, _, err := c.Brewery.SearchOffsetLimit("russian river", 0, 0)
+				return err
+			},
+		},
+		{
+			desc:  "negative max ID",
+			param: "max_id",
+			fn: func() error {
+				_, _, err := c.Beer.CheckinsMinMaxIDLimit(1, 0, -1, 25)
+				return err
+			},
+		},
+		{
+			desc:  "rating increment",
+			param: "rating",
+			fn: func() error {
+				_, _, err := c.Auth.Checkin(CheckinRequest{BeerID: 1, Rating: 3.3})
+				return err
+			},
+		},
+		{
+			desc:  "rating range",
+			param: "rating",
+			fn: func() error {
+				_, _, err := c.Auth.Checkin(CheckinRequest{BeerID: 1, Rating: 5.25})
+				return err
+			},
+		},
+		{
+			desc:  "checkin latitude",
+			param: "geolat",
+			fn: func() error {
+				_, _, err := c.Auth.Checkin(CheckinRequest{BeerID: 1, Latitude: 91})
+				return err
+			},
+		},
+		{
+			desc:  "foursquare without ID",
+			param: "foursquare",
+			fn: func() error {
+				_, _, err := c.Auth.Checkin(CheckinRequest{BeerID: 1, Foursquare: true})
+				return err
+			},
+		},
+		{
+			desc:  "local longitude",
+			param: "lng",
+			fn: func() error {
+				_, _, err := c.Local.Checkins(0, math.NaN())
+				return err
+			},
+		},
+		{
+			desc:  "local radius",
+			param: "radius",
+			fn: func() error {
+				_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{Radius: 26, Units: DistanceMiles})
+				return err
+			},
+		},
+		{
+			desc:  "local units without radius",
+			param: "dist_pref",
+			fn: func() error {
+				_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{Units: DistanceKilometers})
+				return err
+			},
+		},
+		{
+			desc:  "local unknown units",
+			param: "dist_pref",
+			fn: func() error {
+				_, _, err := c.Local.CheckinsMinMaxIDLimitRadius(LocalCheckinsRequest{Radius: 1, Units: Distance("ft")})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.fn()
+
+			var perr *ParamError
+			if !errors.As(err, &perr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if perr.Param != tt.param {
+				t.Fatalf("unexpected parameter: %q != %q", perr.Param, tt.param)
+			}
+			if !errors.Is(err, ErrInvalidParam) {
+				t.Fatal("expected error to match ErrInvalidParam")
+			}
+		})
+	}
+}
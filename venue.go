@@ -1,6 +1,10 @@
 package untappd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -8,29 +12,76 @@ import (
 // venue's name, location, categories, and various other metadata.
 type Venue struct {
 	// Metadata from Untappd.
-	ID      int
+	ID      int64
 	Name    string
 	Updated time.Time
 
-	// Category of thie venue.
+	// Primary category of this venue.
 	Category string
 
+	// All categories assigned to this venue.
+	Categories []VenueCategory
+
 	// Is this a public venue?
 	Public bool
 
+	// Has this venue been verified by its owner?
+	Verified bool
+
+	// ID of the parent venue which contains this venue, such as a stadium
+	// containing a bar, or zero if this venue has no parent.
+	ParentID int64
+
 	// Location of this venue.
 	Location VenueLocation
 
+	// Social media and website contact information for this venue.
+	Contact VenueContact
+
 	// Foursquare data.
 	Foursquare VenueFoursquare
 
-	// Popular beers at this venue.
+	// Popular beers at this venue, each with its Brewery.
 	TopBeers []*Beer
 
+	// Popular beers at this venue, along with their checkin counts, in the
+	// same order as TopBeers.
+	PopularBeers []VenueBeer
+
+	// Photos recently attached to checkins at this venue.
+	Media []*Media
+
 	// Checkins at this venue.
 	Checkins []*Checkin
 }
 
+// HasCategory reports whether this venue has been assigned a category with
+// the specified name, such as "Brewery" or "Bar".  Names are compared
+// case-insensitively.
+func (v *Venue) HasCategory(name string) bool {
+	for _, c := range v.Categories {
+		if strings.EqualFold(c.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VenueBeer is one of the popular beers at an Untappd venue, along with its
+// checkin counts at the venue.
+type VenueBeer struct {
+	Beer *Beer
+
+	// Number of checkins of this beer at the venue, in total and by the
+	// authenticated user.
+	TotalCount int
+	YourCount  int
+
+	// Time of the first checkin of this beer at the venue.
+	Created time.Time
+}
+
 // VenueService is a "service" which allows access to API methods involving
 // venues.
 type VenueService struct {
@@ -49,6 +100,57 @@ type VenueLocation struct {
 	Longitude float64 `json:"lng"`
 }
 
+// VenueCategory represents a category assigned to an Untappd venue.  Venue
+// categories are sourced from Foursquare, and one category is marked as the
+// venue's primary category.
+type VenueCategory struct {
+	ID      string `json:"category_id"`
+	Name    string `json:"category_name"`
+	Primary bool   `json:"is_primary"`
+}
+
+// VenueContact represents an Untappd venue's social media and website contact
+// information.
+type VenueContact struct {
+	// Social media account names.
+	Twitter   string
+	Instagram string
+
+	// Link to the venue's website.
+	URL url.URL
+}
+
+// rawVenueContact is the raw JSON representation of Untappd venue contact
+// information.  Its data is unmarshaled from JSON and then exported to a
+// VenueContact struct.
+type rawVenueContact struct {
+	Twitter   string `json:"twitter"`
+	Instagram string `json:"instagram"`
+	URL       URL    `json:"venue_url"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *rawVenueContact) UnmarshalJSON(data []byte) error {
+	// Venues without contact information may report an empty array instead
+	// of an object
+	if bytes.Equal(data, []byte("[]")) {
+		return nil
+	}
+
+	type contact rawVenueContact
+	return json.Unmarshal(data, (*contact)(r))
+}
+
+// export creates an exported VenueContact from a rawVenueContact struct,
+// allowing for more useful structures to be created for client consumption.
+func (r *rawVenueContact) export() VenueContact {
+	return VenueContact{
+		Twitter:   r.Twitter,
+		Instagram: r.Instagram,
+		URL:       url.URL(r.URL),
+	}
+}
+
 // VenueFoursquare represents an Untappd venue's Foursquare data, and contains
 // the venue's Foursquare ID and URL.
 type VenueFoursquare struct {
@@ -59,21 +161,28 @@ type VenueFoursquare struct {
 // rawVenue is the raw JSON representation of an Untappd venue.  Its data is
 // unmarshaled from JSON and then exported to a Venue struct.
 type rawVenue struct {
-	ID         int             `json:"venue_id"`
-	Name       string          `json:"venue_name"`
-	Updated    responseTime    `json:"last_updated"`
-	Category   string          `json:"primary_category"`
+	ID         int64  `json:"venue_id"`
+	Name       string `json:"venue_name"`
+	Updated    Time   `json:"last_updated"`
+	Category   string `json:"primary_category"`
+	Categories struct {
+		Count int             `json:"count"`
+		Items []VenueCategory `json:"items"`
+	} `json:"categories"`
 	Public     bool            `json:"public_venue"`
+	Verified   Bool            `json:"is_verified"`
+	ParentID   int64           `json:"parent_venue_id"`
 	Location   VenueLocation   `json:"location"`
+	Contact    rawVenueContact `json:"contact"`
 	Foursquare VenueFoursquare `json:"foursquare"`
 	TopBeers   struct {
 		Offset int `json:"offset"`
 		Limit  int `json:"limit"`
 		Count  int `json:"count"`
 		Items  []struct {
-			Created    responseTime `json:"created_at"`
-			TotalCount int          `json:"total_count"`
-			YourCount  int          `json:"your_count"`
+			Created    Time `json:"created_at"`
+			TotalCount int  `json:"total_count"`
+			YourCount  int  `json:"your_count"`
 
 			Beer    rawBeer    `json:"beer"`
 			Brewery rawBrewery `json:"brewery"`
@@ -83,15 +192,31 @@ type rawVenue struct {
 		Count int           `json:"count"`
 		Items []*rawCheckin `json:"items"`
 	} `json:"checkins"`
+	Media struct {
+		Items rawMediaItems `json:"items"`
+	} `json:"media"`
 }
 
 // export creates an exported Venue from a rawVenue struct, allowing for
 // more useful structures to be created for client consumption.
 func (r *rawVenue) export() *Venue {
 	beers := make([]*Beer, r.TopBeers.Count)
-	for i := range r.TopBeers.Items {
-		beers[i] = r.TopBeers.Items[i].Beer.export()
-		beers[i].Brewery = r.TopBeers.Items[i].Brewery.export()
+	var popular []VenueBeer
+	for i, item := range r.TopBeers.Items {
+		beers[i] = item.Beer.export()
+		beers[i].Brewery = item.Brewery.export()
+
+		popular = append(popular, VenueBeer{
+			Beer:       beers[i],
+			TotalCount: item.TotalCount,
+			YourCount:  item.YourCount,
+			Created:    time.Time(item.Created),
+		})
+	}
+
+	var media []*Media
+	for i := range r.Media.Items {
+		media = append(media, r.Media.Items[i].export())
 	}
 
 	checkins := make([]*Checkin, r.Checkins.Count)
@@ -104,10 +229,17 @@ func (r *rawVenue) export() *Venue {
 		Name:       r.Name,
 		Updated:    time.Time(r.Updated),
 		Category:   r.Category,
+		Categories: r.Categories.Items,
 		Public:     r.Public,
+		Verified:   bool(r.Verified),
+		ParentID:   r.ParentID,
 		Location:   r.Location,
+		Contact:    r.Contact.export(),
 		Foursquare: r.Foursquare,
 		TopBeers:   beers,
 		Checkins:   checkins,
+
+		PopularBeers: popular,
+		Media:        media,
 	}
 }
@@ -14,10 +14,10 @@ import (
 // This method returns up to 25 of the Venue's most recent checkins.
 // For more granular control, and to page through the checkins list using ID
 // parameters, use CheckinsMinMaxIDLimit instead.
-func (v *VenueService) Checkins(id int) ([]*Checkin, *http.Response, error) {
-	// Use default parameters as specified by API.  Max ID is somewhat
-	// arbitrary, but should provide plenty of headroom, just in case.
-	return v.CheckinsMinMaxIDLimit(id, 0, math.MaxInt32, 25)
+func (v *VenueService) Checkins(id int64) ([]*Checkin, *http.Response, error) {
+	// Use default parameters as specified by API, with no upper bound on
+	// checkin IDs so that no recent checkins are excluded.
+	return v.CheckinsMinMaxIDLimit(id, 0, math.MaxInt64, 25)
 }
 
 // CheckinsMinMaxIDLimit queries for information about a Venue's checkins,
@@ -26,12 +26,23 @@ func (v *VenueService) Checkins(id int) ([]*Checkin, *http.Response, error) {
 // specifies the Venue ID, which will return a list of recent checkins
 // for a given Venue.
 //
+// If maxID is math.MaxInt64, no upper bound is sent.
+//
 // 25 checkins is the maximum number of checkins which may be returned by
-// one call.
-func (v *VenueService) CheckinsMinMaxIDLimit(id int, minID int, maxID int, limit int) ([]*Checkin, *http.Response, error) {
-	return v.client.getCheckins("venue/checkins/"+strconv.Itoa(id), url.Values{
-		"min_id": []string{strconv.Itoa(minID)},
-		"max_id": []string{strconv.Itoa(maxID)},
+// one call.  If limit is greater than 25, the checkins are fetched using
+// multiple calls, and combined.
+func (v *VenueService) CheckinsMinMaxIDLimit(id int64, minID int64, maxID int64, limit int) ([]*Checkin, *http.Response, error) {
+	if err := checkMinMaxIDLimit(minID, maxID, limit); err != nil {
+		return nil, nil, err
+	}
+
+	q := url.Values{
+		"min_id": []string{strconv.FormatInt(minID, 10)},
 		"limit":  []string{strconv.Itoa(limit)},
-	})
+	}
+	if maxID != math.MaxInt64 {
+		q.Set("max_id", strconv.FormatInt(maxID, 10))
+	}
+
+	return v.client.getCheckinsLimit("venue/checkins/"+strconv.FormatInt(id, 10), q, limit, maxFeedCheckinsLimit)
 }
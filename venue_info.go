@@ -1,6 +1,7 @@
 package untappd
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -9,7 +10,12 @@ import (
 // Info queries for information about a Venue with the specified ID.
 // If the compact parameter is set to 'true', only basic venue information will
 // be populated.
-func (b *VenueService) Info(id int, compact bool) (*Venue, *http.Response, error) {
+func (b *VenueService) Info(id int64, compact bool) (*Venue, *http.Response, error) {
+	return b.info(context.Background(), id, compact)
+}
+
+// info implements Info, binding the HTTP request to the input context.
+func (b *VenueService) info(ctx context.Context, id int64, compact bool) (*Venue, *http.Response, error) {
 	// Determine if a compact response is requested
 	q := url.Values{}
 	if compact {
@@ -24,10 +30,15 @@ func (b *VenueService) Info(id int, compact bool) (*Venue, *http.Response, error
 	}
 
 	// Perform request for venue information by ID
-	res, err := b.client.request("GET", "venue/info/"+strconv.Itoa(id), nil, q, &v)
+	res, err := b.client.requestContext(ctx, "GET", "venue/info/"+strconv.FormatInt(id, 10), nil, q, &v)
 	if err != nil {
 		return nil, res, err
 	}
 
-	return v.Response.Venue.export(), res, nil
+	venue := v.Response.Venue.export()
+	if err := b.client.afterResponse(res, venue); err != nil {
+		return nil, res, err
+	}
+
+	return venue, res, nil
 }
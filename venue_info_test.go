@@ -11,8 +11,8 @@ import (
 // TestClientVenueInfoBadVenue verifies that Client.Venue.Info returns an error when
 // an invalid venue is queried.
 func TestClientVenueInfoBadVenue(t *testing.T) {
-	venueID := -1
-	sVenueID := strconv.Itoa(venueID)
+	venueID := int64(-1)
+	sVenueID := strconv.FormatInt(venueID, 10)
 
 	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/venue/info/" + sVenueID + "/"
@@ -39,8 +39,9 @@ func TestClientVenueInfoCompactOK(t *testing.T) {
 
 		// In the future, we may return compact canned venue data here.
 		// For now, write a mostly empty JSON object is enough to get
-		// test coverage.
-		w.Write([]byte(`{"response":{"venue":{"id":1}}}`))
+		// test coverage.  Venues without contact information report an
+		// empty array.
+		w.Write([]byte(`{"response":{"venue":{"id":1,"contact":[]}}}`))
 	})
 	defer done()
 
@@ -52,8 +53,8 @@ func TestClientVenueInfoCompactOK(t *testing.T) {
 // TestClientVenueInfoOK verifies that Client.Venue.Info returns a valid venue when
 // provided with correct input parameters.
 func TestClientVenueInfoOK(t *testing.T) {
-	venueID := 1021
-	sVenueID := strconv.Itoa(venueID)
+	venueID := int64(1021)
+	sVenueID := strconv.FormatInt(venueID, 10)
 
 	c, done := venueInfoTestClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		path := "/v4/venue/info/" + sVenueID + "/"
@@ -81,6 +82,15 @@ func TestClientVenueInfoOK(t *testing.T) {
 	if c := v.Location.City; c != venueCity {
 		t.Fatalf("unexpected Location.City: %q != %q", c, venueCity)
 	}
+	if !v.Verified {
+		t.Fatal("expected venue to be verified")
+	}
+	if id := v.ParentID; id != 1020 {
+		t.Fatalf("unexpected ParentID: %d != %d", id, 1020)
+	}
+	if c := v.Contact; c.Twitter != "BellsBrewery" || c.Instagram != "bellsbrewery" || c.URL.Host != "www.bellsbeer.com" {
+		t.Fatalf("unexpected Contact: %+v", c)
+	}
 	foursquareID := "4a8f8efcf964a520761520e3"
 	if c := v.Foursquare.ID; c != foursquareID {
 		t.Fatalf("unexpected Foursquare.ID: %q != %q", c, foursquareID)
@@ -90,6 +100,19 @@ func TestClientVenueInfoOK(t *testing.T) {
 		t.Fatalf("unexpected Foursquare.URL: %q != %q", c, foursquareURL)
 	}
 
+	if l := len(v.Categories); l != 2 {
+		t.Fatalf("unexpected number of Categories: %d != %d", l, 2)
+	}
+	if c := v.Categories[0]; c.Name != "Brewery" || !c.Primary {
+		t.Fatalf("unexpected Categories[0]: %+v", c)
+	}
+	if !v.HasCategory("bar") {
+		t.Fatal("expected venue to have category \"bar\"")
+	}
+	if v.HasCategory("Restaurant") {
+		t.Fatal("expected venue not to have category \"Restaurant\"")
+	}
+
 	beerName := "Beer Name"
 	if c := v.TopBeers[0].Name; c != beerName {
 		t.Fatalf("unexpected TopBeers[0].Name: %q != %q", c, beerName)
@@ -104,6 +127,20 @@ func TestClientVenueInfoOK(t *testing.T) {
 	if c := v.Checkins[0].Brewery.Name; c != beerBrewery {
 		t.Fatalf("unexpected Checkins[0].Brewery.Name: %q != %q", c, beerBrewery)
 	}
+
+	if l := len(v.PopularBeers); l != 1 {
+		t.Fatalf("unexpected number of PopularBeers: %d != %d", l, 1)
+	}
+	if b := v.PopularBeers[0]; b.Beer != v.TopBeers[0] || b.TotalCount != 1 || b.YourCount != 0 || b.Created.IsZero() {
+		t.Fatalf("unexpected PopularBeers[0]: %+v", b)
+	}
+
+	if l := len(v.Media); l != 1 {
+		t.Fatalf("unexpected number of Media: %d != %d", l, 1)
+	}
+	if m := v.Media[0]; m.PhotoID != 1 || m.CheckinID != 2 || m.User.UserName != "mdlayher" || m.Beer.Name != beerName {
+		t.Fatalf("unexpected Media[0]: %+v", m)
+	}
 }
 
 // venueInfoTestClient builds upon testClient, and adds additional sanity checks
@@ -144,9 +181,32 @@ var venueJSON = []byte(`
     "venue": {
       "venue_id": 1021,
       "venue_name": "Bell's Eccentric Cafe & General Store",
+      "primary_category": "Nightlife Spot",
+      "categories": {
+        "count": 2,
+        "items": [
+          {
+            "category_name": "Brewery",
+            "category_id": "50327c8591d4c4b30a586d5d",
+            "is_primary": true
+          },
+          {
+            "category_name": "Bar",
+            "category_id": "4bf58dd8d48988d116941735",
+            "is_primary": false
+          }
+        ]
+      },
+      "is_verified": 1,
+      "parent_venue_id": 1020,
       "location": {
         "venue_city": "Kalamazoo"
       },
+      "contact": {
+        "twitter": "BellsBrewery",
+        "instagram": "bellsbrewery",
+        "venue_url": "http://www.bellsbeer.com"
+      },
       "foursquare": {
         "foursquare_id": "4a8f8efcf964a520761520e3",
         "foursquare_url": "http://4sq.com/dheQpl"
@@ -182,6 +242,30 @@ var venueJSON = []byte(`
             }
           }
         ]
+      },
+      "media": {
+        "count": 1,
+        "items": [
+          {
+            "photo_id": 1,
+            "photo": {
+              "photo_img_sm": "https://untappd.akamaized.net/photo/1_100x100.jpg",
+              "photo_img_og": "https://untappd.akamaized.net/photo/1_raw.jpg"
+            },
+            "created_at": "Sat, 21 May 2016 00:15:40 +0000",
+            "checkin_id": 2,
+            "user": {
+              "user_name": "mdlayher"
+            },
+            "beer": {
+              "beer_name": "Beer Name"
+            },
+            "brewery": {
+              "brewery_name": "Brewery Name"
+            },
+            "venue": []
+          }
+        ]
       }
     }
   }
@@ -0,0 +1,80 @@
+package untappd
+
+import (
+	"context"
+	"time"
+)
+
+// A WaitReason describes why a Client is waiting before performing a request.
+type WaitReason string
+
+// WaitReason constants which describe each of the waits performed by a
+// Client.
+const (
+	// WaitRetry is a backoff delay before a failed request is retried,
+	// according to a ServicePolicy.
+	WaitRetry WaitReason = "retry"
+
+	// WaitRateLimit is a delay until the Client's rate limit resets,
+	// because no requests remain in the current hour.
+	WaitRateLimit WaitReason = "rate_limit"
+
+	// WaitSchedule is a delay imposed by a Scheduler, to spread Tasks
+	// evenly across its hourly budget, or by a RatingTracker between
+	// snapshots.
+	WaitSchedule WaitReason = "schedule"
+
+	// WaitOutage is a delay until the estimated end of an outage of the
+	// Untappd APIv4, such as scheduled maintenance, performed by pollers
+	// such as MergedFeed.Run.
+	WaitOutage WaitReason = "outage"
+)
+
+// A WaitEvent describes a single wait performed by a Client.
+type WaitEvent struct {
+	// Reason for the wait, and its expected duration.
+	Reason WaitReason
+	Wait   time.Duration
+
+	// API endpoint of the request which is delayed, if the wait applies to
+	// a single request, such as "beer/info/1".
+	Endpoint string
+
+	// For WaitRetry, the number of the retry which follows the wait,
+	// starting at 1, and the HTTP status code or network error which
+	// caused the previous attempt to fail.
+	Attempt    int
+	StatusCode int
+	Err        error
+}
+
+// A WaitFunc is invoked before each wait performed by a Client.
+type WaitFunc func(ctx context.Context, e WaitEvent)
+
+// WithWaitFunc sets a WaitFunc which is invoked before a Client waits to
+// retry a request, for its rate limit to reset, or for a Scheduler to permit
+// another Task.  Comparing the total duration of these waits with the time
+// spent performing requests reveals when a Client is limited by its policy or
+// rate limit, rather than by the Untappd APIv4.
+//
+// fn may be invoked concurrently by multiple goroutines, and should return
+// quickly, because the wait does not begin until it returns.
+func WithWaitFunc(fn WaitFunc) ClientOption {
+	return func(c *clientConfig) error {
+		c.waitFunc = fn
+		return nil
+	}
+}
+
+// wait reports a WaitEvent to the Client's WaitFunc, if any, and then waits
+// until the input time, or until the context is canceled.  Times which have
+// already passed are not reported.
+func (c *Client) wait(ctx context.Context, e WaitEvent, until time.Time) error {
+	clk := c.clock()
+	e.Wait = until.Sub(clk.Now())
+	if e.Wait > 0 && c.cfg.waitFunc != nil {
+		c.cfg.waitFunc(ctx, e)
+	}
+
+	return sleepUntil(ctx, clk, until)
+}
@@ -0,0 +1,58 @@
+package untappd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestClientWaitFunc verifies that a WaitFunc is invoked before each retry,
+// with the attempt number and the cause of the previous failure.
+func TestClientWaitFunc(t *testing.T) {
+	var requests int
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write(apiErrJSON)
+			return
+		}
+
+		w.Write([]byte("{}"))
+	})
+	defer done()
+
+	var events []WaitEvent
+	applyTestOptions(t, c,
+		WithServicePolicy(ServiceBeer, ServicePolicy{
+			Retries:   2,
+			RetryWait: time.Millisecond,
+		}),
+		WithWaitFunc(func(ctx context.Context, e WaitEvent) {
+			events = append(events, e)
+		}),
+	)
+
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(events); l != 2 {
+		t.Fatalf("unexpected number of wait events: %d != %d", l, 2)
+	}
+	for i, e := range events {
+		if e.Reason != WaitRetry || e.Endpoint != "beer/info/1" || e.Attempt != i+1 {
+			t.Fatalf("unexpected wait event %d: %+v", i, e)
+		}
+		if e.StatusCode != http.StatusServiceUnavailable || e.Err != nil {
+			t.Fatalf("unexpected cause for wait event %d: %+v", i, e)
+		}
+		if e.Wait <= 0 || e.Wait > time.Duration(i+1)*time.Millisecond {
+			t.Fatalf("unexpected duration for wait event %d: %v", i, e.Wait)
+		}
+	}
+	if events[1].Wait <= events[0].Wait/2 {
+		t.Fatalf("expected backoff to increase: %v, %v", events[0].Wait, events[1].Wait)
+	}
+}
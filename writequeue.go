@@ -0,0 +1,481 @@
+package untappd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrWriteQueued is returned by the write methods of a WriteQueue, such
+	// as WriteQueue.Checkin, when a write cannot be sent immediately
+	// because the network is unreachable, the rate limit is exhausted, or
+	// the Untappd APIv4 is down.  The write is stored by the WriteQueue, and
+	// performed by a later Flush.  If the write could not be sent because
+	// of another error, such as a canceled context, ErrWriteQueued wraps
+	// that error as well.
+	ErrWriteQueued = errors.New("write queued")
+
+	// ErrWriteInterrupted is passed to a WriteFunc for a QueuedWrite which
+	// was sent, but whose result was never recorded, such as when a program
+	// crashes while the write is in flight.  The Untappd APIv4 may or may
+	// not have applied the write, so it is not sent again.
+	ErrWriteInterrupted = errors.New("write interrupted after it was sent")
+)
+
+// A WriteKind identifies the operation performed by a QueuedWrite.
+type WriteKind string
+
+// WriteKind constants which correspond to each of the write methods of a
+// WriteQueue.
+const (
+	WriteCheckin WriteKind = "checkin"
+	WriteToast   WriteKind = "toast"
+	WriteComment WriteKind = "comment"
+)
+
+// A QueuedWrite is a single write operation held by a WriteQueue until it
+// can be performed.
+type QueuedWrite struct {
+	// ID of the write, assigned in increasing order by the WriteQueue, and
+	// its operation.
+	ID   int64     `json:"id"`
+	Kind WriteKind `json:"kind"`
+
+	// Time when the write was first requested.
+	Queued time.Time `json:"queued"`
+
+	// For WriteCheckin, the CheckinRequest, with the GMT offset and time
+	// zone of the time the write was requested.
+	Checkin *CheckinRequest `json:"checkin,omitempty"`
+
+	// For WriteToast and WriteComment, the ID of the checkin, and for
+	// WriteComment, the comment.
+	CheckinID int64  `json:"checkin_id,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+
+	// Number of attempts to perform the write which were blocked before
+	// it was sent, and the error which blocked the most recent attempt.
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+
+	// Sent reports whether the write has been sent to the Untappd APIv4.
+	// It is stored before the request begins, so that a write interrupted
+	// by a crash is never sent twice.
+	Sent bool `json:"sent,omitempty"`
+}
+
+// A WriteFunc receives the result of each QueuedWrite which a WriteQueue
+// has sent, or has discarded with ErrWriteInterrupted.  c is the created
+// Checkin, for a successful WriteCheckin.
+type WriteFunc func(w QueuedWrite, c *Checkin, err error)
+
+// A WriteQueueStore persists the QueuedWrites of a WriteQueue, so that they
+// survive restarts of a program.
+type WriteQueueStore interface {
+	// LoadWrites returns the stored QueuedWrites, or none if none are
+	// stored.
+	LoadWrites() ([]QueuedWrite, error)
+
+	// SaveWrites stores QueuedWrites, replacing any previously stored
+	// writes.
+	SaveWrites(writes []QueuedWrite) error
+}
+
+// A WriteQueue performs checkins, toasts, and comments on behalf of the
+// authenticated user, and holds writes which cannot be sent, because the
+// network is unreachable, the rate limit is exhausted, or the Untappd APIv4
+// is down, in a WriteQueueStore until they can be sent, such as for a tool
+// used at a beer festival with no signal.
+//
+// Writes are performed in the order they were requested, and each write is
+// sent at most once.  Only writes which were never sent are queued: a write
+// which fails after its request may have reached the Untappd APIv4, such as
+// with a timeout or a dropped connection, is not retried, because the API
+// may already have applied it.  Retrying would duplicate a checkin or
+// comment, or undo a toast, since the API toggles toasts.  Such failures are
+// returned to the caller, or passed to the WriteFunc of a Flush.
+//
+// The Untappd APIv4 does not accept a time for a checkin, so a queued
+// checkin is recorded at the time it is performed.  Its original GMT offset
+// and time zone are preserved, and the time it was requested is reported in
+// QueuedWrite.Queued.
+//
+// A WriteQueue is safe for concurrent use.
+type WriteQueue struct {
+	client *Client
+	store  WriteQueueStore
+
+	// flushMu serializes flushes, so that writes are performed in order.
+	flushMu sync.Mutex
+
+	mu     sync.Mutex
+	writes []QueuedWrite
+	nextID int64
+
+	// Results of writes performed by any Flush, kept for the submitters
+	// which are waiting for them, keyed by QueuedWrite.ID.
+	waiting map[int64]*writeResult
+}
+
+// A writeResult is the result of a QueuedWrite which has been performed.
+type writeResult struct {
+	done bool
+	c    *Checkin
+	err  error
+}
+
+// NewWriteQueue creates a WriteQueue which performs writes using the input
+// authenticated Client, and restores any writes held by the input
+// WriteQueueStore.  Restored writes are performed by the next Flush.
+func NewWriteQueue(c *Client, s WriteQueueStore) (*WriteQueue, error) {
+	writes, err := s.LoadWrites()
+	if err != nil {
+		return nil, err
+	}
+
+	q := &WriteQueue{
+		client:  c,
+		store:   s,
+		writes:  writes,
+		waiting: make(map[int64]*writeResult),
+	}
+	for _, w := range writes {
+		if w.ID > q.nextID {
+			q.nextID = w.ID
+		}
+	}
+
+	return q, nil
+}
+
+// Checkin checks in a beer, as with AuthService.Checkin.  If the checkin is
+// blocked, or earlier writes are still queued, the checkin is queued and
+// ErrWriteQueued is returned.  Invalid CheckinRequests are rejected without
+// being queued.
+func (q *WriteQueue) Checkin(ctx context.Context, r CheckinRequest) (*Checkin, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	return q.submit(ctx, QueuedWrite{
+		Kind:    WriteCheckin,
+		Checkin: &r,
+	})
+}
+
+// Toast toasts a checkin, as with AuthService.Toast.  If the toast is
+// blocked, or earlier writes are still queued, the toast is queued and
+// ErrWriteQueued is returned.  Because the Untappd APIv4 toggles toasts, a
+// toast which may have been applied is never sent again, even if it was
+// interrupted by a crash.
+func (q *WriteQueue) Toast(ctx context.Context, checkinID int64) error {
+	if err := checkCheckinID(checkinID); err != nil {
+		return err
+	}
+
+	_, err := q.submit(ctx, QueuedWrite{
+		Kind:      WriteToast,
+		CheckinID: checkinID,
+	})
+	return err
+}
+
+// Comment adds a comment to a checkin, as with AuthService.Comment.  If the
+// comment is blocked, or earlier writes are still queued, the comment is
+// queued and ErrWriteQueued is returned.  Invalid comments are rejected
+// without being queued.
+func (q *WriteQueue) Comment(ctx context.Context, checkinID int64, comment string) error {
+	if err := checkComment(checkinID, comment); err != nil {
+		return err
+	}
+
+	_, err := q.submit(ctx, QueuedWrite{
+		Kind:      WriteComment,
+		CheckinID: checkinID,
+		Comment:   comment,
+	})
+	return err
+}
+
+// Pending returns the writes which have not yet been performed, oldest
+// first.
+func (q *WriteQueue) Pending() []QueuedWrite {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]QueuedWrite(nil), q.writes...)
+}
+
+// Flush performs queued writes in order, until the queue is empty or a write
+// cannot be sent, and passes the result of each write to fn, if it is not
+// nil.  A write which cannot be sent, and all writes after it, remain
+// queued, and Flush returns nil.  Writes which are sent are removed from the
+// queue, whether or not they succeed.  Writes which were interrupted after
+// they were sent are removed without being sent again, and passed to fn with
+// ErrWriteInterrupted.
+//
+// Flush returns an error only if the WriteQueueStore fails, or the context is
+// canceled.
+func (q *WriteQueue) Flush(ctx context.Context, fn WriteFunc) error {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	for {
+		q.mu.Lock()
+		if len(q.writes) == 0 {
+			q.mu.Unlock()
+			return nil
+		}
+		w := q.writes[0]
+		q.mu.Unlock()
+
+		var (
+			c   *Checkin
+			err error
+		)
+		if w.Sent {
+			err = ErrWriteInterrupted
+		} else {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := q.client.checkOutage(); err != nil || q.rateLimited() {
+				if err == nil {
+					err = errRateLimitExhausted
+				}
+
+				return q.block(err)
+			}
+
+			// Record that the write is in flight before sending it
+			if err := q.update(func(writes []QueuedWrite) []QueuedWrite {
+				writes[0].Sent = true
+				return writes
+			}); err != nil {
+				return err
+			}
+
+			c, err = q.perform(ctx, w)
+			if writeNotSent(err) {
+				return q.block(err)
+			}
+		}
+
+		if serr := q.update(func(writes []QueuedWrite) []QueuedWrite {
+			return writes[1:]
+		}); serr != nil {
+			return serr
+		}
+
+		q.mu.Lock()
+		if r, ok := q.waiting[w.ID]; ok {
+			r.done, r.c, r.err = true, c, err
+		}
+		q.mu.Unlock()
+
+		if fn != nil {
+			fn(w, c, err)
+		}
+	}
+}
+
+// block records that the first queued write could not be sent, because
+// of err, and leaves it queued.
+func (q *WriteQueue) block(err error) error {
+	return q.update(func(writes []QueuedWrite) []QueuedWrite {
+		writes[0].Sent = false
+		writes[0].Attempts++
+		writes[0].LastError = err.Error()
+		return writes
+	})
+}
+
+// rateLimited reports whether no requests remain in the Client's current
+// rate limit window, so that a write is certain to be rejected.
+func (q *WriteQueue) rateLimited() bool {
+	return q.client.concurrency(1) == 0
+}
+
+// Run flushes the WriteQueue immediately, and then again after each
+// interval, until the context is canceled, passing the result of each write
+// to fn, as with Flush.  While writes are blocked by an outage or by the rate
+// limit, Run waits until the outage is expected to end or the rate limit
+// window resets, if that is later than the next interval.  Run returns the
+// context's error once it is canceled, or the first error returned by the
+// WriteQueueStore.
+func (q *WriteQueue) Run(ctx context.Context, interval time.Duration, fn WriteFunc) error {
+	clk := q.client.clock()
+	for {
+		next := clk.Now().Add(interval)
+		if err := q.Flush(ctx, fn); err != nil {
+			return err
+		}
+
+		if len(q.Pending()) > 0 {
+			if err := q.client.waitOutage(ctx); err != nil {
+				return err
+			}
+			if err := q.client.waitRateLimit(ctx); err != nil {
+				return err
+			}
+		}
+
+		if err := sleepUntil(ctx, clk, next); err != nil {
+			return err
+		}
+	}
+}
+
+// submit queues a write, and then flushes the queue.  If the write is
+// performed, by this flush or by a concurrent one, its result is returned.
+// Otherwise, ErrWriteQueued is returned.
+func (q *WriteQueue) submit(ctx context.Context, w QueuedWrite) (*Checkin, error) {
+	r := &writeResult{}
+	if err := q.update(func(writes []QueuedWrite) []QueuedWrite {
+		q.nextID++
+		w.ID = q.nextID
+		w.Queued = q.client.clock().Now()
+		q.waiting[w.ID] = r
+		return append(writes, w)
+	}); err != nil {
+		q.forget(w.ID)
+		return nil, err
+	}
+
+	err := q.Flush(ctx, nil)
+	q.forget(w.ID)
+
+	switch {
+	case r.done:
+		return r.c, r.err
+	case err != nil:
+		// The write remains queued, so it must not be submitted again
+		return nil, fmt.Errorf("%w: %w", ErrWriteQueued, err)
+	default:
+		return nil, ErrWriteQueued
+	}
+}
+
+// forget stops recording the result of the write with the input ID.
+func (q *WriteQueue) forget(id int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.waiting, id)
+}
+
+// perform performs a single QueuedWrite.
+func (q *WriteQueue) perform(ctx context.Context, w QueuedWrite) (*Checkin, error) {
+	a := &AuthService{client: q.client}
+	switch w.Kind {
+	case WriteCheckin:
+		if w.Checkin == nil {
+			return nil, paramErrorf("checkin", "missing CheckinRequest")
+		}
+
+		c, _, err := a.checkin(ctx, *w.Checkin)
+		return c, err
+	case WriteToast:
+		_, err := a.toast(ctx, w.CheckinID)
+		return nil, err
+	case WriteComment:
+		_, err := a.comment(ctx, w.CheckinID, w.Comment)
+		return nil, err
+	default:
+		return nil, paramErrorf("kind", "unknown write kind %q", w.Kind)
+	}
+}
+
+// update modifies the queued writes using fn, and stores the result.
+func (q *WriteQueue) update(fn func(writes []QueuedWrite) []QueuedWrite) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	writes := fn(append([]QueuedWrite(nil), q.writes...))
+	if err := q.store.SaveWrites(writes); err != nil {
+		return err
+	}
+	q.writes = writes
+
+	return nil
+}
+
+// errRateLimitExhausted blocks a QueuedWrite when no requests remain in the
+// current rate limit window.
+var errRateLimitExhausted = errors.New("rate limit exhausted")
+
+// writeNotSent reports whether a write failed before the Untappd APIv4 could
+// have applied it, so that it may safely be sent again later: when the API is
+// unavailable, the rate limit was exhausted or refused the request, or the
+// connection could not be established.  Other failures after a connection is
+// established, including timeouts, may follow a write which the API applied.
+func writeNotSent(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errRateLimitExhausted) || errors.Is(err, ErrRateLimit) {
+		return true
+	}
+
+	// An outage detected from this request's own failed connection may
+	// still follow a write which the API applied
+	var uerr *ServiceUnavailableError
+	if errors.As(err, &uerr) && (uerr.refused || uerr.StatusCode != 0) {
+		return true
+	}
+
+	var oerr *net.OpError
+	return errors.As(err, &oerr) && oerr.Op == "dial"
+}
+
+// A FileWriteQueueStore is a WriteQueueStore which stores QueuedWrites as
+// JSON in a file.
+type FileWriteQueueStore struct {
+	// Path to the file.  The file is created if it does not exist.
+	Path string
+}
+
+var _ WriteQueueStore = &FileWriteQueueStore{}
+
+// LoadWrites implements WriteQueueStore.  If the file does not exist, no
+// writes are returned.
+func (s *FileWriteQueueStore) LoadWrites() ([]QueuedWrite, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var writes []QueuedWrite
+	if err := json.Unmarshal(b, &writes); err != nil {
+		return nil, err
+	}
+
+	return writes, nil
+}
+
+// SaveWrites implements WriteQueueStore.  The file is replaced atomically,
+// and synced to disk before it replaces the previous file, so that queued
+// writes survive a crash or loss of power.
+func (s *FileWriteQueueStore) SaveWrites(writes []QueuedWrite) error {
+	if writes == nil {
+		writes = []QueuedWrite{}
+	}
+
+	b, err := json.Marshal(writes)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.Path, b)
+}
@@ -0,0 +1,365 @@
+package untappd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestWriteQueue verifies that a WriteQueue stores writes which cannot be
+// sent because the network is unreachable, restores them, and performs them
+// in order once the network is available.
+func TestWriteQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-writequeue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var paths []string
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/v4/checkin/add/":
+			assertBodyParameters(t, r, url.Values{"bid": []string{"1"}, "timezone": []string{"CEST"}})
+			w.Write([]byte(`{"response":{"checkin_id":10,"beer":{"bid":1}}}`))
+		case "/v4/checkin/addcomment/10/":
+			assertBodyParameters(t, r, url.Values{"comment": []string{"cheers"}})
+			w.Write([]byte("{}"))
+		case "/v4/checkin/toast/10/":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(invalidCheckinErrJSON)
+		default:
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+	})
+	defer done()
+
+	// Simulate an unreachable network by failing to dial
+	offline := int32(1)
+	var d net.Dialer
+	applyTestOptions(t, c, WithDialer(func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		if atomic.LoadInt32(&offline) == 1 {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("network is unreachable")}
+		}
+
+		return d.DialContext(ctx, network, addr)
+	}))
+
+	s := &FileWriteQueueStore{Path: filepath.Join(dir, "writes.json")}
+	q, err := NewWriteQueue(c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := q.Checkin(ctx, CheckinRequest{BeerID: 1, GMTOffset: 2, TimeZone: "CEST"}); !errors.Is(err, ErrWriteQueued) {
+		t.Fatalf("unexpected error while offline: %v", err)
+	}
+	if err := q.Comment(ctx, 10, "cheers"); !errors.Is(err, ErrWriteQueued) {
+		t.Fatalf("unexpected error while offline: %v", err)
+	}
+	if err := q.Toast(ctx, 10); !errors.Is(err, ErrWriteQueued) {
+		t.Fatalf("unexpected error while offline: %v", err)
+	}
+
+	// Invalid writes are rejected rather than queued
+	if err := q.Comment(ctx, 10, strings.Repeat("a", maxCommentLength+1)); !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("unexpected error for invalid comment: %v", err)
+	}
+
+	// Restore the writes, as after a restart
+	q, err = NewWriteQueue(c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := q.Pending()
+	if l := len(pending); l != 3 {
+		t.Fatalf("unexpected number of pending writes: %d != %d", l, 3)
+	}
+	if w := pending[0]; w.ID != 1 || w.Kind != WriteCheckin || w.Checkin == nil || w.Checkin.BeerID != 1 ||
+		w.Checkin.GMTOffset != 2 || w.Queued.IsZero() || w.Attempts == 0 || w.LastError == "" || w.Sent {
+		t.Fatalf("unexpected pending checkin: %+v", w)
+	}
+	if w := pending[2]; w.ID != 3 || w.Kind != WriteToast || w.CheckinID != 10 {
+		t.Fatalf("unexpected pending toast: %+v", w)
+	}
+
+	atomic.StoreInt32(&offline, 0)
+
+	var (
+		kinds  []WriteKind
+		failed error
+	)
+	err = q.Flush(ctx, func(w QueuedWrite, c *Checkin, err error) {
+		kinds = append(kinds, w.Kind)
+		if w.Kind == WriteCheckin && (err != nil || c == nil || c.ID != 10) {
+			t.Fatalf("unexpected checkin result: %+v, %v", c, err)
+		}
+		if w.Kind == WriteToast {
+			failed = err
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/v4/checkin/add/", "/v4/checkin/addcomment/10/", "/v4/checkin/toast/10/"}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected requests: %v != %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("unexpected requests: %v != %v", paths, want)
+		}
+	}
+	if l := len(kinds); l != 3 {
+		t.Fatalf("unexpected number of results: %d != %d", l, 3)
+	}
+	var aerr *Error
+	if !errors.As(failed, &aerr) {
+		t.Fatalf("expected API error for failed toast: %v", failed)
+	}
+
+	if l := len(q.Pending()); l != 0 {
+		t.Fatalf("unexpected number of pending writes after flush: %d", l)
+	}
+	writes, err := s.LoadWrites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(writes); l != 0 {
+		t.Fatalf("unexpected number of stored writes after flush: %d", l)
+	}
+
+	// With an empty queue, writes are performed immediately
+	checkin, err := q.Checkin(ctx, CheckinRequest{BeerID: 1, TimeZone: "CEST"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkin.ID != 10 {
+		t.Fatalf("unexpected checkin ID: %d != %d", checkin.ID, 10)
+	}
+}
+
+// TestWriteQueueNotReplayed verifies that a WriteQueue does not queue or
+// replay writes which fail after they are sent, or which were interrupted
+// after they were sent.
+func TestWriteQueueNotReplayed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-writequeue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var requests int32
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		// Simulate a lost connection after the toast is received
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+	})
+	defer done()
+
+	s := &FileWriteQueueStore{Path: filepath.Join(dir, "writes.json")}
+	q, err := NewWriteQueue(c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := q.Toast(ctx, 10); err == nil || errors.Is(err, ErrWriteQueued) {
+		t.Fatalf("unexpected error for lost connection: %v", err)
+	}
+	if l := len(q.Pending()); l != 0 {
+		t.Fatalf("unexpected number of pending writes: %d", l)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("unexpected number of requests: %d != %d", n, 1)
+	}
+
+	// A toast which was in flight during a crash is never sent again
+	if err := s.SaveWrites([]QueuedWrite{{ID: 1, Kind: WriteToast, CheckinID: 10, Sent: true}}); err != nil {
+		t.Fatal(err)
+	}
+	q, err = NewWriteQueue(c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []error
+	if err := q.Flush(ctx, func(_ QueuedWrite, _ *Checkin, err error) {
+		results = append(results, err)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || !errors.Is(results[0], ErrWriteInterrupted) {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("unexpected number of requests: %d != %d", n, 1)
+	}
+	if l := len(q.Pending()); l != 0 {
+		t.Fatalf("unexpected number of pending writes: %d", l)
+	}
+}
+
+// TestWriteQueueNotSent verifies that a WriteQueue keeps writes which the
+// Untappd APIv4 refused without applying them, because its rate limit was
+// exhausted or it was unavailable.
+func TestWriteQueueNotSent(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(w http.ResponseWriter)
+	}{
+		{
+			name: "rate limit",
+			fn: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"meta":{"code":429,"error_detail":"You have reached your API limit.","error_type":"invalid_limit"}}`))
+			},
+		},
+		{
+			name: "rate limit error type",
+			fn: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"meta":{"code":500,"error_detail":"You have reached your API limit.","error_type":"invalid_limit"}}`))
+			},
+		},
+		{
+			name: "maintenance",
+			fn: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"meta":{"code":503,"error_detail":"Scheduled maintenance.","error_type":"maintenance"}}`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				tt.fn(w)
+			})
+			defer done()
+
+			dir, err := ioutil.TempDir("", "untappd-writequeue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			q, err := NewWriteQueue(c, &FileWriteQueueStore{Path: filepath.Join(dir, "writes.json")})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			if err := q.Toast(ctx, 10); !errors.Is(err, ErrWriteQueued) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n := atomic.LoadInt32(&requests); n != 1 {
+				t.Fatalf("unexpected number of requests: %d != %d", n, 1)
+			}
+
+			pending := q.Pending()
+			if l := len(pending); l != 1 {
+				t.Fatalf("unexpected number of pending writes: %d != %d", l, 1)
+			}
+			if w := pending[0]; w.Kind != WriteToast || w.Sent || w.Attempts != 1 {
+				t.Fatalf("unexpected pending toast: %+v", w)
+			}
+		})
+	}
+}
+
+// TestWriteQueueNotSentOutage verifies that a WriteQueue keeps writes which
+// are refused because an outage of the Untappd APIv4 was already known.
+func TestWriteQueueNotSentOutage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-writequeue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var requests int32
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"meta":{"code":503,"error_detail":"Scheduled maintenance.","error_type":"maintenance"}}`))
+	})
+	defer done()
+
+	if _, err := c.request("GET", "beer/info/1", nil, nil, nil); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	q, err := NewWriteQueue(c, &FileWriteQueueStore{Path: filepath.Join(dir, "writes.json")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := q.Comment(ctx, 10, "cheers"); !errors.Is(err, ErrWriteQueued) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("unexpected number of requests: %d != %d", n, 1)
+	}
+	if l := len(q.Pending()); l != 1 {
+		t.Fatalf("unexpected number of pending writes: %d != %d", l, 1)
+	}
+
+	// Writes refused by a request made while the outage is known are kept
+	// as well
+	if !writeNotSent(c.checkOutage()) {
+		t.Fatal("write refused during known outage was not kept")
+	}
+}
+
+// TestWriteQueueCanceled verifies that a WriteQueue reports that a write is
+// queued when it cannot be sent because its context is canceled.
+func TestWriteQueueCanceled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untappd-writequeue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, done := testClient(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %q", r.URL.Path)
+	})
+	defer done()
+
+	q, err := NewWriteQueue(c, &FileWriteQueueStore{Path: filepath.Join(dir, "writes.json")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := q.Toast(ctx, 10); !errors.Is(err, ErrWriteQueued) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error for canceled context: %v", err)
+	}
+	if l := len(q.Pending()); l != 1 {
+		t.Fatalf("unexpected number of pending writes: %d != %d", l, 1)
+	}
+}